@@ -0,0 +1,88 @@
+package streams
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrStreamAbandoned is returned when a Stream was closed because Read was not called
+	// for longer than AbandonConfig.IdleTimeout.
+	ErrStreamAbandoned = fmt.Errorf("client: stream closed, not read for too long")
+
+	// ErrStreamLifetimeExceeded is returned when a Stream was closed after AbandonConfig.MaxLifetime.
+	ErrStreamLifetimeExceeded = fmt.Errorf("client: stream closed, maximum lifetime exceeded")
+)
+
+// AbandonConfig enables detecting Streams no longer read by any consumer,
+// for example forgotten subscriptions in long-lived services.
+// A Stream is abandoned when no Read call is in progress and none returned for IdleTimeout.
+type AbandonConfig struct {
+	IdleTimeout time.Duration // Idle duration after which the Stream is abandoned, disabled if 0
+	MaxLifetime time.Duration // Duration after which the Stream is closed, disabled if 0
+	AutoClose   bool          // Close abandoned Streams with ErrStreamAbandoned
+
+	// OnAbandoned is called once each time the Stream becomes abandoned with its idle duration.
+	OnAbandoned func(idle time.Duration)
+}
+
+// readStarted records a Read call start, the returned function records its end.
+func (s *stream) readStarted() (done func()) {
+	s.readers.Add(1)
+	s.lastRead.Store(time.Now().UnixNano())
+	return func() {
+		s.lastRead.Store(time.Now().UnixNano())
+		s.readers.Add(-1)
+	}
+}
+
+func (s *stream) monitorAbandon() {
+	cfg := s.config.Abandon
+
+	var lifetime <-chan time.Time
+	if cfg.MaxLifetime > 0 {
+		timer := time.NewTimer(cfg.MaxLifetime)
+		defer timer.Stop()
+		lifetime = timer.C
+	}
+
+	var idle <-chan time.Time
+	if cfg.IdleTimeout > 0 {
+		ticker := time.NewTicker(max(cfg.IdleTimeout/4, time.Millisecond))
+		defer ticker.Stop()
+		idle = ticker.C
+	}
+
+	var abandoned bool
+	for {
+		select {
+		case <-s.streamCtx.Done():
+			return
+
+		case <-lifetime:
+			s.config.logInfo("client: stream maximum lifetime %s exceeded, closing", cfg.MaxLifetime)
+			s.fail(ErrStreamLifetimeExceeded)
+			return
+
+		case <-idle:
+			d := time.Since(time.Unix(0, s.lastRead.Load()))
+			if s.readers.Load() > 0 || d < cfg.IdleTimeout {
+				abandoned = false
+				continue
+			}
+			if abandoned {
+				continue
+			}
+			abandoned = true
+
+			s.config.logInfo("client: stream not read for %s, abandoned", d.Truncate(time.Millisecond))
+			if cfg.OnAbandoned != nil {
+				go cfg.OnAbandoned(d)
+			}
+			if cfg.AutoClose {
+				s.fail(ErrStreamAbandoned)
+				return
+			}
+		}
+	}
+}
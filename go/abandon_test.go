@@ -0,0 +1,84 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestClient_StreamAbandoned(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	tests := []struct {
+		name    string
+		cfg     AbandonConfig
+		reading bool
+		wantErr error
+	}{
+		{name: "idle", cfg: AbandonConfig{IdleTimeout: 50 * time.Millisecond, AutoClose: true}, wantErr: ErrStreamAbandoned},
+		{name: "blocked reader", cfg: AbandonConfig{IdleTimeout: 50 * time.Millisecond, AutoClose: true}, reading: true},
+		{name: "lifetime", cfg: AbandonConfig{MaxLifetime: 50 * time.Millisecond}, wantErr: ErrStreamLifetimeExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streamsClient, err := ms.Client()
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+			abandoned := make(chan time.Duration, 1)
+			tt.cfg.OnAbandoned = func(idle time.Duration) { abandoned <- idle }
+			streamsClient.(*client).config.Abandon = &tt.cfg
+
+			sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1})
+			if err != nil {
+				t.Fatalf("error subscribing %s", err)
+			}
+			defer sub.Close()
+
+			if tt.reading {
+				ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+				defer cancel()
+				if _, err = sub.Read(ctx); !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("Read() error = %v, want %v", err, context.DeadlineExceeded)
+				}
+				select {
+				case idle := <-abandoned:
+					t.Errorf("stream abandoned after %s while being read", idle)
+				default:
+				}
+				return
+			}
+
+			if tt.wantErr == ErrStreamAbandoned {
+				if idle := <-abandoned; idle < tt.cfg.IdleTimeout {
+					t.Errorf("OnAbandoned() idle = %s, want at least %s", idle, tt.cfg.IdleTimeout)
+				}
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			if _, err = sub.Read(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Read() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
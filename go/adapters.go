@@ -0,0 +1,170 @@
+package streams
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+)
+
+// Reports returns an iterator over the reports read from the Stream.
+// Iteration stops when the consumer stops iterating or after yielding the first
+// Read error, with a nil report.
+func Reports(ctx context.Context, s Stream) iter.Seq2[*ReportResponse, error] {
+	return func(yield func(*ReportResponse, error) bool) {
+		for {
+			r, err := s.Read(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Channel returns a channel delivering the reports read from the Stream.
+// The channel is closed when the context is done or the Stream fails or is closed.
+func Channel(ctx context.Context, s Stream) <-chan *ReportResponse {
+	ch := make(chan *ReportResponse)
+	go func() {
+		defer close(ch)
+		for r, err := range Reports(ctx, s) {
+			if err != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- r:
+			}
+		}
+	}()
+	return ch
+}
+
+// NewNDJSONReader returns an io.Reader of the reports read from the Stream
+// encoded as newline delimited JSON.
+// The reader returns io.EOF once the Stream is closed.
+func NewNDJSONReader(ctx context.Context, s Stream) io.Reader {
+	return &ndjsonReader{ctx: ctx, stream: s}
+}
+
+type ndjsonReader struct {
+	ctx    context.Context
+	stream Stream
+	buf    bytes.Buffer
+}
+
+func (r *ndjsonReader) Read(p []byte) (n int, err error) {
+	if r.buf.Len() == 0 {
+		rep, err := r.stream.Read(r.ctx)
+		if errors.Is(err, ErrStreamClosed) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		b, err := rep.MarshalJSON()
+		if err != nil {
+			return 0, err
+		}
+		r.buf.Write(b)
+		r.buf.WriteByte('\n')
+	}
+	return r.buf.Read(p)
+}
+
+// RecordCodec is the record format of the reports written by ExtendedStream.WriteRecords.
+type RecordCodec int
+
+const (
+	// CodecNDJSON writes each report as a JSON object followed by a newline.
+	CodecNDJSON RecordCodec = iota
+	// CodecLengthPrefixed writes each report as a JSON object prefixed by its length
+	// as a 4 bytes big endian unsigned integer.
+	CodecLengthPrefixed
+)
+
+// WriteTo writes the reports read from the Stream to w in the codec record format,
+// see ExtendedStream.WriteRecords.
+func WriteTo(ctx context.Context, s Stream, w io.Writer, codec RecordCodec) (n int64, err error) {
+	if es, ok := s.(ExtendedStream); ok {
+		return es.WriteRecords(ctx, w, codec)
+	}
+	return writeRecords(ctx, s, w, codec)
+}
+
+func (s *stream) WriteRecords(ctx context.Context, w io.Writer, codec RecordCodec) (n int64, err error) {
+	return writeRecords(ctx, s, w, codec)
+}
+
+// WriteTo implements io.WriterTo, writing the reports as newline delimited JSON
+// until the Stream is closed or a write fails.
+func (s *stream) WriteTo(w io.Writer) (n int64, err error) {
+	return writeRecords(context.Background(), s, w, CodecNDJSON)
+}
+
+func writeRecords(ctx context.Context, s Stream, w io.Writer, codec RecordCodec) (n int64, err error) {
+	if codec != CodecNDJSON && codec != CodecLengthPrefixed {
+		return 0, fmt.Errorf("client: unknown record codec %d", codec)
+	}
+
+	var buf []byte
+	for r, err := range Reports(ctx, s) {
+		if errors.Is(err, ErrStreamClosed) {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+
+		b, err := r.MarshalJSON()
+		if err != nil {
+			return n, err
+		}
+
+		buf = buf[:0]
+		switch codec {
+		case CodecNDJSON:
+			buf = append(append(buf, b...), '\n')
+		case CodecLengthPrefixed:
+			buf = append(binary.BigEndian.AppendUint32(buf, uint32(len(b))), b...)
+		}
+
+		written, err := w.Write(buf)
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// ReadBatch reads up to n reports from the Stream. ReadBatch blocks until the first
+// report is read and then waits at most wait for the following reports.
+// The reports read before a Read error are returned with a nil error.
+func ReadBatch(ctx context.Context, s Stream, n int, wait time.Duration) (batch []*ReportResponse, err error) {
+	r, err := s.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	batch = append(batch, r)
+
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	for len(batch) < n {
+		if r, err = s.Read(ctx); err != nil {
+			break
+		}
+		batch = append(batch, r)
+	}
+	return batch, nil
+}
@@ -0,0 +1,213 @@
+package streams
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+type mockStream struct {
+	Stream
+	reports []*ReportResponse
+}
+
+func (m *mockStream) Read(ctx context.Context) (r *ReportResponse, err error) {
+	if len(m.reports) == 0 {
+		return nil, ErrStreamClosed
+	}
+	r, m.reports = m.reports[0], m.reports[1:]
+	return r, nil
+}
+
+var adapterReports = []*ReportResponse{
+	{FeedID: feed1, FullReport: []byte{1}, ObservationsTimestamp: 1},
+	{FeedID: feed2, FullReport: []byte{2}, ObservationsTimestamp: 2},
+}
+
+func TestReports(t *testing.T) {
+	var got []*ReportResponse
+	var lastErr error
+	for r, err := range Reports(context.Background(), &mockStream{reports: adapterReports}) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		got = append(got, r)
+	}
+
+	if !reflect.DeepEqual(got, adapterReports) {
+		t.Errorf("Reports() = %v, want %v", got, adapterReports)
+	}
+	if lastErr != ErrStreamClosed {
+		t.Errorf("expected error %s, got %v", ErrStreamClosed, lastErr)
+	}
+}
+
+func TestChannel(t *testing.T) {
+	var got []*ReportResponse
+	for r := range Channel(context.Background(), &mockStream{reports: adapterReports}) {
+		got = append(got, r)
+	}
+
+	if !reflect.DeepEqual(got, adapterReports) {
+		t.Errorf("Channel() = %v, want %v", got, adapterReports)
+	}
+}
+
+func TestNewNDJSONReader(t *testing.T) {
+	b, err := io.ReadAll(NewNDJSONReader(context.Background(), &mockStream{reports: adapterReports}))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %s", err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(b)))
+	var got []*ReportResponse
+	for scanner.Scan() {
+		r := &ReportResponse{}
+		if err = r.UnmarshalJSON(scanner.Bytes()); err != nil {
+			t.Fatalf("UnmarshalJSON() error = %s", err)
+		}
+		got = append(got, r)
+	}
+
+	if !reflect.DeepEqual(got, adapterReports) {
+		t.Errorf("NewNDJSONReader() = %v, want %v", got, adapterReports)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	for _, codec := range []RecordCodec{CodecNDJSON, CodecLengthPrefixed} {
+		var buf bytes.Buffer
+		n, err := WriteTo(context.Background(), &mockStream{reports: adapterReports}, &buf, codec)
+		if err != nil {
+			t.Fatalf("WriteTo() error = %s", err)
+		}
+		if n != int64(buf.Len()) {
+			t.Errorf("WriteTo() = %d, want %d", n, buf.Len())
+		}
+
+		var got []*ReportResponse
+		for buf.Len() > 0 {
+			var b []byte
+			switch codec {
+			case CodecNDJSON:
+				if b, err = buf.ReadBytes('\n'); err != nil {
+					t.Fatalf("ReadBytes() error = %s", err)
+				}
+			case CodecLengthPrefixed:
+				b = make([]byte, binary.BigEndian.Uint32(buf.Next(4)))
+				_, _ = buf.Read(b)
+			}
+			r := &ReportResponse{}
+			if err = r.UnmarshalJSON(b); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %s", err)
+			}
+			got = append(got, r)
+		}
+
+		if !reflect.DeepEqual(got, adapterReports) {
+			t.Errorf("WriteTo(%d) = %v, want %v", codec, got, adapterReports)
+		}
+	}
+
+	if _, err := WriteTo(context.Background(), &mockStream{}, io.Discard, RecordCodec(9)); err == nil {
+		t.Errorf("WriteTo() error = nil for an unknown codec, want error")
+	}
+}
+
+// closingWriter closes the Stream after n writes.
+type closingWriter struct {
+	bytes.Buffer
+	stream Stream
+	n      int
+}
+
+func (w *closingWriter) Write(p []byte) (int, error) {
+	if w.n--; w.n == 0 {
+		_ = w.stream.Close()
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestStream_WriteTo(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for x := 1; x <= 2; x++ {
+			b, err := json.Marshal(&message{&ReportResponse{FeedID: feed1, ObservationsTimestamp: uint64(x)}})
+			if err != nil {
+				t.Errorf("failed to serialize message: %s", err)
+			}
+			if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+				return
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	w := &closingWriter{stream: sub, n: 2}
+	n, err := sub.(io.WriterTo).WriteTo(w)
+	if err != nil || n != int64(w.Len()) {
+		t.Fatalf("WriteTo() = %d, %v, want %d, nil", n, err, w.Len())
+	}
+	if lines := strings.Count(w.String(), "\n"); lines != 2 {
+		t.Errorf("WriteTo() wrote %d records, want 2", lines)
+	}
+}
+
+func TestReadBatch(t *testing.T) {
+	s := &mockStream{reports: adapterReports}
+
+	batch, err := ReadBatch(context.Background(), s, 1, time.Second)
+	if err != nil {
+		t.Fatalf("ReadBatch() error = %v", err)
+	}
+	if !reflect.DeepEqual(batch, adapterReports[:1]) {
+		t.Errorf("ReadBatch() = %v, want %v", batch, adapterReports[:1])
+	}
+
+	// the stream is closed after the last report
+	if batch, err = ReadBatch(context.Background(), s, 10, time.Second); err != nil {
+		t.Fatalf("ReadBatch() error = %v", err)
+	}
+	if !reflect.DeepEqual(batch, adapterReports[1:]) {
+		t.Errorf("ReadBatch() = %v, want %v", batch, adapterReports[1:])
+	}
+
+	if _, err = ReadBatch(context.Background(), s, 10, time.Second); err != ErrStreamClosed {
+		t.Errorf("ReadBatch() error = %v, want %v", err, ErrStreamClosed)
+	}
+}
@@ -0,0 +1,143 @@
+package streams
+
+import (
+	"time"
+)
+
+const (
+	defaultAlertWindow        = time.Hour
+	defaultAlertCheckInterval = 10 * time.Second
+)
+
+// Alert names
+const (
+	AlertDedupRatio        = "dedup_ratio"
+	AlertFullReconnects    = "full_reconnects"
+	AlertPartialReconnects = "partial_reconnects"
+)
+
+// AlertConfig specifies thresholds on the Stream stats evaluated over a rolling window.
+// OnAlert is called once when a threshold is crossed and once when the value is back within
+// the threshold. A zero threshold disables the respective alert.
+type AlertConfig struct {
+	MaxDedupRatio        float64       // Maximum ratio (0-1] of deduplicated to received reports
+	MaxFullReconnects    uint64        // Maximum number of full reconnects
+	MaxPartialReconnects uint64        // Maximum number of partial reconnects
+	Window               time.Duration // Rolling window the thresholds are evaluated over, defaults to 1h
+	CheckInterval        time.Duration // Interval between evaluations, defaults to 10s
+
+	// OnAlert is called with each alert raised or resolved, from a single goroutine.
+	OnAlert func(Alert)
+}
+
+// Alert describes a Stream stats threshold crossing.
+type Alert struct {
+	Name      string        // AlertDedupRatio, AlertFullReconnects or AlertPartialReconnects
+	Value     float64       // Observed value over the window
+	Threshold float64       // Configured threshold
+	Window    time.Duration // Window the value was observed over, shorter than the configured window after the Stream start
+	Resolved  bool          // The value is back within the threshold
+	At        time.Time     // Time the alert was evaluated
+	Labels    Labels        // Stream labels
+}
+
+type alertSample struct {
+	at    time.Time
+	stats Stats
+}
+
+// alertTracker evaluates the alert thresholds over the samples of the Stream stats in the window.
+type alertTracker struct {
+	cfg     AlertConfig
+	labels  Labels
+	samples []alertSample
+	raised  map[string]bool
+}
+
+func newAlertTracker(cfg AlertConfig, labels Labels) *alertTracker {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultAlertWindow
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultAlertCheckInterval
+	}
+	return &alertTracker{cfg: cfg, labels: labels, raised: map[string]bool{}}
+}
+
+// evaluate records the stats sample and returns the alerts raised or resolved since the previous evaluation.
+func (t *alertTracker) evaluate(now time.Time, st Stats) (alerts []Alert) {
+	t.samples = append(t.samples, alertSample{at: now, stats: st})
+	// keep the newest sample outside of the window as the window start
+	for len(t.samples) > 1 && now.Sub(t.samples[1].at) >= t.cfg.Window {
+		t.samples = t.samples[1:]
+	}
+	if len(t.samples) < 2 {
+		return nil
+	}
+
+	first := t.samples[0]
+	window := now.Sub(first.at)
+
+	if t.cfg.MaxDedupRatio > 0 {
+		var ratio float64
+		if received := st.TotalReceived - first.stats.TotalReceived; received > 0 {
+			ratio = float64(st.Deduplicated-first.stats.Deduplicated) / float64(received)
+		}
+		alerts = t.check(alerts, AlertDedupRatio, ratio, t.cfg.MaxDedupRatio, window, now)
+	}
+	if t.cfg.MaxFullReconnects > 0 {
+		n := float64(st.FullReconnects - first.stats.FullReconnects)
+		alerts = t.check(alerts, AlertFullReconnects, n, float64(t.cfg.MaxFullReconnects), window, now)
+	}
+	if t.cfg.MaxPartialReconnects > 0 {
+		n := float64(st.PartialReconnects - first.stats.PartialReconnects)
+		alerts = t.check(alerts, AlertPartialReconnects, n, float64(t.cfg.MaxPartialReconnects), window, now)
+	}
+	return alerts
+}
+
+// check appends an alert if the value crossed the threshold in either direction.
+func (t *alertTracker) check(alerts []Alert, name string, value, threshold float64,
+	window time.Duration, now time.Time) []Alert {
+	exceeded := value > threshold
+	if exceeded == t.raised[name] {
+		return alerts
+	}
+	t.raised[name] = exceeded
+	return append(alerts, Alert{
+		Name:      name,
+		Value:     value,
+		Threshold: threshold,
+		Window:    window,
+		Resolved:  !exceeded,
+		At:        now,
+		Labels:    t.labels,
+	})
+}
+
+func (s *stream) monitorAlerts() {
+	tracker := newAlertTracker(*s.config.Alerts, s.labels)
+	ticker := time.NewTicker(tracker.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	tracker.evaluate(time.Now(), s.Stats())
+	for {
+		select {
+		case <-s.streamCtx.Done():
+			return
+		case <-ticker.C:
+			for _, a := range tracker.evaluate(time.Now(), s.Stats()) {
+				if a.Resolved {
+					s.config.logInfo("client: stream alert %s resolved, %g within %g over %s",
+						a.Name, a.Value, a.Threshold, a.Window.Truncate(time.Second))
+				} else {
+					s.config.logInfo("client: stream alert %s raised, %g above %g over %s",
+						a.Name, a.Value, a.Threshold, a.Window.Truncate(time.Second))
+				}
+				if s.config.Alerts.OnAlert != nil {
+					s.config.Alerts.OnAlert(a)
+				}
+			}
+		}
+	}
+}
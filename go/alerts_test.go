@@ -0,0 +1,49 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAlertTracker(t *testing.T) {
+	labels := Labels{"service": "pricer"}
+	tracker := newAlertTracker(AlertConfig{
+		MaxDedupRatio:     0.9,
+		MaxFullReconnects: 2,
+		Window:            time.Minute,
+	}, labels)
+	start := time.Unix(1700000000, 0)
+
+	type alert struct {
+		name     string
+		resolved bool
+	}
+	steps := []struct {
+		at    time.Duration
+		stats Stats
+		want  []alert
+	}{
+		{at: 0, stats: Stats{}},
+		{at: 10 * time.Second, stats: Stats{TotalReceived: 100, Deduplicated: 50, FullReconnects: 1}},
+		{at: 20 * time.Second, stats: Stats{TotalReceived: 1000, Deduplicated: 950, FullReconnects: 3},
+			want: []alert{{name: AlertDedupRatio}, {name: AlertFullReconnects}}},
+		// still above the thresholds, no new alerts
+		{at: 30 * time.Second, stats: Stats{TotalReceived: 1100, Deduplicated: 1040, FullReconnects: 3}},
+		// the window moved past the deduplications and reconnects
+		{at: 90 * time.Second, stats: Stats{TotalReceived: 2100, Deduplicated: 1540, FullReconnects: 3},
+			want: []alert{{name: AlertDedupRatio, resolved: true}, {name: AlertFullReconnects, resolved: true}}},
+	}
+	for x, step := range steps {
+		var got []alert
+		for _, a := range tracker.evaluate(start.Add(step.at), step.stats) {
+			got = append(got, alert{name: a.Name, resolved: a.Resolved})
+			if !reflect.DeepEqual(a.Labels, labels) || !a.At.Equal(start.Add(step.at)) || a.Window <= 0 {
+				t.Errorf("step %d: alert %+v, want labels, time and window set", x, a)
+			}
+		}
+		if !reflect.DeepEqual(got, step.want) {
+			t.Errorf("step %d: evaluate() = %v, want %v", x, got, step.want)
+		}
+	}
+}
@@ -1,32 +1,35 @@
 package streams
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
-	"fmt"
 	"net/http"
-	"strconv"
+	"net/url"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/auth"
+)
+
+// Auth query parameters used when the signature is placed in the url.
+const (
+	authzKeyParam     = auth.ParamAPIKey
+	authzTSParam      = auth.ParamTimestamp
+	authzExpiresParam = auth.ParamExpires
+	authzSigParam     = auth.ParamSignature
 )
 
+// authScheme returns the signature scheme of the client requests.
+func (c Config) authScheme() auth.Scheme {
+	return auth.Scheme{BodyMethods: c.AuthBodyMethods}
+}
+
 func generateHMAC(method string, path string, body []byte, clientId string, timestamp int64, userSecret string) string {
-	serverBodyHash := sha256.New()
-	serverBodyHash.Write(body)
-	serverBodyHashString := fmt.Sprintf("%s %s %s %s %d",
-		method,
-		path,
-		hex.EncodeToString(serverBodyHash.Sum(nil)),
-		clientId,
-		timestamp)
-	signedMessage := hmac.New(sha256.New, []byte(userSecret))
-	signedMessage.Write([]byte(serverBodyHashString))
-	userHmac := hex.EncodeToString(signedMessage.Sum(nil))
-	return userHmac
+	return auth.Scheme{}.Sign(method, path, body, clientId, userSecret, timestamp)
+}
+
+func generateAuthHeaders(s auth.Scheme, h http.Header, method string, path string, body []byte, clientId string, userSecret string, timestamp int64) {
+	s.SignHeaders(h, method, path, body, clientId, userSecret, timestamp)
 }
 
-func generateAuthHeaders(h http.Header, method string, path string, body []byte, clientId string, userSecret string, timestamp int64) {
-	hmacString := generateHMAC(method, path, body, clientId, timestamp, userSecret)
-	h.Add(authzHeader, clientId)
-	h.Add(authzTSHeader, strconv.FormatInt(timestamp, 10))
-	h.Add(authzSigHeader, hmacString)
+// generateAuthQuery signs the url with an expiry adding the signature to its query parameters.
+// The signed path includes the expires parameter.
+func generateAuthQuery(s auth.Scheme, u *url.URL, method string, body []byte, clientId string, userSecret string, timestamp int64, expires int64) {
+	s.SignURL(u, method, body, clientId, userSecret, timestamp, expires)
 }
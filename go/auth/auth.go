@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Signature headers
+const (
+	HeaderAPIKey    = "Authorization"
+	HeaderTimestamp = "X-Authorization-Timestamp"
+	HeaderSignature = "X-Authorization-Signature-SHA256"
+)
+
+// Signature query parameters, see Scheme.SignURL.
+const (
+	ParamAPIKey    = "apiKey"
+	ParamTimestamp = "timestamp"
+	ParamExpires   = "expires"
+	ParamSignature = "signature"
+)
+
+// Verification errors, see Scheme.Verify.
+var (
+	ErrNoSignature      = errors.New("auth: no signature")      // Request neither signed in the headers nor the url
+	ErrInvalidSignature = errors.New("auth: invalid signature") // Signature or its parameters invalid
+	ErrUnknownAPIKey    = errors.New("auth: unknown API key")   // No secret for the API key
+	ErrExpired          = errors.New("auth: signature expired") // Timestamp too old or too far ahead, or url expired
+)
+
+// Scheme is the canonicalization of the signed requests.
+// The zero Scheme is the one of the Data Streams servers, used by the client unless configured otherwise.
+type Scheme struct {
+	// BodyMethods lists the HTTP methods whose request body is hashed in the string to sign,
+	// the other methods being signed with the hash of the empty body whatever their body.
+	// The body of every method is hashed if empty.
+	BodyMethods []string
+}
+
+// BodyHash returns the lowercase hex SHA-256 of the request body of the method, of the empty body
+// if the method body is not hashed.
+func (s Scheme) BodyHash(method string, body []byte) string {
+	if len(s.BodyMethods) > 0 && !slices.Contains(s.BodyMethods, method) {
+		body = nil
+	}
+	h := sha256.Sum256(body)
+	return hex.EncodeToString(h[:])
+}
+
+// StringToSign returns the canonical string signed for the request, path being its request URI.
+func (s Scheme) StringToSign(method, path string, body []byte, apiKey string, timestamp int64) string {
+	return fmt.Sprintf("%s %s %s %s %d", method, path, s.BodyHash(method, body), apiKey, timestamp)
+}
+
+// Sign returns the lowercase hex signature of the request, path being its request URI.
+func (s Scheme) Sign(method, path string, body []byte, apiKey, secret string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(s.StringToSign(method, path, body, apiKey, timestamp)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignHeaders adds the API key, timestamp and signature headers of the request, path being its request URI.
+func (s Scheme) SignHeaders(h http.Header, method, path string, body []byte, apiKey, secret string, timestamp int64) {
+	h.Add(HeaderAPIKey, apiKey)
+	h.Add(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	h.Add(HeaderSignature, s.Sign(method, path, body, apiKey, secret, timestamp))
+}
+
+// SignURL signs the request url adding the signature to its query parameters, for clients unable
+// to set the request headers. The signed request URI has its query parameters sorted by key and
+// includes the expires parameter, the Unix time in milliseconds after which the signed url is rejected,
+// but not the apiKey, timestamp and signature parameters added afterwards.
+func (s Scheme) SignURL(u *url.URL, method string, body []byte, apiKey, secret string, timestamp, expires int64) {
+	q := u.Query()
+	q.Set(ParamExpires, strconv.FormatInt(expires, 10))
+	u.RawQuery = q.Encode()
+
+	sig := s.Sign(method, u.RequestURI(), body, apiKey, secret, timestamp)
+	q.Set(ParamAPIKey, apiKey)
+	q.Set(ParamTimestamp, strconv.FormatInt(timestamp, 10))
+	q.Set(ParamSignature, sig)
+	u.RawQuery = q.Encode()
+}
+
+// Verify verifies the signature of the received request with the body read from it, signed in the headers
+// or the url query, returning the API key of the request. The secret function returns the secret of an API key,
+// false if unknown. The signature timestamp must be within maxSkew of now unless maxSkew is 0, and signed urls
+// must not be expired.
+func (s Scheme) Verify(r *http.Request, body []byte, secret func(apiKey string) (string, bool),
+	now time.Time, maxSkew time.Duration) (apiKey string, err error) {
+	path := r.URL.RequestURI()
+	apiKey, ts, sig := r.Header.Get(HeaderAPIKey), r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature)
+	if apiKey == "" && sig == "" {
+		q := r.URL.Query()
+		apiKey, ts, sig = q.Get(ParamAPIKey), q.Get(ParamTimestamp), q.Get(ParamSignature)
+		if sig == "" {
+			return "", ErrNoSignature
+		}
+
+		expires, err := strconv.ParseInt(q.Get(ParamExpires), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid expires %q", ErrInvalidSignature, q.Get(ParamExpires))
+		}
+		if now.After(time.UnixMilli(expires)) {
+			return "", fmt.Errorf("%w: url expired at %s", ErrExpired, time.UnixMilli(expires).UTC())
+		}
+
+		for _, p := range []string{ParamAPIKey, ParamTimestamp, ParamSignature} {
+			q.Del(p)
+		}
+		u := *r.URL
+		u.RawQuery = q.Encode()
+		path = u.RequestURI()
+	}
+
+	timestamp, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid timestamp %q", ErrInvalidSignature, ts)
+	}
+	if skew := now.Sub(time.UnixMilli(timestamp)).Abs(); maxSkew > 0 && skew > maxSkew {
+		return "", fmt.Errorf("%w: timestamp %d off by %s", ErrExpired, timestamp, skew)
+	}
+
+	key, ok := secret(apiKey)
+	if !ok {
+		return "", ErrUnknownAPIKey
+	}
+	if !hmac.Equal([]byte(sig), []byte(s.Sign(r.Method, path, body, apiKey, key, timestamp))) {
+		return "", ErrInvalidSignature
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const emptyBodyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func TestScheme_StringToSign(t *testing.T) {
+	body := []byte(`{"attr1": "value1","attr2": [1,2,3]}`)
+	bodyHash := Scheme{}.BodyHash(http.MethodPost, body)
+
+	tests := []struct {
+		name   string
+		scheme Scheme
+		method string
+		path   string
+		body   []byte
+		want   string
+	}{
+		{
+			name:   "empty body",
+			method: http.MethodGet,
+			path:   "/api/v1/feeds",
+			want:   "GET /api/v1/feeds " + emptyBodyHash + " clientId 1718885772",
+		},
+		{
+			name:   "query string",
+			method: http.MethodGet,
+			path:   "/api/v1/reports?feedID=0x0003&timestamp=1718885772",
+			want:   "GET /api/v1/reports?feedID=0x0003&timestamp=1718885772 " + emptyBodyHash + " clientId 1718885772",
+		},
+		{
+			name:   "body",
+			method: http.MethodPost,
+			path:   "/api/v1/reports/bulk",
+			body:   body,
+			want:   "POST /api/v1/reports/bulk " + bodyHash + " clientId 1718885772",
+		},
+		{
+			name:   "body of a hashed method",
+			scheme: Scheme{BodyMethods: []string{http.MethodPost}},
+			method: http.MethodPost,
+			path:   "/api/v1/reports/bulk",
+			body:   body,
+			want:   "POST /api/v1/reports/bulk " + bodyHash + " clientId 1718885772",
+		},
+		{
+			name:   "body of a method not hashed",
+			scheme: Scheme{BodyMethods: []string{http.MethodPost}},
+			method: http.MethodGet,
+			path:   "/api/v1/feeds",
+			body:   body,
+			want:   "GET /api/v1/feeds " + emptyBodyHash + " clientId 1718885772",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scheme.StringToSign(tt.method, tt.path, tt.body, "clientId", 1718885772); got != tt.want {
+				t.Errorf("StringToSign() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheme_Sign(t *testing.T) {
+	got := Scheme{}.Sign(http.MethodGet, "/api/v1/feeds", nil, "clientId", "userSecret", 1718885772)
+	if want := "e9b2aa1deb13b2abd078353a5e335b2f50307159ad28b433157d2c74dbab2072"; got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+}
+
+func TestScheme_SignURL(t *testing.T) {
+	u, _ := url.Parse("wss://ws.domain.link/api/v1/ws?feedIDs=0x0003")
+	Scheme{}.SignURL(u, http.MethodGet, nil, "clientId", "userSecret", 1718885772000, 1718885832000)
+
+	q := u.Query()
+	if q.Get(ParamAPIKey) != "clientId" || q.Get(ParamTimestamp) != "1718885772000" || q.Get(ParamExpires) != "1718885832000" {
+		t.Errorf("SignURL() query = %s, want the API key, timestamp and expires", u.RawQuery)
+	}
+	path := "/api/v1/ws?expires=1718885832000&feedIDs=0x0003"
+	if want := (Scheme{}).Sign(http.MethodGet, path, nil, "clientId", "userSecret", 1718885772000); q.Get(ParamSignature) != want {
+		t.Errorf("SignURL() signature = %s, want the signature of %s", q.Get(ParamSignature), path)
+	}
+}
+
+func TestScheme_Verify(t *testing.T) {
+	now := time.UnixMilli(1718885772000)
+	body := []byte(`{"feedIDs":["0x0003"]}`)
+	secrets := func(apiKey string) (string, bool) {
+		return "userSecret", apiKey == "clientId"
+	}
+
+	signed := func(method, target string, body []byte, apiKey string, at time.Time) *http.Request {
+		r := httptest.NewRequest(method, target, bytes.NewReader(body))
+		Scheme{}.SignHeaders(r.Header, method, r.URL.RequestURI(), body, apiKey, "userSecret", at.UnixMilli())
+		return r
+	}
+	signedURL := func(target string, expires time.Time) *http.Request {
+		u, _ := url.Parse(target)
+		Scheme{}.SignURL(u, http.MethodGet, nil, "clientId", "userSecret", now.UnixMilli(), expires.UnixMilli())
+		return httptest.NewRequest(http.MethodGet, u.String(), nil)
+	}
+
+	tests := []struct {
+		name    string
+		r       *http.Request
+		body    []byte
+		wantErr error
+	}{
+		{name: "headers", r: signed(http.MethodGet, "/api/v1/feeds", nil, "clientId", now)},
+		{name: "query string", r: signed(http.MethodGet, "/api/v1/reports?feedID=0x0003&timestamp=1", nil, "clientId", now)},
+		{name: "body", r: signed(http.MethodPost, "/api/v1/reports/bulk", body, "clientId", now), body: body},
+		{name: "url", r: signedURL("/api/v1/ws?feedIDs=0x0003", now.Add(time.Minute))},
+		{
+			name:    "no signature",
+			r:       httptest.NewRequest(http.MethodGet, "/api/v1/feeds", nil),
+			wantErr: ErrNoSignature,
+		},
+		{
+			name:    "tampered body",
+			r:       signed(http.MethodPost, "/api/v1/reports/bulk", body, "clientId", now),
+			body:    []byte(`{"feedIDs":["0x0004"]}`),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "tampered query string",
+			r: func() *http.Request {
+				r := signed(http.MethodGet, "/api/v1/reports?feedID=0x0003", nil, "clientId", now)
+				r.URL.RawQuery = "feedID=0x0004"
+				return r
+			}(),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "unknown API key",
+			r:       signed(http.MethodGet, "/api/v1/feeds", nil, "otherId", now),
+			wantErr: ErrUnknownAPIKey,
+		},
+		{
+			name:    "old timestamp",
+			r:       signed(http.MethodGet, "/api/v1/feeds", nil, "clientId", now.Add(-time.Hour)),
+			wantErr: ErrExpired,
+		},
+		{
+			name:    "expired url",
+			r:       signedURL("/api/v1/ws?feedIDs=0x0003", now.Add(-time.Second)),
+			wantErr: ErrExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKey, err := Scheme{}.Verify(tt.r, tt.body, secrets, now, time.Minute)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && apiKey != "clientId" {
+				t.Errorf("Verify() = %s, want clientId", apiKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Package auth implements the HMAC authentication of the Data Streams API requests, used by the
+// client to sign its requests and by gateway implementers to verify the signatures server side.
+//
+// A request is signed with the HMAC-SHA256, keyed with the API secret, of the string to sign:
+//
+//	METHOD PATH BODY_HASH API_KEY TIMESTAMP
+//
+// separated by single spaces, where:
+//   - METHOD is the HTTP method, e.g. GET,
+//   - PATH is the request URI as sent, the escaped path followed by the raw query if any,
+//     e.g. /api/v1/reports?feedID=0x00&timestamp=1718885772,
+//   - BODY_HASH is the lowercase hex SHA-256 of the request body, the hash of the empty body
+//     e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 for requests without body,
+//   - API_KEY is the API key,
+//   - TIMESTAMP is the decimal Unix timestamp of the signature in milliseconds.
+//
+// The signature is the lowercase hex HMAC, sent with the API key and the timestamp in the HeaderAPIKey,
+// HeaderTimestamp and HeaderSignature headers or, for clients unable to set headers such as browsers
+// opening websockets, in the query parameters, see Scheme.SignURL.
+package auth
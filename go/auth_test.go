@@ -130,10 +130,23 @@ func Test_generateAuthHeaders(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 
 			got := http.Header{}
-			generateAuthHeaders(got, tt.args.method, tt.args.path, tt.args.body, tt.args.clientId, tt.args.userSecret, tt.args.timestamp)
+			generateAuthHeaders(Config{}.authScheme(), got, tt.args.method, tt.args.path, tt.args.body, tt.args.clientId, tt.args.userSecret, tt.args.timestamp)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("generateAuthHeaders() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestConfig_authScheme(t *testing.T) {
+	body := []byte(`{"attr1": "value1"}`)
+	cfg := Config{AuthBodyMethods: []string{http.MethodPost}}
+	if got, want := cfg.authScheme().Sign(http.MethodGet, "/api/v1/feeds", body, "clientId", "userSecret", 1718885772),
+		generateHMAC(http.MethodGet, "/api/v1/feeds", nil, "clientId", 1718885772, "userSecret"); got != want {
+		t.Errorf("authScheme().Sign() = %s, want the signature of the empty body %s", got, want)
+	}
+	if got, want := cfg.authScheme().Sign(http.MethodPost, "/api/v1/feeds", body, "clientId", "userSecret", 1718885772),
+		generateHMAC(http.MethodPost, "/api/v1/feeds", body, "clientId", 1718885772, "userSecret"); got != want {
+		t.Errorf("authScheme().Sign() = %s, want %s", got, want)
+	}
+}
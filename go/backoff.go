@@ -0,0 +1,56 @@
+package streams
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// BackoffStrategy computes the delay before a Stream connection reconnection attempt.
+// It is called concurrently by the connections of all the Streams of the client.
+type BackoffStrategy interface {
+	// Backoff returns the delay before the reconnection attempt following the failed attempt, starting at 1.
+	Backoff(attempt int) time.Duration
+}
+
+// BackoffFunc adapts a function to a BackoffStrategy.
+type BackoffFunc func(attempt int) time.Duration
+
+func (f BackoffFunc) Backoff(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// DefaultBackoff returns the default BackoffStrategy, a random delay between 1 and 10 seconds.
+func DefaultBackoff() BackoffStrategy {
+	return NewJitterBackoff(
+		minWSReconnectIntervalMillis*time.Millisecond,
+		maxWSReconnectIntervalMIllis*time.Millisecond,
+		nil,
+	)
+}
+
+// NewJitterBackoff returns a BackoffStrategy returning a delay uniformly distributed between min and max
+// for every attempt, drawn from src. The delays are deterministic for a seeded source, such as
+// rand.NewPCG(1, 2), and random if src is nil.
+func NewJitterBackoff(min, max time.Duration, src rand.Source) BackoffStrategy {
+	if src == nil {
+		src = rand.NewPCG(rand.Uint64(), rand.Uint64())
+	}
+	return &jitterBackoff{min: min, max: max, rand: rand.New(src)}
+}
+
+type jitterBackoff struct {
+	min, max time.Duration
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (b *jitterBackoff) Backoff(int) time.Duration {
+	if b.max <= b.min {
+		return b.min
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.min + time.Duration(b.rand.Int64N(int64(b.max-b.min)))
+}
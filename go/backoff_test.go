@@ -0,0 +1,100 @@
+package streams
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestJitterBackoff(t *testing.T) {
+	b1 := NewJitterBackoff(time.Second, 2*time.Second, rand.NewPCG(1, 2))
+	b2 := NewJitterBackoff(time.Second, 2*time.Second, rand.NewPCG(1, 2))
+	for attempt := 1; attempt <= 100; attempt++ {
+		d := b1.Backoff(attempt)
+		if d < time.Second || d >= 2*time.Second {
+			t.Fatalf("Backoff(%d) = %s, want within [1s, 2s)", attempt, d)
+		}
+		if d2 := b2.Backoff(attempt); d != d2 {
+			t.Fatalf("Backoff(%d) = %s and %s with the same seed", attempt, d, d2)
+		}
+	}
+
+	if d := NewJitterBackoff(time.Second, time.Second, nil).Backoff(1); d != time.Second {
+		t.Errorf("Backoff() = %s, want 1s", d)
+	}
+	if d := DefaultBackoff().Backoff(1); d < time.Second || d >= 10*time.Second {
+		t.Errorf("DefaultBackoff().Backoff() = %s, want within [1s, 10s)", d)
+	}
+}
+
+func TestClient_StreamReconnectBackoff(t *testing.T) {
+	connects := &atomic.Uint64{}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		// the first session is closed and the next two reconnection attempts rejected
+		switch connects.Add(1) {
+		case 2, 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+		if connects.Load() == 1 {
+			return
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	var mu sync.Mutex
+	var attempts []int
+	streamsClient.(*client).config.WsReconnectBackoff = BackoffFunc(func(attempt int) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts = append(attempts, attempt)
+		return time.Millisecond
+	})
+
+	sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	for deadline := time.Now().Add(2 * time.Second); connects.Load() < 4; {
+		if time.Now().After(deadline) {
+			t.Fatalf("stream not reconnected, connections: %d", connects.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []int{1, 2}; !reflect.DeepEqual(attempts, want) {
+		t.Errorf("Backoff() attempts = %v, want %v", attempts, want)
+	}
+}
@@ -0,0 +1,51 @@
+package streams
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/internal/cborenc"
+)
+
+// ReportResponse CBOR map keys, the protobuf field numbers of datastreams.proto.
+const (
+	cborFeedID                uint64 = 1
+	cborFullReport            uint64 = 2
+	cborValidFromTimestamp    uint64 = 3
+	cborObservationsTimestamp uint64 = 4
+)
+
+// MarshalCBOR encodes the report as a CBOR map keyed by the field numbers of the ReportResponse
+// protobuf message, for compact storage. Annotations such as Verification and Metadata are not encoded.
+// The report data decoded from the full report is encoded by the report/codec package MarshalCBOR.
+func (r *ReportResponse) MarshalCBOR() ([]byte, error) {
+	b := cborenc.AppendMap(nil, 4)
+	b = cborenc.AppendBytes(b, cborFeedID, r.FeedID[:])
+	b = cborenc.AppendBytes(b, cborFullReport, r.FullReportBytes())
+	b = cborenc.AppendUint(b, cborValidFromTimestamp, r.ValidFromTimestamp)
+	b = cborenc.AppendUint(b, cborObservationsTimestamp, r.ObservationsTimestamp)
+	return b, nil
+}
+
+// UnmarshalCBOR decodes the report encoded by MarshalCBOR, unknown fields are ignored.
+func (r *ReportResponse) UnmarshalCBOR(b []byte) error {
+	*r = ReportResponse{}
+	err := cborenc.Decode(b, func(f cborenc.Field) (err error) {
+		switch f.Key {
+		case cborFeedID:
+			err = f.CopyFixed(r.FeedID[:])
+		case cborFullReport:
+			if r.FullReport, err = f.Bytes(); len(r.FullReport) == 0 {
+				r.FullReport = nil
+			}
+		case cborValidFromTimestamp:
+			r.ValidFromTimestamp, err = f.Uint(64)
+		case cborObservationsTimestamp:
+			r.ObservationsTimestamp, err = f.Uint(64)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("client: invalid report cbor: %w", err)
+	}
+	return nil
+}
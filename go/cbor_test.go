@@ -0,0 +1,50 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReportResponse_CBOR(t *testing.T) {
+	want := &ReportResponse{FeedID: feed1, FullReport: []byte{0xde, 0xad, 0xbe, 0xef}, ValidFromTimestamp: 9, ObservationsTimestamp: 10}
+	b, err := want.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR() error = %s", err)
+	}
+
+	got := &ReportResponse{Metadata: map[string]any{"stale": true}}
+	if err = got.UnmarshalCBOR(b); err != nil {
+		t.Fatalf("UnmarshalCBOR() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalCBOR() = %#v, want %#v", got, want)
+	}
+
+	lazy := &ReportResponse{FeedID: feed1, fullReportHex: []byte("deadbeef")}
+	if b, err = lazy.MarshalCBOR(); err != nil {
+		t.Fatalf("MarshalCBOR() of lazy report error = %s", err)
+	}
+	if err = got.UnmarshalCBOR(b); err != nil || !reflect.DeepEqual(got.FullReport, want.FullReport) {
+		t.Errorf("UnmarshalCBOR() of lazy report = %#v, %v, want full report %x", got, err, want.FullReport)
+	}
+
+	empty := &ReportResponse{}
+	if b, err = empty.MarshalCBOR(); err != nil {
+		t.Fatalf("MarshalCBOR() of empty report error = %s", err)
+	}
+	if err = got.UnmarshalCBOR(b); err != nil || !reflect.DeepEqual(got, empty) {
+		t.Errorf("UnmarshalCBOR() of empty report = %#v, %v, want %#v", got, err, empty)
+	}
+
+	invalid := [][]byte{
+		nil,
+		{0xa1, 0x01, 0x41, 0x01}, // 1: h'01', short feed ID
+		{0xa1, 0x03, 0x61, 0x31}, // 3: "1"
+		b[:len(b)-1],
+	}
+	for _, b := range invalid {
+		if err = got.UnmarshalCBOR(b); err == nil {
+			t.Errorf("UnmarshalCBOR(%x) error = nil, want error", b)
+		}
+	}
+}
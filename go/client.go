@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -24,9 +25,12 @@ type Client interface {
 	GetFeeds(ctx context.Context) (r []*feed.Feed, err error)
 
 	// GetLatestReport fetches the latest report available for the given feedID.
+	// The report is served from cache until it expires if Config.LatestReportCache is set.
 	GetLatestReport(ctx context.Context, id feed.ID) (r *ReportResponse, err error)
 
 	// GetReports fetches the reports for the given feedIDs and timestamp.
+	// If the server returns multiple reports for the same feed, only the report with the
+	// highest ValidFromTimestamp and ObservationsTimestamp is kept unless Config.RawBulkReports is set.
 	GetReports(ctx context.Context, ids []feed.ID, timestamp uint64) ([]*ReportResponse, error)
 
 	// GetReportPage paginates the reports for the given feedID and start timestamp.
@@ -40,57 +44,146 @@ type Client interface {
 		connStatusCallback func(isConnected bool, host string, origin string)) (Stream, error)
 }
 
+// ExtendedClient is the Client with the capabilities added since the Client interface was introduced,
+// implemented by the Clients returned by New. Type assert a Client to use them, so that existing
+// implementations and mocks of Client remain valid.
+type ExtendedClient interface {
+	Client
+
+	// GetFreshReport fetches the latest report for the given feedID retrying every retryEvery
+	// until a report observed less than maxAge ago is available or the context is done.
+	GetFreshReport(ctx context.Context, id feed.ID, maxAge, retryEvery time.Duration) (r *ReportResponse, err error)
+
+	// GetReport fetches the report for the given feedID and timestamp.
+	// Cheaper than GetReports when fetching a single feed report.
+	GetReport(ctx context.Context, id feed.ID, timestamp uint64) (*ReportResponse, error)
+
+	// GetReportPageWithLimit paginates the reports for the given feedID and start timestamp
+	// returning at most limit reports per page.
+	GetReportPageWithLimit(ctx context.Context, id feed.ID, startTS uint64, limit int) (*ReportPage, error)
+
+	// IterReportPage iterates the reports of the page for the given feedID and start timestamp
+	// as they are decoded, without buffering the whole page. At most limit reports are returned if positive.
+	IterReportPage(ctx context.Context, id feed.ID, startTS uint64, limit int) iter.Seq2[*ReportResponse, error]
+
+	// GetReportsBefore fetches up to n of the latest reports for the given feedID
+	// observed before the given timestamp, newest first. The lookup goes back a bounded
+	// time range, returning fewer reports if the feed has no older reports within it.
+	GetReportsBefore(ctx context.Context, id feed.ID, beforeTS uint64, n int) ([]*ReportResponse, error)
+
+	// GetOrigins fetches the Streams server origins and their metadata.
+	GetOrigins(ctx context.Context) ([]OriginInfo, error)
+
+	// StreamWithConnStatusCallback creates realtime report stream for the given feedIDs.
+	// The callback is called with each connection status change, in order, identifying the
+	// connection and session so connect and disconnect events can be paired.
+	StreamWithConnStatusCallback(ctx context.Context, feedIDs []feed.ID,
+		connStatusCallback func(ConnStatus)) (ExtendedStream, error)
+
+	// StreamWithHandler creates a realtime report stream for the given feedIDs delivering its reports
+	// to the handler instead of Read. The handler is called by Config.HandlerConcurrency workers,
+	// the reports of a feed are handled in order by the same worker. Read returns ErrHandlerStream.
+	StreamWithHandler(ctx context.Context, feedIDs []feed.ID, handler func(*ReportResponse)) (ExtendedStream, error)
+
+	// StreamRouted creates a realtime report stream for the feedIDs of routes delivering the reports of each
+	// feed in order to its own handler. A panicking handler is recovered and a slow handler drops the reports
+	// of its feed once its queue is full, without affecting the other feeds. Read returns ErrHandlerStream.
+	StreamRouted(ctx context.Context, routes map[feed.ID]func(*ReportResponse)) (RoutedStream, error)
+
+	// StreamStandby creates a realtime report stream for the given feedIDs in standby mode.
+	// The stream starts delivering reports once StandbyStream.TakeOver is called.
+	StreamStandby(ctx context.Context, feedIDs []feed.ID, store HandoverStore) (StandbyStream, error)
+
+	// TransferStats returns the rest responses transfer counters of the client.
+	TransferStats() TransferStats
+
+	// MeasureClock measures the request round trip time and the clock offset to the server,
+	// updating and returning the running ClockEstimate.
+	MeasureClock(ctx context.Context) (ClockEstimate, error)
+
+	// ClockEstimate returns the running estimate of the clock offset to the server,
+	// updated by MeasureClock and the responses of the other requests.
+	ClockEstimate() ClockEstimate
+
+	// Reconfigure applies the config update at runtime, for example rotated credentials or new endpoints,
+	// see WatchConfigFile. The rest requests use the updated config once Reconfigure returns. When the
+	// credentials or the websocket url changed, the Stream connections are redialed one at a time with
+	// the updated config, Reconfigure returns once they reconnected or the context is done.
+	Reconfigure(ctx context.Context, u ConfigUpdate) error
+
+	// DebugDump writes a support bundle with the client config, credentials masked,
+	// the open Streams state, stats and recent events, to attach to support tickets.
+	DebugDump(ctx context.Context, w io.Writer) error
+}
+
 // LogPrintf implements a LogFunction using fmt.Printf
 func LogPrintf(format string, a ...any) {
 	fmt.Printf(time.Now().Format(time.RFC3339)+" "+format+"\n", a...)
 }
 
-var _ Client = (*client)(nil)
+var _ ExtendedClient = (*client)(nil)
 
 type client struct {
-	config Config
-	http   *http.Client
+	config    Config
+	endpoints *endpoints
+	http      *http.Client
+	transfer  transferStats
+	clock     *clockEstimator
+	creds     *credentials
+	limiter   *restLimiter
+	origins   *originSelector
+	streams   streamRegistry
+	latest    *latestCache
 }
 
 // New creates a new Client with the given config.
-// New does not initialize any connection to the Data Streams service.
+// New does not connect to the Data Streams service. New may however do network I/O: with Config.DNSCache set,
+// it starts resolving the endpoint hosts with DNS queries in the background, see DNSCacheConfig.
+// An invalid config returns a *ConfigError listing all the config problems.
 func New(cfg Config) (c Client, err error) {
-	if cfg.RestURL == "" && cfg.WsURL == "" {
-		return nil, fmt.Errorf("client: no server url provided")
+	if err = cfg.Validate(); err != nil {
+		return nil, err
 	}
 
-	if cfg.restURL, err = url.Parse(cfg.RestURL); err != nil {
-		return nil, fmt.Errorf("client: error parsing rest URL: %w", err)
+	if cfg.WsMaxReconnect == 0 {
+		cfg.WsMaxReconnect = maxWSReconnectAttempts
 	}
-
-	if cfg.wsURL, err = url.Parse(cfg.WsURL); err != nil {
-		return nil, fmt.Errorf("client: error parsing websocket URL: %w", err)
+	if cfg.WsReconnectBackoff == nil {
+		cfg.WsReconnectBackoff = DefaultBackoff()
 	}
 
-	if cfg.ApiKey == "" {
-		return nil, fmt.Errorf("client: empty api key")
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			// disable linting since this is intentional
+			InsecureSkipVerify: cfg.InsecureSkipVerify}, //nolint:gosec
 	}
-
-	if cfg.ApiSecret == "" {
-		return nil, fmt.Errorf("client: empty api secret")
+	endpoints := newEndpoints(cfg)
+	if cfg.DNSCache != nil {
+		dns := newDNSCache(cfg)
+		transport.DialContext = dns.dialContext
+		dns.preresolve(endpoints.rest().Hostname(), endpoints.ws().Hostname())
 	}
-
-	if cfg.WsMaxReconnect == 0 {
-		cfg.WsMaxReconnect = maxWSReconnectAttempts
+	httpClient := &http.Client{Transport: transport}
+	cl := &client{
+		config:    cfg,
+		endpoints: endpoints,
+		http:      httpClient,
+		clock:     &clockEstimator{correct: cfg.ClockCorrection},
+		creds:     newCredentials(cfg),
+		limiter:   newRestLimiter(cfg.RestConcurrency),
+		origins:   &originSelector{policy: cfg.WsOriginPolicy, preferred: cfg.WsPreferredOrigins},
 	}
-
-	c = &client{
-		config: cfg,
-		http: &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					// disable linting since this is intentional
-					InsecureSkipVerify: cfg.InsecureSkipVerify}, //nolint:gosec
-			},
-		},
+	if cfg.Redirects != RedirectFollow {
+		httpClient.CheckRedirect = cl.checkRedirect
+	}
+	if cfg.LatestReportCache != nil {
+		cl.latest = newLatestCache(*cfg.LatestReportCache)
 	}
 
-	return c, nil
+	cfg.logDebug("client: data streams sdk version: %s, commit: %s, schema versions: %v",
+		Version(), CommitHash, SupportedSchemaVersions())
+
+	return cl, nil
 }
 
 // ReportPage implements the server pagination response.
@@ -106,29 +199,69 @@ func (c *client) Stream(ctx context.Context, ids []feed.ID) (s Stream, err error
 
 func (c *client) StreamWithStatusCallback(ctx context.Context, ids []feed.ID,
 	connStatusCallback func(isConnected bool, host string, origin string)) (s Stream, err error) {
-	var origins []string
+	var cb func(ConnStatus)
+	if connStatusCallback != nil {
+		cb = func(st ConnStatus) { connStatusCallback(st.Connected, st.Host, st.Origin) }
+	}
+	return c.StreamWithConnStatusCallback(ctx, ids, cb)
+}
 
-	// Only fetch origins if websocket high availability mode is enabled
-	if c.config.WsHA {
-		h, err := c.serverHeaders(ctx, c.config.wsURL)
-		if err != nil {
-			c.config.logInfo("client: Unable to retrieve server headers, error: %w", err)
-			// Return nil if the context has been timed out or been canceled
-			if ctx.Err() != nil {
-				return nil, err
-			}
+func (c *client) StreamWithConnStatusCallback(ctx context.Context, ids []feed.ID,
+	connStatusCallback func(ConnStatus)) (s ExtendedStream, err error) {
+	origins, err := c.streamOrigins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.newStream(ctx, c.http, ids, origins, connStatusCallback)
+}
+
+// streamOrigins returns the origins to connect to when in websocket high availability mode.
+// When not in high availability mode a single origin is returned if selected by Config.WsOriginPolicy.
+func (c *client) streamOrigins(ctx context.Context) (origins []string, err error) {
+	// Only fetch origins if websocket high availability mode is enabled or an origin is selected
+	if !c.config.WsHA && c.config.WsOriginPolicy == OriginPolicyNone {
+		return nil, nil
+	}
+
+	config := c.config.withLabels(labelsFromContext(ctx))
+	h, err := c.serverHeaders(ctx, c.endpoints.ws())
+	if err != nil {
+		config.logInfo("client: Unable to retrieve server headers, error: %w", err)
+		// Return nil if the context has been timed out or been canceled
+		if ctx.Err() != nil {
+			return nil, err
 		}
+	}
 
-		origins = extractOrigins(h)
-		if origins == nil {
-			c.config.logInfo("client: no origins found, the websocket connections are not running in HA mode")
+	origins = extractOrigins(h)
+	if !c.config.WsHA {
+		origin := c.origins.selectOrigin(origins)
+		if origin == "" {
+			config.logInfo("client: no origin selected by the %s origin policy among %v, the server chooses the origin",
+				c.config.WsOriginPolicy, origins)
+			return nil, nil
 		}
+		config.logDebug("client: origin %s selected by the %s origin policy", origin, c.config.WsOriginPolicy)
+		return []string{origin}, nil
 	}
 
-	return c.newStream(ctx, c.http, ids, origins, connStatusCallback)
+	if origins == nil {
+		config.logInfo("client: no origins found, the websocket connections are not running in HA mode")
+	}
+	return origins, nil
 }
 
 func (c *client) GetLatestReport(ctx context.Context, id feed.ID) (r *ReportResponse, err error) {
+	if c.latest != nil {
+		if r, ok := c.latest.get(id); ok {
+			return r, nil
+		}
+	}
+	return c.fetchLatestReport(ctx, id)
+}
+
+// fetchLatestReport fetches the latest report of the feed, caching it if Config.LatestReportCache is set.
+func (c *client) fetchLatestReport(ctx context.Context, id feed.ID) (r *ReportResponse, err error) {
 	type response struct {
 		Report *ReportResponse `json:"report"`
 	}
@@ -145,9 +278,59 @@ func (c *client) GetLatestReport(ctx context.Context, id feed.ID) (r *ReportResp
 	if err == nil && resp.Report == nil {
 		err = errors.New("client: response data error: latest report object not found")
 	}
+	if err == nil && c.latest != nil {
+		c.latest.put(resp.Report)
+	}
 	return resp.Report, err
 }
 
+func (c *client) GetReport(ctx context.Context, id feed.ID, ts uint64) (r *ReportResponse, err error) {
+	type response struct {
+		Report *ReportResponse `json:"report"`
+	}
+
+	resp := &response{}
+	req := &request{
+		method: http.MethodGet,
+		path:   apiV1Reports,
+		params: url.Values{
+			"feedID":    {id.String()},
+			"timestamp": {strconv.FormatUint(ts, 10)},
+		},
+	}
+	err = c.rest(ctx, req, resp)
+	if err == nil && resp.Report == nil {
+		err = errors.New("client: response data error: report object not found")
+	}
+	return resp.Report, err
+}
+
+func (c *client) GetFreshReport(ctx context.Context, id feed.ID, maxAge, retryEvery time.Duration) (r *ReportResponse, err error) {
+	if retryEvery <= 0 {
+		return nil, fmt.Errorf("client: invalid retry interval %s", retryEvery)
+	}
+
+	ticker := time.NewTicker(retryEvery)
+	defer ticker.Stop()
+	for {
+		r, err = c.fetchLatestReport(ctx, id)
+		if err == nil {
+			age := time.Since(time.Unix(int64(r.ObservationsTimestamp), 0))
+			if age < maxAge {
+				return r, nil
+			}
+			err = fmt.Errorf("client: latest report for feed %s is %s old", id, age.Truncate(time.Second))
+		}
+		c.config.logDebug("client: fresh report for feed %s not available: %s", id, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("client: no report fresher than %s: %w, last error: %w", maxAge, ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
 // ReportResponse implements the report envelope that contains the full report payload,
 // its FeedID and timestamps. For decoding the Report Payload use report.Decode().
 type ReportResponse struct {
@@ -155,12 +338,26 @@ type ReportResponse struct {
 	FullReport            []byte  `json:"fullReport"`
 	ValidFromTimestamp    uint64  `json:"validFromTimestamp"`
 	ObservationsTimestamp uint64  `json:"observationsTimestamp"`
+
+	// Verification annotates Stream reports when Config.Verification is set.
+	Verification VerificationStatus `json:"-"`
+	// VerificationErr is the reason a Stream report is Unverified.
+	VerificationErr error `json:"-"`
+	// Metadata holds annotations attached to Stream reports by Config.OnAccept.
+	Metadata map[string]any `json:"-"`
+	// RawExtra holds the server fields not modeled by ReportResponse
+	// when Config.UnknownFields is UnknownFieldsCapture.
+	RawExtra map[string]json.RawMessage `json:"-"`
+
+	// fullReportHex is the hex encoded full report decoded by FullReportBytes
+	// when Config.LazyFullReport is set.
+	fullReportHex []byte
 }
 
 func (r *ReportResponse) UnmarshalJSON(b []byte) (err error) {
 	type Alias ReportResponse
 	aux := &struct {
-		FullReport string `json:"fullReport"`
+		FullReport hexReport `json:"fullReport"`
 		*Alias
 	}{
 		Alias: (*Alias)(r),
@@ -170,24 +367,23 @@ func (r *ReportResponse) UnmarshalJSON(b []byte) (err error) {
 		return err
 	}
 
-	if len(aux.FullReport) < 3 {
-		return nil
+	if aux.FullReport != nil {
+		r.FullReport = aux.FullReport
 	}
-
-	if r.FullReport, err = hex.DecodeString(aux.FullReport[2:]); err != nil {
-		return nil
-	}
-
 	return nil
 }
 
 func (r *ReportResponse) MarshalJSON() ([]byte, error) {
 	type Alias ReportResponse
+	fullReport := "0x" + hex.EncodeToString(r.FullReport)
+	if r.FullReport == nil && r.fullReportHex != nil {
+		fullReport = "0x" + string(r.fullReportHex)
+	}
 	return json.Marshal(&struct {
 		FullReport string `json:"fullReport"`
 		*Alias
 	}{
-		FullReport: "0x" + hex.EncodeToString(r.FullReport),
+		FullReport: fullReport,
 		Alias:      (*Alias)(r),
 	})
 }
@@ -224,10 +420,45 @@ func (c *client) GetReports(ctx context.Context, ids []feed.ID, ts uint64) (r []
 	if err == nil && rs.Reports == nil {
 		err = errors.New("client: response data error: reports list not found")
 	}
-	return rs.Reports, err
+	if err != nil || c.config.RawBulkReports {
+		return rs.Reports, err
+	}
+	return dedupReports(rs.Reports), nil
+}
+
+// dedupReports keeps a single report per feed, the one with the highest ValidFromTimestamp
+// and ObservationsTimestamp, in the order the feeds first appear.
+func dedupReports(reports []*ReportResponse) (r []*ReportResponse) {
+	index := make(map[feed.ID]int, len(reports))
+	r = make([]*ReportResponse, 0, len(reports))
+	for _, rep := range reports {
+		x, ok := index[rep.FeedID]
+		if !ok {
+			index[rep.FeedID] = len(r)
+			r = append(r, rep)
+			continue
+		}
+
+		if cur := r[x]; rep.ValidFromTimestamp > cur.ValidFromTimestamp ||
+			(rep.ValidFromTimestamp == cur.ValidFromTimestamp && rep.ObservationsTimestamp > cur.ObservationsTimestamp) {
+			r[x] = rep
+		}
+	}
+	return r
 }
 
 func (c *client) GetReportPage(ctx context.Context, id feed.ID, pageTS uint64) (r *ReportPage, err error) {
+	return c.getReportPage(ctx, id, pageTS, 0)
+}
+
+func (c *client) GetReportPageWithLimit(ctx context.Context, id feed.ID, pageTS uint64, limit int) (r *ReportPage, err error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("client: invalid page limit %d", limit)
+	}
+	return c.getReportPage(ctx, id, pageTS, limit)
+}
+
+func (c *client) getReportPage(ctx context.Context, id feed.ID, pageTS uint64, limit int) (r *ReportPage, err error) {
 	r = &ReportPage{}
 	req := &request{
 		method: http.MethodGet,
@@ -237,6 +468,9 @@ func (c *client) GetReportPage(ctx context.Context, id feed.ID, pageTS uint64) (
 			"startTimestamp": {strconv.FormatUint(pageTS, 10)},
 		},
 	}
+	if limit > 0 {
+		req.params.Set("limit", strconv.Itoa(limit))
+	}
 	err = c.rest(ctx, req, r)
 	if err == nil && r.Reports == nil {
 		err = errors.New("client: response data error: reports page list not found")
@@ -248,6 +482,65 @@ func (c *client) GetReportPage(ctx context.Context, id feed.ID, pageTS uint64) (
 	return r, err
 }
 
+// reportsBeforeMaxWindows is the maximum number of windows fetched by GetReportsBefore,
+// looking back up to n*(2^16-1) seconds before beforeTS.
+const reportsBeforeMaxWindows = 16
+
+// GetReportsBefore emulates descending pagination as the server only paginates forward.
+// The reports are fetched in windows preceding beforeTS, starting with a window of n seconds
+// and doubling it on each iteration, until n reports are found, the timestamp 0 is reached
+// or reportsBeforeMaxWindows windows were fetched, the feed having no older reports nearby.
+func (c *client) GetReportsBefore(ctx context.Context, id feed.ID, beforeTS uint64, n int) (r []*ReportResponse, err error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("client: invalid number of reports %d", n)
+	}
+
+	end := beforeTS
+	window := uint64(n)
+	for x := 0; len(r) < n && end > 0 && x < reportsBeforeMaxWindows; x++ {
+		start := uint64(0)
+		if end > window {
+			start = end - window
+		}
+
+		reports, err := c.reportsRange(ctx, id, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		// prepend the older window reports newest first
+		for i := len(reports) - 1; i >= 0 && len(r) < n; i-- {
+			r = append(r, reports[i])
+		}
+
+		end = start
+		window *= 2
+	}
+	return r, nil
+}
+
+// reportsRange fetches the reports for the given feedID observed in the [start, end) range, oldest first.
+func (c *client) reportsRange(ctx context.Context, id feed.ID, start, end uint64) (r []*ReportResponse, err error) {
+	for ts := start; ts < end; {
+		page, err := c.GetReportPage(ctx, id, ts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rep := range page.Reports {
+			if rep.ObservationsTimestamp >= start && rep.ObservationsTimestamp < end {
+				r = append(r, rep)
+			}
+		}
+
+		if len(page.Reports) == 0 || page.NextPageTS <= ts {
+			break
+		}
+		ts = page.NextPageTS
+	}
+	return r, nil
+}
+
 type feedsResponse struct {
 	Feeds []*feed.Feed `json:"feeds"`
 }
@@ -274,7 +567,120 @@ type request struct {
 }
 
 func (c *client) rest(ctx context.Context, d *request, dst interface{}) (err error) {
-	reqURL := c.config.restURL.ResolveReference(&url.URL{Path: d.path})
+	id := requestID(ctx)
+	defer func() {
+		if err != nil {
+			err = &RequestError{RequestID: id, Err: err}
+		}
+	}()
+
+	start := time.Now()
+	var resp *http.Response
+	resp, err = c.send(ctx, id, d)
+	if err != nil {
+		return err
+	}
+
+	buf, err := c.readBody(resp)
+	resp.Body.Close()
+	c.checkSlow(id, d, start)
+
+	// defer inspect if enabled with a bytes.Reader from the read above.
+	// do this before checking for read errors to ensure the response gets inspected.
+	// If an error is caught and `buf` is nil, the reader will return io.EOF
+	if inspect := c.inspectHttpResponse(ctx); inspect != nil {
+		// Reset the response body, so it can be read again by InspectHttpResponse if needed
+		resp.Body = io.NopCloser(bytes.NewReader(buf))
+		defer inspect(resp)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("client: http status code: %d, response body %s", resp.StatusCode, string(buf))
+	}
+
+	// check for body read errors
+	if err != nil {
+		return fmt.Errorf("client: error reading response body: %w", err)
+	}
+
+	if err = json.Unmarshal(buf, dst); err != nil {
+		return fmt.Errorf("client: deserializing response error: %w, body: %s", err, string(buf))
+	}
+
+	return c.config.checkUnknownFields(buf, dst)
+}
+
+// inspectHttpResponse returns the response inspection hook set in the context with InspectHttpResponseCtxKey,
+// Config.InspectHttpResponse if not set.
+func (c *client) inspectHttpResponse(ctx context.Context) func(*http.Response) {
+	if inspect, ok := inspectFromContext(ctx); ok {
+		return inspect
+	}
+	return c.config.InspectHttpResponse
+}
+
+// inspectFromContext returns the response inspection hook set in the context with InspectHttpResponseCtxKey.
+func inspectFromContext(ctx context.Context) (inspect func(*http.Response), ok bool) {
+	inspect, ok = ctx.Value(InspectHttpResponseCtxKey).(func(*http.Response))
+	return inspect, ok && inspect != nil
+}
+
+// send performs the signed rest request returning the response with its body unread.
+// Requests rejected with an authentication error are retried once with refreshed credentials
+// if a CredentialsProvider is configured.
+func (c *client) send(ctx context.Context, id string, d *request) (resp *http.Response, err error) {
+	priority := priorityFromContext(ctx)
+	release, err := c.limiter.acquire(ctx, priority)
+	if err != nil {
+		return nil, fmt.Errorf("client: waiting for a %s priority request slot: %w", priority, err)
+	}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
+
+	for retry := false; ; retry = true {
+		apiKey, apiSecret, err := c.creds.get(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp, err = c.sendSigned(ctx, id, d, apiKey, apiSecret); err != nil {
+			return nil, err
+		}
+		if retry || !isAuthError(resp.StatusCode) {
+			resp.Body = &limitedBody{ReadCloser: resp.Body, release: release}
+			return resp, nil
+		}
+
+		ok, err := c.creds.refresh(ctx, apiKey, apiSecret)
+		if err != nil {
+			c.config.logInfo("client: rest request id: %s, error refreshing credentials: %s", id, err)
+		}
+		if !ok {
+			resp.Body = &limitedBody{ReadCloser: resp.Body, release: release}
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		c.config.logInfo("client: rest request id: %s rejected with status code %d, retrying with refreshed credentials",
+			id, resp.StatusCode)
+
+		// low priority retries wait behind the other requests
+		if priority == PriorityLow && c.limiter != nil {
+			release()
+			if release, err = c.limiter.acquire(ctx, priority); err != nil {
+				release = func() {}
+				return nil, fmt.Errorf("client: waiting for a %s priority request slot: %w", priority, err)
+			}
+		}
+	}
+}
+
+// sendSigned performs the rest request signed with the given credentials.
+func (c *client) sendSigned(ctx context.Context, id string, d *request, apiKey, apiSecret string) (resp *http.Response, err error) {
+	reqURL := c.endpoints.rest().ResolveReference(&url.URL{Path: d.path})
 	if d.params != nil {
 		reqURL.RawQuery = d.params.Encode()
 	}
@@ -282,11 +688,16 @@ func (c *client) rest(ctx context.Context, d *request, dst interface{}) (err err
 	var req *http.Request
 	req, err = http.NewRequestWithContext(ctx, d.method, reqURL.String(), bytes.NewReader(d.body))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	generateAuthHeaders(req.Header, req.Method, reqURL.RequestURI(), d.body,
-		c.config.ApiKey, c.config.ApiSecret, time.Now().UnixMilli())
+	generateAuthHeaders(c.config.authScheme(), req.Header, req.Method, reqURL.RequestURI(), d.body,
+		apiKey, apiSecret, c.clock.now().UnixMilli())
+	req.Header.Set(requestIDHeader, id)
+	req.Header.Set(userAgentHeader, userAgent())
+	if c.config.RestCompression {
+		req.Header.Set(acceptEncodingHeader, acceptEncoding)
+	}
 
 	if value := ctx.Value(CustomHeadersCtxKey); value != nil {
 		if h, ok := value.(http.Header); ok {
@@ -303,44 +714,26 @@ func (c *client) rest(ctx context.Context, d *request, dst interface{}) (err err
 	}
 
 	c.config.logDebug(
-		"client rest request url: %s, method: %s, query: %s headers: %s, body: %s",
-		req.URL.String(), req.Method, req.URL.Query().Encode(), req.Header, string(d.body))
+		"client rest request id: %s, url: %s, method: %s, query: %s headers: %s, body: %s",
+		id, req.URL.String(), req.Method, req.URL.Query().Encode(), req.Header, string(d.body))
 
-	var resp *http.Response
+	sent := time.Now()
 	resp, err = c.http.Do(req)
 	if err != nil {
-		return fmt.Errorf("client: error performing http request: %w", err)
-	}
-
-	buf, err := io.ReadAll(resp.Body)
-	resp.Body.Close()
-
-	// defer inspect if enabled with a bytes.Reader from the read above.
-	// do this before checking for read errors to ensure the response gets inspected.
-	// If an error is caught and `buf` is nil, the reader will return io.EOF
-	if c.config.InspectHttpResponse != nil {
-		// Reset the response body, so it can be read again by InspectHttpResponse if needed
-		resp.Body = io.NopCloser(bytes.NewReader(buf))
-		defer c.config.InspectHttpResponse(resp)
-	}
-
-	if resp.StatusCode >= http.StatusBadRequest {
-		return fmt.Errorf("client: http status code: %d, response body %s", resp.StatusCode, string(buf))
+		return nil, fmt.Errorf("client: error performing http request: %w", err)
 	}
-
-	// check for body read errors
-	if err != nil {
-		return fmt.Errorf("client: error reading response body: %w", err)
-	}
-
-	if err = json.Unmarshal(buf, dst); err != nil {
-		return fmt.Errorf("client: deserializing response error: %w, body: %s", err, string(buf))
-	}
-
-	return nil
+	c.clock.observe(sent, time.Now(), resp.Header)
+	return resp, nil
 }
 
 func (c *client) serverHeaders(ctx context.Context, u *url.URL) (h http.Header, err error) {
+	id := requestID(ctx)
+	defer func() {
+		if err != nil {
+			err = &RequestError{RequestID: id, Err: err}
+		}
+	}()
+
 	reqURL := u.ResolveReference(&url.URL{Path: "/"})
 	// HEAD method doesn't support 'ws' or 'wss' scheme
 	switch reqURL.Scheme {
@@ -357,42 +750,34 @@ func (c *client) serverHeaders(ctx context.Context, u *url.URL) (h http.Header,
 		return nil, err
 	}
 
-	generateAuthHeaders(req.Header, req.Method, reqURL.RequestURI(), nil,
-		c.config.ApiKey, c.config.ApiSecret, time.Now().UnixMilli())
+	apiKey, apiSecret, err := c.creds.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	generateAuthHeaders(c.config.authScheme(), req.Header, req.Method, reqURL.RequestURI(), nil,
+		apiKey, apiSecret, c.clock.now().UnixMilli())
+	req.Header.Set(requestIDHeader, id)
+	req.Header.Set(userAgentHeader, userAgent())
 
 	c.config.logDebug(
-		"client headers request url: %s, method: %s, query: %s headers: %s",
-		req.URL.String(), req.Method, req.URL.Query().Encode(), req.Header)
+		"client headers request id: %s, url: %s, method: %s, query: %s headers: %s",
+		id, req.URL.String(), req.Method, req.URL.Query().Encode(), req.Header)
 
 	var resp *http.Response
 	resp, err = c.http.Do(req)
 	if err != nil {
-		c.config.logDebug("client headers request error: %s", err)
+		c.config.logDebug("client headers request id: %s, error: %s", id, err)
 		return nil, err
 	}
 
 	defer resp.Body.Close()
-	c.config.logDebug("client headers response: %s", resp.Header)
+	c.config.logDebug("client headers request id: %s, response: %s", id, resp.Header)
 	return resp.Header, nil
 }
 
 func extractOrigins(h http.Header) (origins []string) {
-	if len(h) == 0 {
-		return nil
-	}
-
-	o := h.Get(cllAvailOriginsHeader)
-	if o == "" {
-		return nil
-	}
-
-	if o[0] == '{' {
-		o = o[1:]
+	for _, o := range ParseOrigins(h.Get(cllAvailOriginsHeader)) {
+		origins = append(origins, o.Name)
 	}
-
-	if o[len(o)-1] == '}' {
-		o = o[:len(o)-1]
-	}
-
-	return strings.Split(o, ",")
+	return origins
 }
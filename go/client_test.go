@@ -3,12 +3,15 @@ package streams
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/data-streams-sdk/go/feed"
@@ -115,6 +118,51 @@ func TestClient_GetReports(t *testing.T) {
 	}
 }
 
+func TestClient_GetReportsDuplicates(t *testing.T) {
+	serverReports := []*ReportResponse{
+		{FeedID: feed1, ValidFromTimestamp: 10, ObservationsTimestamp: 12},
+		{FeedID: feed2, ValidFromTimestamp: 10, ObservationsTimestamp: 12},
+		{FeedID: feed1, ValidFromTimestamp: 10, ObservationsTimestamp: 13},
+		{FeedID: feed1, ValidFromTimestamp: 9, ObservationsTimestamp: 14},
+	}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(reportsResponse{
+			Reports: serverReports,
+		})
+		if err != nil {
+			t.Errorf("failed to encode response: %s", err)
+		}
+	})
+	defer ms.Close()
+
+	clnt, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	reports, err := clnt.GetReports(context.Background(), []feed.ID{feed1, feed2}, 12)
+	if err != nil {
+		t.Fatalf("GetReports() error = %v", err)
+	}
+
+	expectedReports := []*ReportResponse{serverReports[2], serverReports[1]}
+	if !reflect.DeepEqual(reports, expectedReports) {
+		t.Errorf("GetReports() = %v, want %v", reports, expectedReports)
+	}
+
+	clnt.(*client).config.RawBulkReports = true
+	reports, err = clnt.GetReports(context.Background(), []feed.ID{feed1, feed2}, 12)
+	if err != nil {
+		t.Fatalf("GetReports() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(reports, serverReports) {
+		t.Errorf("GetReports() = %v, want %v", reports, serverReports)
+	}
+}
+
 func TestClient_GetLatestReport(t *testing.T) {
 	expectedReport := &ReportResponse{
 		FeedID:     feed1,
@@ -159,21 +207,115 @@ func TestClient_GetLatestReport(t *testing.T) {
 	}
 }
 
+func TestClient_GetReport(t *testing.T) {
+	expectedReport := &ReportResponse{
+		FeedID:                feed1,
+		FullReport:            hexutil.Bytes(`report1 payload`),
+		ObservationsTimestamp: 1234567890,
+	}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET request, got %s", r.Method)
+		}
+
+		if r.URL.Path != apiV1Reports {
+			t.Errorf("expected path %s, got %s", apiV1Reports, r.URL.Path)
+		}
+
+		if r.URL.Query().Get("feedID") != feed1str {
+			t.Errorf("expected feedID, %s got %s", feed1str, r.URL.Query().Get("feedID"))
+		}
+
+		if r.URL.Query().Get("timestamp") != "1234567890" {
+			t.Errorf("expected timestamp 1234567890, got %s", r.URL.Query().Get("timestamp"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		err := json.NewEncoder(w).Encode(struct {
+			Report *ReportResponse `json:"report"`
+		}{
+			Report: expectedReport,
+		})
+
+		if err != nil {
+			t.Errorf("failed to encode response: %s", err)
+		}
+	})
+	defer ms.Close()
+
+	client, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	report, err := client.GetReport(context.Background(), feed1, 1234567890)
+	if err != nil {
+		t.Fatalf("GetReport() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(report, expectedReport) {
+		t.Errorf("GetReport() = %v, want %v", report, expectedReport)
+	}
+}
+
+func TestClient_GetFreshReport(t *testing.T) {
+	var requests atomic.Int32
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		// the third request returns a fresh report
+		ts := uint64(time.Now().Add(-time.Hour).Unix())
+		if requests.Add(1) >= 3 {
+			ts = uint64(time.Now().Unix())
+		}
+
+		err := json.NewEncoder(w).Encode(struct {
+			Report *ReportResponse `json:"report"`
+		}{
+			Report: &ReportResponse{FeedID: feed1, ObservationsTimestamp: ts},
+		})
+		if err != nil {
+			t.Errorf("failed to encode response: %s", err)
+		}
+	})
+	defer ms.Close()
+
+	client, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	report, err := client.GetFreshReport(ctx, feed1, time.Minute, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("GetFreshReport() error = %v", err)
+	}
+	if requests.Load() != 3 || time.Since(time.Unix(int64(report.ObservationsTimestamp), 0)) > time.Minute {
+		t.Errorf("GetFreshReport() = %v after %d requests, want fresh report after 3 requests", report, requests.Load())
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err = client.GetFreshReport(ctx, feed1, -time.Minute, 10*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetFreshReport() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
 func TestClient_GetReportPage(t *testing.T) {
 	expectedInitialTS := uint64(1234567891)
 
 	expectedReportPage1 := &ReportPage{
 		Reports: []*ReportResponse{
-			{FeedID: feed1, FullReport: hexutil.Bytes(`report1 payload`)},
-			{FeedID: feed1, FullReport: hexutil.Bytes(`report2 payload`)},
+			{FeedID: feed1, FullReport: hexutil.Bytes(`report1 payload`), ObservationsTimestamp: 1234567891},
+			{FeedID: feed1, FullReport: hexutil.Bytes(`report2 payload`), ObservationsTimestamp: 1234567898},
 		},
 		NextPageTS: 1234567899,
 	}
 
 	expectedReportPage2 := &ReportPage{
 		Reports: []*ReportResponse{
-			{FeedID: feed1, FullReport: hexutil.Bytes(`report3 payload`)},
-			{FeedID: feed1, FullReport: hexutil.Bytes(`report4 payload`)},
+			{FeedID: feed1, FullReport: hexutil.Bytes(`report3 payload`), ObservationsTimestamp: 1234567899},
+			{FeedID: feed1, FullReport: hexutil.Bytes(`report4 payload`), ObservationsTimestamp: 1234567998},
 		},
 		NextPageTS: 1234567999,
 	}
@@ -243,6 +385,87 @@ func TestClient_GetReportPage(t *testing.T) {
 	}
 }
 
+func TestClient_GetReportsBefore(t *testing.T) {
+	// one report every 2 seconds from 100 to 198
+	var serverReports []*ReportResponse
+	for ts := uint64(100); ts < 200; ts += 2 {
+		serverReports = append(serverReports, &ReportResponse{FeedID: feed1, ObservationsTimestamp: ts})
+	}
+
+	var requests atomic.Int64
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		startTS, err := strconv.ParseUint(r.URL.Query().Get("startTimestamp"), 10, 64)
+		if err != nil {
+			t.Errorf("error parsing startTimestamp: %s", err)
+		}
+
+		limit := 3
+		if l := r.URL.Query().Get("limit"); l != "" {
+			if limit, err = strconv.Atoi(l); err != nil {
+				t.Errorf("error parsing limit: %s", err)
+			}
+		}
+
+		page := &ReportPage{Reports: []*ReportResponse{}}
+		for _, rep := range serverReports {
+			if rep.ObservationsTimestamp >= startTS && len(page.Reports) < limit {
+				page.Reports = append(page.Reports, rep)
+			}
+		}
+
+		if err = json.NewEncoder(w).Encode(page); err != nil {
+			t.Errorf("failed to encode response: %s", err)
+		}
+	})
+	defer ms.Close()
+
+	client, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	page, err := client.GetReportPageWithLimit(context.Background(), feed1, 100, 5)
+	if err != nil {
+		t.Fatalf("GetReportPageWithLimit() error = %v", err)
+	}
+	if len(page.Reports) != 5 || page.NextPageTS != 109 {
+		t.Errorf("GetReportPageWithLimit() = %v, want 5 reports and next page 109", page)
+	}
+
+	reports, err := client.GetReportsBefore(context.Background(), feed1, 150, 7)
+	if err != nil {
+		t.Fatalf("GetReportsBefore() error = %v", err)
+	}
+
+	expectedReports := []*ReportResponse{
+		serverReports[24], serverReports[23], serverReports[22], serverReports[21],
+		serverReports[20], serverReports[19], serverReports[18],
+	}
+	if !reflect.DeepEqual(reports, expectedReports) {
+		t.Errorf("GetReportsBefore() = %v, want %v", reports, expectedReports)
+	}
+
+	reports, err = client.GetReportsBefore(context.Background(), feed1, 104, 7)
+	if err != nil {
+		t.Fatalf("GetReportsBefore() error = %v", err)
+	}
+	if !reflect.DeepEqual(reports, []*ReportResponse{serverReports[1], serverReports[0]}) {
+		t.Errorf("GetReportsBefore() = %v, want the 2 reports before 104", reports)
+	}
+
+	// the lookup stops after reportsBeforeMaxWindows empty windows
+	requests.Store(0)
+	reports, err = client.GetReportsBefore(context.Background(), feed1, 1<<40, 7)
+	if err != nil {
+		t.Fatalf("GetReportsBefore() error = %v", err)
+	}
+	if len(reports) != 0 || requests.Load() != reportsBeforeMaxWindows {
+		t.Errorf("GetReportsBefore() = %v in %d requests, want no reports in %d requests",
+			reports, requests.Load(), reportsBeforeMaxWindows)
+	}
+}
+
 func TestClient_CustomHeadersInspect(t *testing.T) {
 	expectedReport := &ReportResponse{
 		FeedID:     feed1,
@@ -305,6 +528,42 @@ func TestClient_CustomHeadersInspect(t *testing.T) {
 	}
 }
 
+func TestClient_RequestID(t *testing.T) {
+	expectedRequestID := "01J1A1Q7AZ5MTJEHQ95Z85PYVQ"
+	var receivedRequestID string
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		receivedRequestID = r.Header.Get(requestIDHeader)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer ms.Close()
+
+	client, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	ctx := context.WithValue(context.Background(), RequestIDCtxKey, expectedRequestID)
+	_, err = client.GetLatestReport(ctx, feed1)
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected RequestError, got %v", err)
+	}
+	if reqErr.RequestID != expectedRequestID || receivedRequestID != expectedRequestID {
+		t.Errorf("expected request id %s, got error id %s and header %s",
+			expectedRequestID, reqErr.RequestID, receivedRequestID)
+	}
+
+	_, err = client.GetLatestReport(context.Background(), feed1)
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected RequestError, got %v", err)
+	}
+	if reqErr.RequestID == "" || reqErr.RequestID != receivedRequestID {
+		t.Errorf("expected generated request id %s, got %s", receivedRequestID, reqErr.RequestID)
+	}
+}
+
 func TestClient_serverHeaders(t *testing.T) {
 	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodHead {
@@ -326,7 +585,7 @@ func TestClient_serverHeaders(t *testing.T) {
 	}
 
 	c := clnt.(*client)
-	h, err := c.serverHeaders(context.Background(), c.config.wsURL)
+	h, err := c.serverHeaders(context.Background(), c.endpoints.ws())
 	if err != nil {
 		t.Fatalf("error calling serverHeaders %s", err)
 	}
@@ -446,6 +705,13 @@ func Test_extractOrigins(t *testing.T) {
 			},
 			wantOrigins: []string{"origin1", "originA", "origin3"},
 		},
+		{
+			name: "whitespace, quotes and metadata",
+			h: http.Header{
+				cllAvailOriginsHeader: {` { "origin1";region=us, 'originA' ; priority=2 ,, origin3 } `},
+			},
+			wantOrigins: []string{"origin1", "originA", "origin3"},
+		},
 		{
 			name:        "empty header",
 			h:           http.Header{},
@@ -480,11 +746,23 @@ func (ms *mockServer) Close() {
 	ms.server.Close()
 }
 
-func (ms *mockServer) Client() (c Client, err error) {
-	return New(Config{
+func (ms *mockServer) Client() (ExtendedClient, error) {
+	c, err := New(Config{
 		RestURL:   ms.server.URL,
 		WsURL:     ms.server.URL,
 		ApiKey:    "apiKey",
 		ApiSecret: "apiSecret",
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.(ExtendedClient), nil
+}
+
+// extendedStream returns the Stream created by the client as an ExtendedStream.
+func extendedStream(s Stream, err error) (ExtendedStream, error) {
+	if err != nil {
+		return nil, err
+	}
+	return s.(ExtendedStream), nil
 }
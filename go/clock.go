@@ -0,0 +1,102 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ClockEstimate is the running estimate of the local clock offset to the Streams servers clock.
+// The estimate is updated from the Date header of the server responses, which has a second resolution,
+// and smoothed over the samples.
+type ClockEstimate struct {
+	Offset    time.Duration // Server clock minus local clock
+	RTT       time.Duration // Smoothed request round trip time
+	Samples   uint64        // Number of samples the estimate is based on, the estimate is unset if 0
+	UpdatedAt time.Time     // Local time of the last sample
+}
+
+// ServerNow returns the current time corrected by the clock offset.
+func (e ClockEstimate) ServerNow() time.Time {
+	return time.Now().Add(e.Offset)
+}
+
+// Since returns the duration elapsed since the server time t, such as a report observations timestamp,
+// corrected by the clock offset.
+func (e ClockEstimate) Since(t time.Time) time.Duration {
+	return e.ServerNow().Sub(t)
+}
+
+// clockEstimator maintains the ClockEstimate.
+type clockEstimator struct {
+	correct bool // correct the authentication timestamps
+
+	mu  sync.Mutex
+	est ClockEstimate
+}
+
+// observe updates the estimate with the Date header of a response to a request sent at the given time.
+// Returns false if the response has no Date header.
+func (c *clockEstimator) observe(sent, received time.Time, h http.Header) (ok bool) {
+	date, err := http.ParseTime(h.Get("Date"))
+	if err != nil {
+		return false
+	}
+
+	rtt := received.Sub(sent)
+	// the Date header is truncated to the second, on average half a second behind the server time
+	offset := date.Add(500 * time.Millisecond).Sub(sent.Add(rtt / 2))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.est.Samples == 0 {
+		c.est.Offset, c.est.RTT = offset, rtt
+	} else {
+		c.est.Offset += (offset - c.est.Offset) / 8
+		c.est.RTT += (rtt - c.est.RTT) / 8
+	}
+	c.est.Samples++
+	c.est.UpdatedAt = received
+	return true
+}
+
+func (c *clockEstimator) estimate() ClockEstimate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.est
+}
+
+// now returns the time used for authentication timestamps,
+// corrected by the clock offset if Config.ClockCorrection is set.
+func (c *clockEstimator) now() time.Time {
+	if c == nil || !c.correct {
+		return time.Now()
+	}
+	return c.estimate().ServerNow()
+}
+
+// serverNow returns the current time corrected by the clock offset, used to measure the reports latency.
+func (c *clockEstimator) serverNow() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.estimate().ServerNow()
+}
+
+func (c *client) ClockEstimate() ClockEstimate {
+	return c.clock.estimate()
+}
+
+func (c *client) MeasureClock(ctx context.Context) (e ClockEstimate, err error) {
+	sent := time.Now()
+	h, err := c.serverHeaders(ctx, c.endpoints.rest())
+	if err != nil {
+		return e, err
+	}
+	if !c.clock.observe(sent, time.Now(), h) {
+		return e, &RequestError{RequestID: requestID(ctx), Err: errors.New("client: server response has no Date header")}
+	}
+	return c.clock.estimate(), nil
+}
@@ -0,0 +1,73 @@
+package streams
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClient_MeasureClock(t *testing.T) {
+	const skew = time.Minute
+
+	timestamps := make(chan int64, 1)
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			ts, err := strconv.ParseInt(r.Header.Get(authzTSHeader), 10, 64)
+			if err != nil {
+				t.Errorf("error parsing timestamp: %s", err)
+			}
+			timestamps <- ts
+		}
+		w.Header().Set("Date", time.Now().Add(skew).UTC().Format(http.TimeFormat))
+		_, _ = w.Write([]byte(`{"feeds":[]}`))
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).clock.correct = true
+
+	if e := streamsClient.ClockEstimate(); e.Samples != 0 {
+		t.Fatalf("ClockEstimate() = %+v, want no samples", e)
+	}
+
+	e, err := streamsClient.MeasureClock(context.Background())
+	if err != nil {
+		t.Fatalf("MeasureClock() error = %s", err)
+	}
+	if e.Samples != 1 || e.Offset < skew-time.Second || e.Offset > skew+time.Second {
+		t.Errorf("MeasureClock() = %+v, want offset %s", e, skew)
+	}
+
+	if _, err = streamsClient.GetFeeds(context.Background()); err != nil {
+		t.Fatalf("GetFeeds() error = %s", err)
+	}
+	if e = streamsClient.ClockEstimate(); e.Samples != 2 {
+		t.Errorf("ClockEstimate() samples = %d, want 2", e.Samples)
+	}
+
+	d := time.Duration(<-timestamps-time.Now().Add(skew).UnixMilli()) * time.Millisecond
+	if d < -time.Second || d > time.Second {
+		t.Errorf("authentication timestamp off by %s from the server clock", d)
+	}
+}
+
+func TestClient_MeasureClockNoDate(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["Date"] = nil
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	if _, err = streamsClient.MeasureClock(context.Background()); err == nil {
+		t.Errorf("MeasureClock() error = nil, want error")
+	}
+}
@@ -0,0 +1,27 @@
+package streams
+
+import (
+	"time"
+)
+
+// ClockSanityConfig specifies the tolerances used to flag reports with an ObservationsTimestamp
+// too far in the future or in the past compared to the local clock.
+// A zero tolerance disables the respective check.
+type ClockSanityConfig struct {
+	MaxFutureSkew time.Duration // Maximum tolerated ObservationsTimestamp ahead of local time
+	MaxAge        time.Duration // Maximum tolerated ObservationsTimestamp behind local time
+	Reject        bool          // Drop anomalous reports instead of delivering them
+}
+
+// check returns a description of the clock anomaly of the observations timestamp, if any.
+func (c *ClockSanityConfig) check(observationsTimestamp uint64, now time.Time) (anomaly string) {
+	d := time.Unix(int64(observationsTimestamp), 0).Sub(now)
+	switch {
+	case c.MaxFutureSkew > 0 && d > c.MaxFutureSkew:
+		return "future dated by " + d.String()
+	case c.MaxAge > 0 && -d > c.MaxAge:
+		return "aged by " + (-d).String()
+	default:
+		return ""
+	}
+}
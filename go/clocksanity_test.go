@@ -0,0 +1,34 @@
+package streams
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockSanityConfig_check(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cfg := &ClockSanityConfig{MaxFutureSkew: 5 * time.Second, MaxAge: time.Minute}
+
+	tests := []struct {
+		name        string
+		ts          uint64
+		wantAnomaly bool
+	}{
+		{name: "current", ts: 1700000000},
+		{name: "within future skew", ts: 1700000005},
+		{name: "future dated", ts: 1700000006, wantAnomaly: true},
+		{name: "within max age", ts: 1700000000 - 60},
+		{name: "too old", ts: 1700000000 - 61, wantAnomaly: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if anomaly := cfg.check(tt.ts, now); (anomaly != "") != tt.wantAnomaly {
+				t.Errorf("check() = %q, wantAnomaly %v", anomaly, tt.wantAnomaly)
+			}
+		})
+	}
+
+	if anomaly := (&ClockSanityConfig{}).check(0, now); anomaly != "" {
+		t.Errorf("expected disabled checks, got %q", anomaly)
+	}
+}
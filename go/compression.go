@@ -0,0 +1,175 @@
+package streams
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	acceptEncoding = "gzip, deflate"
+
+	// defaultRestMaxDecodedSize is the default maximum decoded size of compressed rest responses.
+	defaultRestMaxDecodedSize = 64 << 20
+)
+
+// ErrResponseTooLarge is returned when a rest response body exceeds the configured size limit.
+var ErrResponseTooLarge = fmt.Errorf("client: response body too large")
+
+// TransferStats are the client rest transfer counters.
+type TransferStats struct {
+	Requests        uint64 // Total number of rest responses received
+	Compressed      uint64 // Total number of compressed rest responses received
+	BytesReceived   uint64 // Total number of response body bytes received over the network
+	BytesDecoded    uint64 // Total number of response body bytes after decompression
+	DecodeLimitHits uint64 // Total number of responses rejected for exceeding the decoded size limit
+
+	ResponseLimitHits uint64 // Total number of responses rejected for exceeding Config.RestMaxResponseSize
+	SlowRequests      uint64 // Total number of requests slower than Config.RestSlowThreshold
+	Reauths           uint64 // Total number of credentials refreshes after authentication errors
+}
+
+func (s TransferStats) String() (st string) {
+	return fmt.Sprintf(
+		"requests: %d, compressed: %d, bytes_received: %d, bytes_decoded: %d, decode_limit_hits: %d, response_limit_hits: %d, slow_requests: %d, reauths: %d",
+		s.Requests, s.Compressed, s.BytesReceived, s.BytesDecoded, s.DecodeLimitHits,
+		s.ResponseLimitHits, s.SlowRequests, s.Reauths,
+	)
+}
+
+type transferStats struct {
+	requests        atomic.Uint64
+	compressed      atomic.Uint64
+	bytesReceived   atomic.Uint64
+	bytesDecoded    atomic.Uint64
+	decodeLimitHits atomic.Uint64
+
+	responseLimitHits atomic.Uint64
+	slowRequests      atomic.Uint64
+}
+
+func (c *client) TransferStats() (st TransferStats) {
+	st.Requests = c.transfer.requests.Load()
+	st.Compressed = c.transfer.compressed.Load()
+	st.BytesReceived = c.transfer.bytesReceived.Load()
+	st.BytesDecoded = c.transfer.bytesDecoded.Load()
+	st.DecodeLimitHits = c.transfer.decodeLimitHits.Load()
+	st.ResponseLimitHits = c.transfer.responseLimitHits.Load()
+	st.SlowRequests = c.transfer.slowRequests.Load()
+	st.Reauths = c.creds.reauths.Load()
+	return st
+}
+
+func (c Config) restMaxDecodedSize() int64 {
+	if c.RestMaxDecodedSize > 0 {
+		return c.RestMaxDecodedSize
+	}
+	return defaultRestMaxDecodedSize
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n *atomic.Uint64
+}
+
+func (cr countingReader) Read(p []byte) (n int, err error) {
+	n, err = cr.r.Read(p)
+	cr.n.Add(uint64(n))
+	return n, err
+}
+
+// limitedReader fails with ErrResponseTooLarge once more than n bytes were read.
+type limitedReader struct {
+	r    io.Reader
+	n    int64
+	what string         // limited size description
+	hits *atomic.Uint64 // limit hits counter
+}
+
+func (lr *limitedReader) Read(p []byte) (n int, err error) {
+	if lr.n < 0 {
+		return 0, fmt.Errorf("%w, %s over limit", ErrResponseTooLarge, lr.what)
+	}
+	if int64(len(p)) > lr.n+1 {
+		p = p[:lr.n+1]
+	}
+	n, err = lr.r.Read(p)
+	lr.n -= int64(n)
+	if lr.n < 0 {
+		lr.hits.Add(1)
+		return n, fmt.Errorf("%w, %s over limit", ErrResponseTooLarge, lr.what)
+	}
+	return n, err
+}
+
+// bodyReader returns a reader of the response body decompressing it according to its Content-Encoding.
+// Bodies larger than Config.RestMaxResponseSize and decompressed bodies larger than
+// Config.RestMaxDecodedSize fail with ErrResponseTooLarge.
+// The returned reader must be closed, closing the response body is the caller responsibility.
+func (c *client) bodyReader(resp *http.Response) (body io.ReadCloser, err error) {
+	c.transfer.requests.Add(1)
+	var wire io.Reader = countingReader{r: resp.Body, n: &c.transfer.bytesReceived}
+	if limit := c.config.RestMaxResponseSize; limit > 0 {
+		if resp.ContentLength > limit {
+			c.transfer.responseLimitHits.Add(1)
+			return nil, fmt.Errorf("%w, content length %d over %d bytes", ErrResponseTooLarge, resp.ContentLength, limit)
+		}
+		wire = &limitedReader{r: wire, n: limit, what: "response size", hits: &c.transfer.responseLimitHits}
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" {
+		return io.NopCloser(countingReader{r: wire, n: &c.transfer.bytesDecoded}), nil
+	}
+
+	var dec io.ReadCloser
+	switch encoding {
+	case "gzip", "x-gzip":
+		dec, err = gzip.NewReader(wire)
+	case "deflate":
+		dec, err = zlib.NewReader(wire)
+	default:
+		return nil, fmt.Errorf("client: unsupported response content encoding %q", encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("client: error decompressing %s response body: %w", encoding, err)
+	}
+	c.transfer.compressed.Add(1)
+
+	// the body is handed over decoded, as done by the http.Transport transparent decompression
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	decoded := &limitedReader{
+		r:    countingReader{r: dec, n: &c.transfer.bytesDecoded},
+		n:    c.config.restMaxDecodedSize(),
+		what: "decompressed size",
+		hits: &c.transfer.decodeLimitHits,
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{decoded, dec}, nil
+}
+
+// readBody reads the whole response body, see bodyReader.
+func (c *client) readBody(resp *http.Response) (buf []byte, err error) {
+	body, err := c.bodyReader(resp)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if buf, err = io.ReadAll(body); errors.Is(err, ErrResponseTooLarge) {
+		return nil, err
+	}
+	return buf, err
+}
@@ -0,0 +1,153 @@
+package streams
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+func TestClient_RestCompression(t *testing.T) {
+	expectedFeeds := []*feed.Feed{
+		{FeedID: feed1},
+		{FeedID: feed2},
+	}
+	body, err := json.Marshal(feedsResponse{Feeds: expectedFeeds})
+	if err != nil {
+		t.Fatalf("failed to encode response: %s", err)
+	}
+
+	compress := func(encoding string, b []byte) []byte {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		switch encoding {
+		case "gzip":
+			w = gzip.NewWriter(&buf)
+		case "deflate":
+			w = zlib.NewWriter(&buf)
+		default:
+			return b
+		}
+		_, _ = w.Write(b)
+		_ = w.Close()
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name       string
+		encoding   string
+		body       []byte
+		maxDecoded int64
+		wantErr    error
+		want       TransferStats
+	}{
+		{name: "identity", body: body, want: TransferStats{Requests: 1}},
+		{name: "gzip", encoding: "gzip", body: body, want: TransferStats{Requests: 1, Compressed: 1}},
+		{name: "deflate", encoding: "deflate", body: body, want: TransferStats{Requests: 1, Compressed: 1}},
+		{
+			name:       "decoded size limit",
+			encoding:   "gzip",
+			body:       []byte(`{"feeds":[],"padding":"` + strings.Repeat("a", 1024) + `"}`),
+			maxDecoded: 512,
+			wantErr:    ErrResponseTooLarge,
+			want:       TransferStats{Requests: 1, Compressed: 1, DecodeLimitHits: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wire := compress(tt.encoding, tt.body)
+			ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Accept-Encoding"); got != acceptEncoding {
+					t.Errorf("Accept-Encoding = %q, want %q", got, acceptEncoding)
+				}
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				_, _ = w.Write(wire)
+			})
+			defer ms.Close()
+
+			streamsClient, err := ms.Client()
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+			streamsClient.(*client).config.RestCompression = true
+			streamsClient.(*client).config.RestMaxDecodedSize = tt.maxDecoded
+
+			feeds, err := streamsClient.GetFeeds(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetFeeds() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(feeds, expectedFeeds) {
+				t.Errorf("GetFeeds() = %#v, want %#v", feeds, expectedFeeds)
+			}
+
+			tt.want.BytesReceived = uint64(len(wire))
+			got := streamsClient.TransferStats()
+			if tt.wantErr == nil {
+				tt.want.BytesDecoded = uint64(len(tt.body))
+			} else {
+				tt.want.BytesDecoded = got.BytesDecoded
+			}
+			if got != tt.want {
+				t.Errorf("TransferStats() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_RestMaxResponseSize(t *testing.T) {
+	body := []byte(`{"feeds":[],"padding":"` + strings.Repeat("a", 1024) + `"}`)
+
+	tests := []struct {
+		name    string
+		chunked bool
+		limit   int64
+		wantErr error
+	}{
+		{name: "under limit", limit: 4096},
+		{name: "content length over limit", limit: 512, wantErr: ErrResponseTooLarge},
+		{name: "chunked over limit", chunked: true, limit: 512, wantErr: ErrResponseTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+				if tt.chunked {
+					_, _ = w.Write(body[:10])
+					w.(http.Flusher).Flush()
+					_, _ = w.Write(body[10:])
+					return
+				}
+				_, _ = w.Write(body)
+			})
+			defer ms.Close()
+
+			streamsClient, err := ms.Client()
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+			streamsClient.(*client).config.RestMaxResponseSize = tt.limit
+
+			if _, err = streamsClient.GetFeeds(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetFeeds() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var wantHits uint64
+			if tt.wantErr != nil {
+				wantHits = 1
+			}
+			if got := streamsClient.TransferStats().ResponseLimitHits; got != wantHits {
+				t.Errorf("TransferStats().ResponseLimitHits = %d, want %d", got, wantHits)
+			}
+		})
+	}
+}
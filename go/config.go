@@ -2,29 +2,178 @@ package streams
 
 import (
 	"net/http"
-	"net/url"
+	"time"
 )
 
+const defaultWsQueryAuthTTL = time.Minute
+
 // Config specifies the client configuration and dependencies.
 // If specified the Logger function will be used to log informational client activity.
 type Config struct {
 	ApiKey             string                        // Client Api key
 	ApiSecret          string                        // Client Api secret
 	RestURL            string                        // Rest Api url
-	restURL            *url.URL                      // Rest Api url
 	WsURL              string                        // Websocket Api url
-	wsURL              *url.URL                      // Websocket Api url
 	WsHA               bool                          // Use concurrent connections to multiple Streams servers
 	WsMaxReconnect     int                           // Maximum number of reconnection attempts for Stream underlying connections
+	RawBulkReports     bool                          // Return GetReports results as received, including duplicate feed reports
 	LogDebug           bool                          // Log debug information
 	InsecureSkipVerify bool                          // Skip server certificate chain and host name verification
 	Logger             func(format string, a ...any) // Logger function
 
+	// Credentials provides the credentials instead of ApiKey and ApiSecret, see CredentialsProvider.
+	Credentials CredentialsProvider
+
+	// AuthBodyMethods lists the HTTP methods whose request body is hashed in the request signatures,
+	// the body of every method by default as expected by the Data Streams servers. See auth.Scheme.
+	AuthBodyMethods []string
+
+	// OnSessionReplaced is called when the server closes a Stream connection because another
+	// connection using the same credentials replaced it. Replaced connections are not reconnected.
+	OnSessionReplaced func(host string, origin string)
+
+	// WsReconnectBackoff computes the delay between Stream connection reconnection attempts,
+	// defaults to DefaultBackoff. See BackoffStrategy.
+	WsReconnectBackoff BackoffStrategy
+
+	// WsOriginPolicy selects the origin of the Stream connection when WsHA is false,
+	// by default the server chooses it. See OriginPolicy.
+	WsOriginPolicy OriginPolicy
+	// WsPreferredOrigins lists the origins by preference for OriginPolicyPreferred.
+	WsPreferredOrigins []string
+
+	// WsOriginOverride is called when dialing a Stream connection with the origin name,
+	// empty when not in HA mode, and may return credentials and headers overrides for the origin.
+	WsOriginOverride func(origin string) *OriginOverride
+
+	// WsReadTimeout is the maximum duration to wait for a message on a Stream connection before it is
+	// closed and reconnected with ErrReadTimeout, detecting connections hung while pings are still answered,
+	// for example by a middlebox after a NAT timeout. Must exceed the expected interval between reports,
+	// disabled if 0.
+	WsReadTimeout time.Duration
+
+	// ConnStatusWindow rate limits the connection status callback of flapping Stream connections:
+	// the status changes of a connection within the window following a notified change are held and
+	// notified at the end of the window as a single ConnStatus, the latest change, summarizing them with
+	// its Flaps and FlapDuration. A closed connection status is notified immediately. Disabled if 0.
+	ConnStatusWindow time.Duration
+
+	// WsQueryAuth places the Stream connection signature in the url query parameters instead
+	// of the request headers, for gateways requiring signed urls. The signed url expires after WsQueryAuthTTL.
+	WsQueryAuth bool
+	// WsQueryAuthTTL is the signed url validity, defaults to 1 minute.
+	WsQueryAuthTTL time.Duration
+
+	// HandlerConcurrency is the number of workers calling the handler of Streams created with
+	// StreamWithHandler, defaults to 4.
+	HandlerConcurrency int
+
+	// UnknownFields specifies how the server response fields not modeled by the SDK are handled,
+	// ignored by default. In strict mode Stream reports with unknown fields are dropped and logged.
+	UnknownFields UnknownFields
+
+	// LazyFullReport keeps the full report of Stream reports hex encoded, decoded by
+	// ReportResponse.FullReportBytes, saving the decoding of reports never inspected.
+	// ReportResponse.FullReport is nil.
+	LazyFullReport bool
+
+	// ClockSanity enables flagging Stream reports with implausible observation timestamps
+	// compared to the local clock, see ClockSanityConfig.
+	ClockSanity *ClockSanityConfig
+
+	// Provenance enables recording the connections delivering each Stream report, see ProvenanceConfig.
+	Provenance *ProvenanceConfig
+
+	// Latency enables the smoothed end-to-end latency of each Stream feed in StatsV2, see LatencyConfig.
+	Latency *LatencyConfig
+
+	// Monotonicity enables checking the per feed ordering of the delivered Stream reports, see MonotonicityConfig.
+	Monotonicity *MonotonicityConfig
+
+	// MarketStatus enables tracking the market status of v4 Stream reports, see MarketStatusConfig.
+	MarketStatus *MarketStatusConfig
+
+	// Verification enables Stream reports verification, see VerificationConfig.
+	Verification *VerificationConfig
+
+	// OnAccept is called with each Stream report accepted after deduplication, before it is delivered.
+	// It may annotate the report, for example setting ReportResponse.Metadata, and returns false
+	// to drop the report. OnAccept is called from a single goroutine and must not block.
+	// The otellog package emits OpenTelemetry log events for the accepted reports through OnAccept.
+	OnAccept func(r *ReportResponse) (deliver bool)
+
+	// SharedDedup deduplicates Stream reports across consumers of the same feeds,
+	// only the consumer claiming a report delivers it, see SharedDedupStore.
+	SharedDedup SharedDedupStore
+
+	// Entitlement enables dropping the Stream feeds the account lost access to, see EntitlementConfig.
+	Entitlement *EntitlementConfig
+
+	// Abandon enables detecting and closing Streams no longer read, see AbandonConfig.
+	Abandon *AbandonConfig
+
+	// SLO enables Stream service level objectives tracking, see SLOConfig.
+	SLO *SLOConfig
+
+	// Alerts enables alerting on Stream stats thresholds, see AlertConfig.
+	Alerts *AlertConfig
+
+	// Redirects specifies how HTTP redirects are handled, see RedirectPolicy.
+	Redirects RedirectPolicy
+	// RedirectHosts lists the hosts, including the port if any, redirects are re-signed for
+	// when Redirects is RedirectResign.
+	RedirectHosts []string
+
+	// ClockCorrection corrects the authentication timestamps by the estimated clock offset to the server,
+	// for hosts with a drifting clock. See ExtendedClient.ClockEstimate.
+	ClockCorrection bool
+
+	// RestCompression requests gzip or deflate compressed rest responses, reducing the transfer time
+	// of large responses such as report pages over slow links. See ExtendedClient.TransferStats.
+	RestCompression bool
+	// RestMaxDecodedSize is the maximum size of a decompressed rest response body, defaults to 64MiB.
+	RestMaxDecodedSize int64
+	// RestMaxResponseSize is the maximum size of a rest response body as received, unlimited if 0.
+	// Larger responses fail with ErrResponseTooLarge.
+	RestMaxResponseSize int64
+
+	// LatestReportCache enables caching the GetLatestReport reports until they expire, see LatestReportCacheConfig.
+	LatestReportCache *LatestReportCacheConfig
+
+	// DNSCache enables caching the endpoints resolved addresses for faster reconnections, see DNSCacheConfig.
+	DNSCache *DNSCacheConfig
+
+	// RestConcurrency is the maximum number of concurrent rest requests, unlimited if 0.
+	// Waiting requests are admitted by Priority, highest first, and low priority requests
+	// retried with refreshed credentials wait behind the others. See PriorityCtxKey.
+	RestConcurrency int
+
+	// RestSlowThreshold is the duration after which a rest request is reported as slow,
+	// logged, counted in TransferStats.SlowRequests and passed to OnSlowRequest. Disabled if 0.
+	RestSlowThreshold time.Duration
+	// OnSlowRequest is called with each slow rest request, see RestSlowThreshold.
+	OnSlowRequest func(SlowRequest)
+
 	// InspectHttp intercepts http responses for rest requests.
-	// The response object must not be modified.
+	// The response object must not be modified. Overridden per call with InspectHttpResponseCtxKey.
 	InspectHttpResponse func(*http.Response)
 }
 
+// OriginOverride overrides the Stream connection credentials and headers for an origin.
+// Empty credentials default to the Config credentials.
+type OriginOverride struct {
+	ApiKey    string      // Origin Api key
+	ApiSecret string      // Origin Api secret
+	Header    http.Header // Headers set on the origin connection request
+}
+
+func (c Config) wsQueryAuthTTL() time.Duration {
+	if c.WsQueryAuthTTL <= 0 {
+		return defaultWsQueryAuthTTL
+	}
+	return c.WsQueryAuthTTL
+}
+
 func (c Config) logInfo(format string, a ...any) {
 	if c.Logger != nil {
 		c.Logger(format, a...)
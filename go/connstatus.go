@@ -0,0 +1,268 @@
+package streams
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DisconnectReason is the reason a Stream connection was lost.
+type DisconnectReason string
+
+const (
+	DisconnectNone         DisconnectReason = ""              // Connection established
+	DisconnectStreamClosed DisconnectReason = "stream_closed" // Stream closed by the application
+	DisconnectContextDone  DisconnectReason = "context_done"  // Context the Stream was created with is done
+	DisconnectPingTimeout  DisconnectReason = "ping_timeout"  // Server did not answer a ping in time
+	DisconnectReadTimeout  DisconnectReason = "read_timeout"  // No message received within Config.WsReadTimeout
+	DisconnectServerClose  DisconnectReason = "server_close"  // Server closed the connection with a close frame
+	DisconnectReadError    DisconnectReason = "read_error"    // Connection failed with a network or protocol error
+)
+
+// ConnState is the lifecycle state of a Stream connection or, as returned by ExtendedStream.State,
+// the aggregate state of the Stream connections.
+type ConnState int32
+
+const (
+	ConnConnecting   ConnState = iota // Connection being established
+	ConnConnected                     // Connection established, all the Stream connections for the aggregate state
+	ConnDegraded                      // Aggregate state only: some of the Stream HA connections are reconnecting
+	ConnReconnecting                  // Connection lost and being re-established, all the Stream connections for the aggregate state
+	ConnClosed                        // Connection closed for good, the Stream is closed for the aggregate state
+)
+
+func (cs ConnState) String() string {
+	switch cs {
+	case ConnConnecting:
+		return "connecting"
+	case ConnConnected:
+		return "connected"
+	case ConnDegraded:
+		return "degraded"
+	case ConnReconnecting:
+		return "reconnecting"
+	case ConnClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// ConnStatus describes a Stream connection status change.
+type ConnStatus struct {
+	ConnID      uint64           // Stable identifier of the connection within the Stream
+	Seq         uint64           // Connection session number, shared by the connect and disconnect events of a session
+	Connected   bool             // Whether the connection was established or lost, State is ConnConnected
+	State       ConnState        // Connection state after the change, ConnReconnecting or ConnClosed when lost
+	StreamState ConnState        // Aggregate state of the Stream connections after the change, see ExtendedStream.State
+	Host        string           // Connection host
+	Origin      string           // Connection origin, empty when not in HA mode
+	Labels      Labels           // Stream labels
+	Reason      DisconnectReason // Reason the connection was lost, DisconnectNone when connected
+	CloseCode   int              // Websocket close code sent by the server, only set for DisconnectServerClose
+	CloseReason string           // Websocket close reason sent by the server, only set for DisconnectServerClose
+
+	// Flaps is the number of status changes of the connection summarized by this one, the latest,
+	// when coalesced within Config.ConnStatusWindow, 0 if the status change was not coalesced.
+	Flaps int
+	// FlapDuration is the time between the first and the last coalesced status changes.
+	FlapDuration time.Duration
+}
+
+// connNotifier calls the connection status callback in the order the status changes happened
+// without blocking the connections, coalescing the status changes of flapping connections
+// if window is set.
+type connNotifier struct {
+	mu       sync.Mutex
+	callback func(ConnStatus)
+	queue    []ConnStatus
+	running  bool
+
+	window  time.Duration
+	windows map[uint64]*connWindow
+}
+
+// connWindow holds the status changes of a connection following a notified change within the window.
+type connWindow struct {
+	timer       *time.Timer
+	latest      ConnStatus
+	count       int
+	first, last time.Time
+}
+
+func (n *connNotifier) notify(st ConnStatus) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.window > 0 && n.coalesce(&st, time.Now()) {
+		return
+	}
+	n.enqueue(st)
+}
+
+// coalesce holds the status change if the connection changed status within the window, returning true.
+// Otherwise a window is started for the connection. Closing the connection ends its window, the closed
+// status summarizing the held changes. Must be called with the lock held.
+func (n *connNotifier) coalesce(st *ConnStatus, now time.Time) bool {
+	w := n.windows[st.ConnID]
+	if w == nil {
+		if st.State == ConnClosed {
+			return false
+		}
+		if n.windows == nil {
+			n.windows = map[uint64]*connWindow{}
+		}
+		id := st.ConnID
+		w = &connWindow{}
+		w.timer = time.AfterFunc(n.window, func() { n.flush(id, w) })
+		n.windows[id] = w
+		return false
+	}
+
+	if w.count == 0 {
+		w.first = now
+	}
+	w.count++
+	w.last = now
+	if st.State == ConnClosed {
+		w.timer.Stop()
+		delete(n.windows, st.ConnID)
+		st.Flaps, st.FlapDuration = w.count, w.last.Sub(w.first)
+		return false
+	}
+	w.latest = *st
+	return true
+}
+
+// flush notifies the latest status change held in the connection window summarizing the held changes,
+// starting a new window, or ends the window if no change was held.
+func (n *connNotifier) flush(id uint64, w *connWindow) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.windows[id] != w {
+		// ended by the connection closing
+		return
+	}
+	if w.count == 0 {
+		delete(n.windows, id)
+		return
+	}
+
+	st := w.latest
+	st.Flaps, st.FlapDuration = w.count, w.last.Sub(w.first)
+	w.count = 0
+	w.timer.Reset(n.window)
+	n.enqueue(st)
+}
+
+// enqueue queues the status change for the callback. Must be called with the lock held.
+func (n *connNotifier) enqueue(st ConnStatus) {
+	n.queue = append(n.queue, st)
+	if !n.running {
+		n.running = true
+		go n.drain()
+	}
+}
+
+func (n *connNotifier) drain() {
+	for {
+		n.mu.Lock()
+		if len(n.queue) == 0 {
+			n.running = false
+			n.mu.Unlock()
+			return
+		}
+		st := n.queue[0]
+		n.queue = n.queue[1:]
+		n.mu.Unlock()
+
+		n.callback(st)
+	}
+}
+
+func (s *stream) notifyConnect(conn *wsConn) {
+	if s.connNotifier == nil {
+		return
+	}
+
+	s.connNotifier.notify(ConnStatus{
+		ConnID:      conn.id,
+		Seq:         conn.seq,
+		Connected:   true,
+		State:       ConnConnected,
+		StreamState: s.State(),
+		Host:        conn.host,
+		Origin:      conn.origin,
+		Labels:      s.labels,
+	})
+}
+
+// notifyDisconnect notifies the connection loss with the reason derived from the read error.
+func (s *stream) notifyDisconnect(conn *wsConn, err error) {
+	if s.connNotifier == nil {
+		return
+	}
+
+	st := ConnStatus{
+		ConnID:      conn.id,
+		Seq:         conn.seq,
+		State:       ConnState(conn.state.Load()),
+		StreamState: s.State(),
+		Host:        conn.host,
+		Origin:      conn.origin,
+		Labels:      s.labels,
+	}
+
+	code, reason, closed := closeStatus(err)
+	switch {
+	case s.closed.Load():
+		st.Reason = DisconnectStreamClosed
+	case s.streamCtx.Err() != nil:
+		st.Reason = DisconnectContextDone
+	case conn.pingFailed.Load():
+		st.Reason = DisconnectPingTimeout
+	case errors.Is(err, ErrReadTimeout):
+		st.Reason = DisconnectReadTimeout
+	case closed:
+		st.Reason, st.CloseCode, st.CloseReason = DisconnectServerClose, code, reason
+	default:
+		st.Reason = DisconnectReadError
+	}
+	s.connNotifier.notify(st)
+}
+
+// State returns the aggregate state of the Stream connections: ConnConnected while all the connections
+// are established, ConnDegraded while only some are, ConnReconnecting while none are and ConnClosed
+// once the Stream is closed.
+func (s *stream) State() ConnState {
+	if s.closed.Load() || s.streamCtx.Err() != nil {
+		return ConnClosed
+	}
+
+	var connected, reconnecting, connecting int
+	for _, conn := range s.conns {
+		switch ConnState(conn.state.Load()) {
+		case ConnConnected:
+			connected++
+		case ConnReconnecting:
+			reconnecting++
+		case ConnConnecting:
+			connecting++
+		}
+	}
+	switch {
+	case connected > 0 && connected == len(s.conns):
+		return ConnConnected
+	case connected > 0:
+		return ConnDegraded
+	case reconnecting > 0:
+		return ConnReconnecting
+	case connecting > 0:
+		return ConnConnecting
+	default:
+		return ConnClosed
+	}
+}
@@ -0,0 +1,261 @@
+package streams
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestClient_StreamWithConnStatusCallback(t *testing.T) {
+	origin1Connects := &atomic.Uint64{}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Add(cllAvailOriginsHeader, "{001,002}")
+			w.WriteHeader(200)
+			return
+		}
+
+		conn, err := websocket.Accept(
+			w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover},
+		)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		// drop the first origin 001 connection
+		if r.Header.Get(cllOriginHeader) == "001" && origin1Connects.Add(1) == 1 {
+			time.Sleep(50 * time.Millisecond)
+			return
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).config.WsHA = true
+
+	var mu sync.Mutex
+	statuses := map[string][]ConnStatus{}
+	sub, err := streamsClient.StreamWithConnStatusCallback(context.Background(), []feed.ID{feed1},
+		func(st ConnStatus) {
+			mu.Lock()
+			defer mu.Unlock()
+			statuses[st.Origin] = append(statuses[st.Origin], st)
+		})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	for {
+		mu.Lock()
+		n := len(statuses["001"])
+		mu.Unlock()
+		if n == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	host := statuses["001"][0].Host
+	// the aggregate state of the other origin connect event depends on the timing of the dropped connection
+	statuses["002"][0].StreamState = 0
+	expected := map[string][]ConnStatus{
+		"001": {
+			{ConnID: 1, Seq: 1, Connected: true, State: ConnConnected, StreamState: ConnConnected, Host: host, Origin: "001"},
+			{ConnID: 1, Seq: 1, Connected: false, State: ConnReconnecting, StreamState: ConnDegraded, Host: host, Origin: "001", Reason: DisconnectReadError},
+			{ConnID: 1, Seq: 2, Connected: true, State: ConnConnected, StreamState: ConnConnected, Host: host, Origin: "001"},
+		},
+		"002": {
+			{ConnID: 2, Seq: 1, Connected: true, State: ConnConnected, Host: host, Origin: "002"},
+		},
+	}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("statuses = %v, want %v", statuses, expected)
+	}
+}
+
+func TestClient_StreamDisconnectReason(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		if r.Header.Get("X-Test-Close") != "" {
+			_ = conn.Close(websocket.StatusTryAgainLater, "overloaded")
+			return
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	tests := []struct {
+		name        string
+		close       bool
+		cancel      bool
+		readTimeout time.Duration
+		want        ConnStatus
+	}{
+		{
+			name:  "server close",
+			close: true,
+			want: ConnStatus{
+				ConnID: 1, Seq: 1, State: ConnReconnecting, StreamState: ConnReconnecting, Reason: DisconnectServerClose,
+				CloseCode: int(websocket.StatusTryAgainLater), CloseReason: "overloaded",
+			},
+		},
+		{name: "stream closed", want: ConnStatus{ConnID: 1, Seq: 1, State: ConnClosed, StreamState: ConnClosed, Reason: DisconnectStreamClosed}},
+		{name: "context done", cancel: true, want: ConnStatus{ConnID: 1, Seq: 1, State: ConnClosed, StreamState: ConnClosed, Reason: DisconnectContextDone}},
+		{
+			name:        "read timeout",
+			readTimeout: 100 * time.Millisecond,
+			want:        ConnStatus{ConnID: 1, Seq: 1, State: ConnReconnecting, StreamState: ConnReconnecting, Reason: DisconnectReadTimeout},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streamsClient, err := ms.Client()
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+			streamsClient.(*client).config.WsReadTimeout = tt.readTimeout
+
+			statuses := make(chan ConnStatus, 16)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.close {
+				ctx = context.WithValue(ctx, CustomHeadersCtxKey, http.Header{"X-Test-Close": {"1"}})
+			}
+			sub, err := streamsClient.StreamWithConnStatusCallback(ctx, []feed.ID{feed1}, func(st ConnStatus) {
+				statuses <- st
+			})
+			if err != nil {
+				t.Fatalf("error subscribing %s", err)
+			}
+			defer sub.Close()
+
+			if st := <-statuses; !st.Connected {
+				t.Fatalf("first status = %v, want connected", st)
+			}
+			switch {
+			case tt.cancel:
+				cancel()
+			case !tt.close && tt.readTimeout == 0:
+				_ = sub.Close()
+			}
+
+			st := <-statuses
+			st.Host = ""
+			if !reflect.DeepEqual(st, tt.want) {
+				t.Errorf("disconnect status = %+v, want %+v", st, tt.want)
+			}
+			if tt.want.StreamState == ConnClosed && sub.State() != ConnClosed {
+				t.Errorf("State() = %s, want %s", sub.State(), ConnClosed)
+			}
+		})
+	}
+}
+
+func TestConnNotifier_Coalesce(t *testing.T) {
+	const window = 100 * time.Millisecond
+	statuses := make(chan ConnStatus, 16)
+	n := &connNotifier{callback: func(st ConnStatus) { statuses <- st }, window: window}
+
+	next := func() ConnStatus {
+		select {
+		case st := <-statuses:
+			return st
+		case <-time.After(5 * window):
+			t.Fatalf("no status notified")
+			return ConnStatus{}
+		}
+	}
+	connected := func(id, seq uint64) ConnStatus {
+		return ConnStatus{ConnID: id, Seq: seq, Connected: true, State: ConnConnected}
+	}
+	lost := func(id, seq uint64) ConnStatus {
+		return ConnStatus{ConnID: id, Seq: seq, State: ConnReconnecting, Reason: DisconnectReadError}
+	}
+
+	// the first change is notified immediately, the flapping ones are summarized at the end of the window
+	n.notify(connected(1, 1))
+	if st := next(); !reflect.DeepEqual(st, connected(1, 1)) {
+		t.Errorf("first status = %+v, want %+v", st, connected(1, 1))
+	}
+	n.notify(lost(1, 1))
+	n.notify(connected(1, 2))
+	n.notify(connected(2, 1))
+	n.notify(lost(1, 2))
+	n.notify(connected(1, 3))
+	if st := next(); !reflect.DeepEqual(st, connected(2, 1)) {
+		t.Errorf("other connection status = %+v, want %+v", st, connected(2, 1))
+	}
+	st := next()
+	if st.FlapDuration <= 0 || st.FlapDuration >= window {
+		t.Errorf("FlapDuration = %s, want within the window", st.FlapDuration)
+	}
+	st.FlapDuration = 0
+	want := connected(1, 3)
+	want.Flaps = 4
+	if !reflect.DeepEqual(st, want) {
+		t.Errorf("coalesced status = %+v, want %+v", st, want)
+	}
+
+	// a change after a quiet window is notified immediately
+	time.Sleep(3 * window)
+	n.notify(lost(1, 3))
+	select {
+	case st := <-statuses:
+		if !reflect.DeepEqual(st, lost(1, 3)) {
+			t.Errorf("status after quiet window = %+v, want %+v", st, lost(1, 3))
+		}
+	case <-time.After(window / 2):
+		t.Fatalf("status after quiet window not notified immediately")
+	}
+
+	// closing notifies immediately, summarizing the held changes
+	n.notify(connected(1, 4))
+	closed := ConnStatus{ConnID: 1, Seq: 4, State: ConnClosed, Reason: DisconnectStreamClosed}
+	n.notify(closed)
+	select {
+	case st := <-statuses:
+		if st.Flaps != 2 || st.State != ConnClosed {
+			t.Errorf("closed status = %+v, want 2 flaps", st)
+		}
+	case <-time.After(window / 2):
+		t.Fatalf("closed status not notified immediately")
+	}
+
+	time.Sleep(2 * window)
+	select {
+	case st := <-statuses:
+		t.Errorf("unexpected status %+v after close", st)
+	default:
+	}
+}
@@ -0,0 +1,93 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// CredentialsProvider provides the client credentials, for deployments rotating them.
+// When a request or a Stream connection is rejected with a 401 or 403 status code,
+// the credentials are refreshed once and the request retried before returning the error.
+type CredentialsProvider interface {
+	// Credentials returns the current credentials, refresh is set when the server rejected
+	// the previously returned ones.
+	Credentials(ctx context.Context, refresh bool) (apiKey, apiSecret string, err error)
+}
+
+// credentials caches the credentials of a CredentialsProvider,
+// or holds the Config credentials if no provider is configured.
+type credentials struct {
+	provider CredentialsProvider
+
+	mu        sync.Mutex
+	loaded    bool
+	apiKey    string
+	apiSecret string
+
+	reauths atomic.Uint64
+}
+
+func newCredentials(cfg Config) *credentials {
+	return &credentials{
+		provider:  cfg.Credentials,
+		loaded:    cfg.Credentials == nil,
+		apiKey:    cfg.ApiKey,
+		apiSecret: cfg.ApiSecret,
+	}
+}
+
+// get returns the current credentials, loading them from the provider on first use.
+func (c *credentials) get(ctx context.Context) (apiKey, apiSecret string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.loaded {
+		if err = c.load(ctx, false); err != nil {
+			return "", "", err
+		}
+	}
+	return c.apiKey, c.apiSecret, nil
+}
+
+// refresh refreshes the rejected credentials, returns false without a provider.
+// Concurrent refreshes of the same rejected credentials are only performed once.
+func (c *credentials) refresh(ctx context.Context, rejectedKey, rejectedSecret string) (ok bool, err error) {
+	if c.provider == nil {
+		return false, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.loaded && (c.apiKey != rejectedKey || c.apiSecret != rejectedSecret) {
+		return true, nil
+	}
+	if err = c.load(ctx, true); err != nil {
+		return false, err
+	}
+	c.reauths.Add(1)
+	return true, nil
+}
+
+// load fetches the credentials from the provider, must be called with the mutex held.
+func (c *credentials) load(ctx context.Context, refresh bool) (err error) {
+	apiKey, apiSecret, err := c.provider.Credentials(ctx, refresh)
+	if err != nil {
+		return fmt.Errorf("client: error getting credentials: %w", err)
+	}
+	c.apiKey, c.apiSecret, c.loaded = apiKey, apiSecret, true
+	return nil
+}
+
+// set replaces the credentials, used until the provider, if any, is refreshed.
+func (c *credentials) set(apiKey, apiSecret string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiKey, c.apiSecret, c.loaded = apiKey, apiSecret, true
+}
+
+// isAuthError reports whether the status code rejects the request credentials.
+func isAuthError(statusCode int) bool {
+	return statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden
+}
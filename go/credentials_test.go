@@ -0,0 +1,97 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+type mockCredentialsProvider struct {
+	mu        sync.Mutex
+	keys      []string
+	refreshes int
+	err       error
+}
+
+func (p *mockCredentialsProvider) Credentials(_ context.Context, refresh bool) (apiKey, apiSecret string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return "", "", p.err
+	}
+	if refresh && len(p.keys) > 1 {
+		p.refreshes++
+		p.keys = p.keys[1:]
+	}
+	return p.keys[0], p.keys[0] + "Secret", nil
+}
+
+func TestClient_CredentialsProvider(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(authzHeader) != "validKey" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != apiV1WS {
+			_, _ = w.Write([]byte(`{"feeds":[]}`))
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	tests := []struct {
+		name        string
+		keys        []string
+		err         error
+		stream      bool
+		wantErr     bool
+		wantReauths uint64
+	}{
+		{name: "valid", keys: []string{"validKey"}},
+		{name: "rest refresh", keys: []string{"expiredKey", "validKey"}, wantReauths: 1},
+		{name: "stream refresh", keys: []string{"expiredKey", "validKey"}, stream: true, wantReauths: 1},
+		{name: "still rejected", keys: []string{"expiredKey", "revokedKey", "validKey"}, wantErr: true, wantReauths: 1},
+		{name: "provider error", err: errors.New("vault unavailable"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &mockCredentialsProvider{keys: tt.keys, err: tt.err}
+			streamsClient, err := New(Config{RestURL: ms.server.URL, WsURL: ms.server.URL, Credentials: provider})
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+
+			if tt.stream {
+				var sub Stream
+				sub, err = streamsClient.Stream(context.Background(), []feed.ID{feed1})
+				if err == nil {
+					_ = sub.Close()
+				}
+			} else {
+				_, err = streamsClient.GetFeeds(context.Background())
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := streamsClient.(ExtendedClient).TransferStats().Reauths; got != tt.wantReauths {
+				t.Errorf("TransferStats().Reauths = %d, want %d", got, tt.wantReauths)
+			}
+		})
+	}
+}
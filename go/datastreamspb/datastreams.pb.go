@@ -0,0 +1,735 @@
+// Code generated by go run ./internal/gen; DO NOT EDIT.
+
+// Protobuf definitions of the Data Streams reports, for forwarding reports over gRPC pipelines.
+// Their Go types are generated by protoc-gen-go in the datastreamspb package, see datastreamspb/generate.go.
+// The ReportResponse and VnData messages are converted by the report/codec package.
+// Big integers are decimal strings.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: datastreams.proto
+
+package datastreamspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeedId                []byte `protobuf:"bytes,1,opt,name=feed_id,json=feedId,proto3" json:"feed_id,omitempty"`
+	FullReport            []byte `protobuf:"bytes,2,opt,name=full_report,json=fullReport,proto3" json:"full_report,omitempty"`
+	ValidFromTimestamp    uint64 `protobuf:"varint,3,opt,name=valid_from_timestamp,json=validFromTimestamp,proto3" json:"valid_from_timestamp,omitempty"`
+	ObservationsTimestamp uint64 `protobuf:"varint,4,opt,name=observations_timestamp,json=observationsTimestamp,proto3" json:"observations_timestamp,omitempty"`
+}
+
+func (x *ReportResponse) Reset() {
+	*x = ReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_datastreams_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportResponse) ProtoMessage() {}
+
+func (x *ReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_datastreams_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportResponse.ProtoReflect.Descriptor instead.
+func (*ReportResponse) Descriptor() ([]byte, []int) {
+	return file_datastreams_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReportResponse) GetFeedId() []byte {
+	if x != nil {
+		return x.FeedId
+	}
+	return nil
+}
+
+func (x *ReportResponse) GetFullReport() []byte {
+	if x != nil {
+		return x.FullReport
+	}
+	return nil
+}
+
+func (x *ReportResponse) GetValidFromTimestamp() uint64 {
+	if x != nil {
+		return x.ValidFromTimestamp
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetObservationsTimestamp() uint64 {
+	if x != nil {
+		return x.ObservationsTimestamp
+	}
+	return 0
+}
+
+type V1Data struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeedId                []byte `protobuf:"bytes,1,opt,name=feed_id,json=feedId,proto3" json:"feed_id,omitempty"`
+	ObservationsTimestamp uint32 `protobuf:"varint,2,opt,name=observations_timestamp,json=observationsTimestamp,proto3" json:"observations_timestamp,omitempty"`
+	BenchmarkPrice        string `protobuf:"bytes,3,opt,name=benchmark_price,json=benchmarkPrice,proto3" json:"benchmark_price,omitempty"`
+	Bid                   string `protobuf:"bytes,4,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask                   string `protobuf:"bytes,5,opt,name=ask,proto3" json:"ask,omitempty"`
+	CurrentBlockNum       uint64 `protobuf:"varint,6,opt,name=current_block_num,json=currentBlockNum,proto3" json:"current_block_num,omitempty"`
+	CurrentBlockHash      []byte `protobuf:"bytes,7,opt,name=current_block_hash,json=currentBlockHash,proto3" json:"current_block_hash,omitempty"`
+	ValidFromBlockNum     uint64 `protobuf:"varint,8,opt,name=valid_from_block_num,json=validFromBlockNum,proto3" json:"valid_from_block_num,omitempty"`
+	CurrentBlockTimestamp uint64 `protobuf:"varint,9,opt,name=current_block_timestamp,json=currentBlockTimestamp,proto3" json:"current_block_timestamp,omitempty"`
+}
+
+func (x *V1Data) Reset() {
+	*x = V1Data{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_datastreams_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *V1Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*V1Data) ProtoMessage() {}
+
+func (x *V1Data) ProtoReflect() protoreflect.Message {
+	mi := &file_datastreams_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use V1Data.ProtoReflect.Descriptor instead.
+func (*V1Data) Descriptor() ([]byte, []int) {
+	return file_datastreams_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *V1Data) GetFeedId() []byte {
+	if x != nil {
+		return x.FeedId
+	}
+	return nil
+}
+
+func (x *V1Data) GetObservationsTimestamp() uint32 {
+	if x != nil {
+		return x.ObservationsTimestamp
+	}
+	return 0
+}
+
+func (x *V1Data) GetBenchmarkPrice() string {
+	if x != nil {
+		return x.BenchmarkPrice
+	}
+	return ""
+}
+
+func (x *V1Data) GetBid() string {
+	if x != nil {
+		return x.Bid
+	}
+	return ""
+}
+
+func (x *V1Data) GetAsk() string {
+	if x != nil {
+		return x.Ask
+	}
+	return ""
+}
+
+func (x *V1Data) GetCurrentBlockNum() uint64 {
+	if x != nil {
+		return x.CurrentBlockNum
+	}
+	return 0
+}
+
+func (x *V1Data) GetCurrentBlockHash() []byte {
+	if x != nil {
+		return x.CurrentBlockHash
+	}
+	return nil
+}
+
+func (x *V1Data) GetValidFromBlockNum() uint64 {
+	if x != nil {
+		return x.ValidFromBlockNum
+	}
+	return 0
+}
+
+func (x *V1Data) GetCurrentBlockTimestamp() uint64 {
+	if x != nil {
+		return x.CurrentBlockTimestamp
+	}
+	return 0
+}
+
+type V2Data struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeedId                []byte `protobuf:"bytes,1,opt,name=feed_id,json=feedId,proto3" json:"feed_id,omitempty"`
+	ValidFromTimestamp    uint32 `protobuf:"varint,2,opt,name=valid_from_timestamp,json=validFromTimestamp,proto3" json:"valid_from_timestamp,omitempty"`
+	ObservationsTimestamp uint32 `protobuf:"varint,3,opt,name=observations_timestamp,json=observationsTimestamp,proto3" json:"observations_timestamp,omitempty"`
+	NativeFee             string `protobuf:"bytes,4,opt,name=native_fee,json=nativeFee,proto3" json:"native_fee,omitempty"`
+	LinkFee               string `protobuf:"bytes,5,opt,name=link_fee,json=linkFee,proto3" json:"link_fee,omitempty"`
+	ExpiresAt             uint32 `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	BenchmarkPrice        string `protobuf:"bytes,7,opt,name=benchmark_price,json=benchmarkPrice,proto3" json:"benchmark_price,omitempty"`
+}
+
+func (x *V2Data) Reset() {
+	*x = V2Data{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_datastreams_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *V2Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*V2Data) ProtoMessage() {}
+
+func (x *V2Data) ProtoReflect() protoreflect.Message {
+	mi := &file_datastreams_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use V2Data.ProtoReflect.Descriptor instead.
+func (*V2Data) Descriptor() ([]byte, []int) {
+	return file_datastreams_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *V2Data) GetFeedId() []byte {
+	if x != nil {
+		return x.FeedId
+	}
+	return nil
+}
+
+func (x *V2Data) GetValidFromTimestamp() uint32 {
+	if x != nil {
+		return x.ValidFromTimestamp
+	}
+	return 0
+}
+
+func (x *V2Data) GetObservationsTimestamp() uint32 {
+	if x != nil {
+		return x.ObservationsTimestamp
+	}
+	return 0
+}
+
+func (x *V2Data) GetNativeFee() string {
+	if x != nil {
+		return x.NativeFee
+	}
+	return ""
+}
+
+func (x *V2Data) GetLinkFee() string {
+	if x != nil {
+		return x.LinkFee
+	}
+	return ""
+}
+
+func (x *V2Data) GetExpiresAt() uint32 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *V2Data) GetBenchmarkPrice() string {
+	if x != nil {
+		return x.BenchmarkPrice
+	}
+	return ""
+}
+
+type V3Data struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeedId                []byte `protobuf:"bytes,1,opt,name=feed_id,json=feedId,proto3" json:"feed_id,omitempty"`
+	ValidFromTimestamp    uint32 `protobuf:"varint,2,opt,name=valid_from_timestamp,json=validFromTimestamp,proto3" json:"valid_from_timestamp,omitempty"`
+	ObservationsTimestamp uint32 `protobuf:"varint,3,opt,name=observations_timestamp,json=observationsTimestamp,proto3" json:"observations_timestamp,omitempty"`
+	NativeFee             string `protobuf:"bytes,4,opt,name=native_fee,json=nativeFee,proto3" json:"native_fee,omitempty"`
+	LinkFee               string `protobuf:"bytes,5,opt,name=link_fee,json=linkFee,proto3" json:"link_fee,omitempty"`
+	ExpiresAt             uint32 `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	BenchmarkPrice        string `protobuf:"bytes,7,opt,name=benchmark_price,json=benchmarkPrice,proto3" json:"benchmark_price,omitempty"`
+	Bid                   string `protobuf:"bytes,8,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask                   string `protobuf:"bytes,9,opt,name=ask,proto3" json:"ask,omitempty"`
+}
+
+func (x *V3Data) Reset() {
+	*x = V3Data{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_datastreams_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *V3Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*V3Data) ProtoMessage() {}
+
+func (x *V3Data) ProtoReflect() protoreflect.Message {
+	mi := &file_datastreams_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use V3Data.ProtoReflect.Descriptor instead.
+func (*V3Data) Descriptor() ([]byte, []int) {
+	return file_datastreams_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *V3Data) GetFeedId() []byte {
+	if x != nil {
+		return x.FeedId
+	}
+	return nil
+}
+
+func (x *V3Data) GetValidFromTimestamp() uint32 {
+	if x != nil {
+		return x.ValidFromTimestamp
+	}
+	return 0
+}
+
+func (x *V3Data) GetObservationsTimestamp() uint32 {
+	if x != nil {
+		return x.ObservationsTimestamp
+	}
+	return 0
+}
+
+func (x *V3Data) GetNativeFee() string {
+	if x != nil {
+		return x.NativeFee
+	}
+	return ""
+}
+
+func (x *V3Data) GetLinkFee() string {
+	if x != nil {
+		return x.LinkFee
+	}
+	return ""
+}
+
+func (x *V3Data) GetExpiresAt() uint32 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *V3Data) GetBenchmarkPrice() string {
+	if x != nil {
+		return x.BenchmarkPrice
+	}
+	return ""
+}
+
+func (x *V3Data) GetBid() string {
+	if x != nil {
+		return x.Bid
+	}
+	return ""
+}
+
+func (x *V3Data) GetAsk() string {
+	if x != nil {
+		return x.Ask
+	}
+	return ""
+}
+
+type V4Data struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeedId                []byte `protobuf:"bytes,1,opt,name=feed_id,json=feedId,proto3" json:"feed_id,omitempty"`
+	ValidFromTimestamp    uint32 `protobuf:"varint,2,opt,name=valid_from_timestamp,json=validFromTimestamp,proto3" json:"valid_from_timestamp,omitempty"`
+	ObservationsTimestamp uint32 `protobuf:"varint,3,opt,name=observations_timestamp,json=observationsTimestamp,proto3" json:"observations_timestamp,omitempty"`
+	NativeFee             string `protobuf:"bytes,4,opt,name=native_fee,json=nativeFee,proto3" json:"native_fee,omitempty"`
+	LinkFee               string `protobuf:"bytes,5,opt,name=link_fee,json=linkFee,proto3" json:"link_fee,omitempty"`
+	ExpiresAt             uint32 `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	BenchmarkPrice        string `protobuf:"bytes,7,opt,name=benchmark_price,json=benchmarkPrice,proto3" json:"benchmark_price,omitempty"`
+	MarketStatus          uint32 `protobuf:"varint,8,opt,name=market_status,json=marketStatus,proto3" json:"market_status,omitempty"`
+}
+
+func (x *V4Data) Reset() {
+	*x = V4Data{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_datastreams_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *V4Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*V4Data) ProtoMessage() {}
+
+func (x *V4Data) ProtoReflect() protoreflect.Message {
+	mi := &file_datastreams_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use V4Data.ProtoReflect.Descriptor instead.
+func (*V4Data) Descriptor() ([]byte, []int) {
+	return file_datastreams_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *V4Data) GetFeedId() []byte {
+	if x != nil {
+		return x.FeedId
+	}
+	return nil
+}
+
+func (x *V4Data) GetValidFromTimestamp() uint32 {
+	if x != nil {
+		return x.ValidFromTimestamp
+	}
+	return 0
+}
+
+func (x *V4Data) GetObservationsTimestamp() uint32 {
+	if x != nil {
+		return x.ObservationsTimestamp
+	}
+	return 0
+}
+
+func (x *V4Data) GetNativeFee() string {
+	if x != nil {
+		return x.NativeFee
+	}
+	return ""
+}
+
+func (x *V4Data) GetLinkFee() string {
+	if x != nil {
+		return x.LinkFee
+	}
+	return ""
+}
+
+func (x *V4Data) GetExpiresAt() uint32 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *V4Data) GetBenchmarkPrice() string {
+	if x != nil {
+		return x.BenchmarkPrice
+	}
+	return ""
+}
+
+func (x *V4Data) GetMarketStatus() uint32 {
+	if x != nil {
+		return x.MarketStatus
+	}
+	return 0
+}
+
+var File_datastreams_proto protoreflect.FileDescriptor
+
+var file_datastreams_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73,
+	0x22, 0xb3, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x65, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x66, 0x65, 0x65, 0x64, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x66, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x30, 0x0a,
+	0x14, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x35, 0x0a, 0x16, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x15, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xe8, 0x02, 0x0a, 0x06, 0x56, 0x31, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x65, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x66, 0x65, 0x65, 0x64, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x16, 0x6f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x5f, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x62, 0x65, 0x6e, 0x63,
+	0x68, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x61, 0x73, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x73, 0x6b, 0x12, 0x2a,
+	0x0a, 0x11, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x6e, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2f, 0x0a, 0x14, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x72, 0x6f,
+	0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x12, 0x36, 0x0a, 0x17, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x22, 0x8c, 0x02, 0x0a, 0x06, 0x56, 0x32, 0x44, 0x61, 0x74, 0x61, 0x12, 0x17, 0x0a, 0x07,
+	0x66, 0x65, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x66,
+	0x65, 0x65, 0x64, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x12, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x35, 0x0a, 0x16, 0x6f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x46, 0x65, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x46, 0x65, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x65, 0x6e, 0x63, 0x68,
+	0x6d, 0x61, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x62, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x22, 0xb0, 0x02, 0x0a, 0x06, 0x56, 0x33, 0x44, 0x61, 0x74, 0x61, 0x12, 0x17, 0x0a, 0x07, 0x66,
+	0x65, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x66, 0x65,
+	0x65, 0x64, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x66, 0x72,
+	0x6f, 0x6d, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x12, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x35, 0x0a, 0x16, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x46, 0x65, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6c, 0x69, 0x6e, 0x6b, 0x46, 0x65, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x65, 0x6e, 0x63, 0x68, 0x6d,
+	0x61, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x62, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x62, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x73, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x61, 0x73, 0x6b, 0x22, 0xb1, 0x02, 0x0a, 0x06, 0x56, 0x34, 0x44, 0x61, 0x74, 0x61, 0x12, 0x17,
+	0x0a, 0x07, 0x66, 0x65, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x66, 0x65, 0x65, 0x64, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x35, 0x0a, 0x16, 0x6f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x46, 0x65, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x46, 0x65, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x65, 0x6e,
+	0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x62, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x6b, 0x69, 0x74, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x2d, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x67, 0x6f, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_datastreams_proto_rawDescOnce sync.Once
+	file_datastreams_proto_rawDescData = file_datastreams_proto_rawDesc
+)
+
+func file_datastreams_proto_rawDescGZIP() []byte {
+	file_datastreams_proto_rawDescOnce.Do(func() {
+		file_datastreams_proto_rawDescData = protoimpl.X.CompressGZIP(file_datastreams_proto_rawDescData)
+	})
+	return file_datastreams_proto_rawDescData
+}
+
+var file_datastreams_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_datastreams_proto_goTypes = []interface{}{
+	(*ReportResponse)(nil), // 0: datastreams.ReportResponse
+	(*V1Data)(nil),         // 1: datastreams.V1Data
+	(*V2Data)(nil),         // 2: datastreams.V2Data
+	(*V3Data)(nil),         // 3: datastreams.V3Data
+	(*V4Data)(nil),         // 4: datastreams.V4Data
+}
+var file_datastreams_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_datastreams_proto_init() }
+func file_datastreams_proto_init() {
+	if File_datastreams_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_datastreams_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_datastreams_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*V1Data); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_datastreams_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*V2Data); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_datastreams_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*V3Data); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_datastreams_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*V4Data); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_datastreams_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_datastreams_proto_goTypes,
+		DependencyIndexes: file_datastreams_proto_depIdxs,
+		MessageInfos:      file_datastreams_proto_msgTypes,
+	}.Build()
+	File_datastreams_proto = out.File
+	file_datastreams_proto_rawDesc = nil
+	file_datastreams_proto_goTypes = nil
+	file_datastreams_proto_depIdxs = nil
+}
@@ -0,0 +1,7 @@
+// Package datastreamspb holds the Go types of the Data Streams reports protobuf messages, generated by
+// protoc-gen-go from datastreams.proto, itself generated from the report schemas, see the report package.
+// The streams package ReportResponse and the vN.Data of the report data version packages are converted
+// to and from these messages by the report/codec package.
+package datastreamspb
+
+//go:generate protoc -I.. --go_out=.. --go_opt=module=github.com/smartcontractkit/data-streams-sdk/go ../datastreams.proto
@@ -0,0 +1,222 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// debugRecentEvents is the number of recent events of each Stream kept for DebugDump.
+const debugRecentEvents = 32
+
+// streamRegistry tracks the open Streams of a client.
+type streamRegistry struct {
+	mu      sync.Mutex
+	streams map[*stream]struct{}
+}
+
+func (r *streamRegistry) add(s *stream) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.streams == nil {
+		r.streams = map[*stream]struct{}{}
+	}
+	r.streams[s] = struct{}{}
+}
+
+func (r *streamRegistry) remove(s *stream) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.streams, s)
+}
+
+func (r *streamRegistry) list() (streams []*stream) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for s := range r.streams {
+		streams = append(streams, s)
+	}
+	return streams
+}
+
+// recentEvents keeps the last Stream events.
+type recentEvents struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recentEvents) add(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.events) == debugRecentEvents {
+		copy(r.events, r.events[1:])
+		r.events = r.events[:len(r.events)-1]
+	}
+	r.events = append(r.events, e)
+}
+
+func (r *recentEvents) list() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+type debugDump struct {
+	Time       time.Time          `json:"time"`
+	GoVersion  string             `json:"goVersion"`
+	SDKVersion string             `json:"sdkVersion"`
+	CommitHash string             `json:"commitHash,omitempty"`
+	Schemas    []feed.FeedVersion `json:"schemaVersions"`
+	Config     map[string]any     `json:"config"`
+	Transfer   TransferStats      `json:"transfer"`
+	Clock      ClockEstimate      `json:"clock"`
+	Streams    []streamDump       `json:"streams"`
+}
+
+type streamDump struct {
+	Feeds        []string    `json:"feeds"`
+	Labels       Labels      `json:"labels,omitempty"`
+	Closed       bool        `json:"closed"`
+	CloseError   string      `json:"closeError,omitempty"`
+	Stats        Stats       `json:"stats"`
+	SLO          *SLOStatus  `json:"slo,omitempty"`
+	Conns        []connDump  `json:"conns"`
+	RecentEvents []eventDump `json:"recentEvents"`
+}
+
+type connDump struct {
+	ID        uint64 `json:"id"`
+	Host      string `json:"host"`
+	Origin    string `json:"origin,omitempty"`
+	State     string `json:"state"`
+	RequestID string `json:"requestId"`
+}
+
+type eventDump struct {
+	Type    EventType `json:"type"`
+	Message string    `json:"message,omitempty"`
+	Time    time.Time `json:"time"`
+	Host    string    `json:"host"`
+	Origin  string    `json:"origin,omitempty"`
+}
+
+// DebugDump writes a support bundle describing the client and its open Streams as indented JSON.
+// Credentials are masked and hooks are only reported as set.
+func (c *client) DebugDump(ctx context.Context, w io.Writer) (err error) {
+	d := debugDump{
+		Time:       time.Now().UTC(),
+		GoVersion:  runtime.Version(),
+		SDKVersion: Version(),
+		CommitHash: CommitHash,
+		Schemas:    SupportedSchemaVersions(),
+		Config:     c.config.redacted(),
+		Transfer:   c.TransferStats(),
+		Clock:      c.ClockEstimate(),
+		Streams:    []streamDump{},
+	}
+
+	for _, s := range c.streams.list() {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		d.Streams = append(d.Streams, s.dump())
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(d); err != nil {
+		return fmt.Errorf("client: error writing debug dump: %w", err)
+	}
+	return nil
+}
+
+func (s *stream) dump() (d streamDump) {
+	d.Feeds = feedIdsToStringList(s.feeds())
+	d.Labels = s.labels
+	d.Closed = s.closed.Load()
+	if err, ok := s.closeError.Load().(error); ok {
+		d.CloseError = err.Error()
+	}
+	d.Stats = s.Stats()
+	if s.slo != nil {
+		slo := s.SLO()
+		d.SLO = &slo
+	}
+
+	d.Conns = []connDump{}
+	for _, conn := range s.conns {
+		conn.mu.Lock()
+		requestID := conn.requestID
+		conn.mu.Unlock()
+		d.Conns = append(d.Conns, connDump{
+			ID:        conn.id,
+			Host:      conn.host,
+			Origin:    conn.origin,
+			State:     ConnState(conn.state.Load()).String(),
+			RequestID: requestID,
+		})
+	}
+
+	d.RecentEvents = []eventDump{}
+	for _, e := range s.recent.list() {
+		d.RecentEvents = append(d.RecentEvents, eventDump{
+			Type: e.Type, Message: e.Message, Time: e.Time, Host: e.Host, Origin: e.Origin,
+		})
+	}
+	return d
+}
+
+// redacted returns the config exported fields with the credentials masked,
+// urls redacted and the hooks and dependencies only reported as set.
+func (c Config) redacted() (m map[string]any) {
+	m = map[string]any{}
+	v := reflect.ValueOf(c)
+	for x := 0; x < v.NumField(); x++ {
+		f, fv := v.Type().Field(x), v.Field(x)
+		if !f.IsExported() {
+			continue
+		}
+
+		switch {
+		case f.Name == "ApiKey":
+			m[f.Name] = maskSecret(c.ApiKey, 4)
+		case f.Name == "ApiSecret":
+			m[f.Name] = maskSecret(c.ApiSecret, 0)
+		case f.Name == "RestURL" || f.Name == "WsURL":
+			if u, err := url.Parse(fv.String()); err == nil {
+				m[f.Name] = u.Redacted()
+			} else {
+				m[f.Name] = "invalid url"
+			}
+		default:
+			switch fv.Kind() {
+			case reflect.Func, reflect.Interface, reflect.Ptr, reflect.Map:
+				if !fv.IsNil() {
+					m[f.Name] = "set"
+				}
+			default:
+				m[f.Name] = fv.Interface()
+			}
+		}
+	}
+	return m
+}
+
+// maskSecret masks the secret keeping at most its first n characters.
+func maskSecret(s string, n int) string {
+	if s == "" {
+		return ""
+	}
+	if len(s) <= 2*n {
+		n = 0
+	}
+	return s[:n] + "****"
+}
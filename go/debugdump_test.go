@@ -0,0 +1,103 @@
+package streams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestClient_DebugDump(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		_ = conn.Write(context.Background(), websocket.MessageText, []byte(`{"type":"notice","message":"hello"}`))
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	c, err := New(Config{
+		RestURL:   ms.server.URL,
+		WsURL:     ms.server.URL,
+		ApiKey:    "clientApiKey",
+		ApiSecret: "clientApiSecret",
+		Logger:    func(string, ...any) {},
+	})
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient := c.(ExtendedClient)
+
+	ctx := context.WithValue(context.Background(), LabelsCtxKey, Labels{"service": "pricer"})
+	sub, err := extendedStream(streamsClient.Stream(ctx, []feed.ID{feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	<-sub.Events()
+
+	var buf bytes.Buffer
+	if err = streamsClient.DebugDump(context.Background(), &buf); err != nil {
+		t.Fatalf("DebugDump() error = %s", err)
+	}
+	if strings.Contains(buf.String(), "clientApiSecret") || strings.Contains(buf.String(), "clientApiKey") {
+		t.Errorf("DebugDump() leaks credentials: %s", buf.String())
+	}
+
+	var d debugDump
+	if err = json.Unmarshal(buf.Bytes(), &d); err != nil {
+		t.Fatalf("error decoding dump: %s", err)
+	}
+	if d.Config["ApiKey"] != "clie****" || d.Config["ApiSecret"] != "****" || d.Config["Logger"] != "set" {
+		t.Errorf("DebugDump() config = %v", d.Config)
+	}
+	if len(d.Streams) != 1 {
+		t.Fatalf("DebugDump() streams = %d, want 1", len(d.Streams))
+	}
+	st := d.Streams[0]
+	if st.Feeds[0] != feed1str || st.Labels["service"] != "pricer" || len(st.Conns) != 1 ||
+		st.Conns[0].State != "connected" || len(st.RecentEvents) != 1 || st.RecentEvents[0].Message != "hello" {
+		t.Errorf("DebugDump() stream = %+v", st)
+	}
+
+	_ = sub.Close()
+	buf.Reset()
+	if err = streamsClient.DebugDump(context.Background(), &buf); err != nil {
+		t.Fatalf("DebugDump() error = %s", err)
+	}
+	if err = json.Unmarshal(buf.Bytes(), &d); err != nil {
+		t.Fatalf("error decoding dump: %s", err)
+	}
+	if len(d.Streams) != 0 {
+		t.Errorf("DebugDump() streams = %d after close, want 0", len(d.Streams))
+	}
+
+	// a stream ended by its context is unregistered without Close
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err = streamsClient.Stream(ctx, []feed.ID{feed1}); err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for len(c.(*client).streams.list()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("stream still registered after its context is done")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,239 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDNSCacheTTL = time.Minute
+	dialTimeout        = 30 * time.Second
+	dialKeepAlive      = 30 * time.Second
+	dialFallbackDelay  = 300 * time.Millisecond // delay before racing the next address, as net.Dialer.FallbackDelay
+)
+
+// HostResolver resolves host names to addresses, implemented by net.Resolver.
+type HostResolver interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+}
+
+// DNSCacheConfig enables caching the resolved addresses of the rest and websocket endpoints.
+// The endpoint hosts are resolved in the background as soon as the client is created by New, which then
+// issues DNS queries before any request or Stream, and the cached
+// addresses are used while fresh. Expired addresses are still used to dial while they are
+// refreshed in the background, so reconnections after a failover do not wait for a DNS round trip.
+// The addresses are dialed in order, the next address being raced with the pending dials when an address
+// fails or does not connect within 300ms. Addresses failing to connect are resolved again before giving up.
+type DNSCacheConfig struct {
+	TTL      time.Duration // Duration the resolved addresses are fresh, defaults to 1 minute
+	Resolver HostResolver  // Resolver, defaults to net.DefaultResolver
+}
+
+// ResolveError is returned when the host of a Data Streams endpoint could not be resolved,
+// distinguishing resolution failures from connection failures.
+type ResolveError struct {
+	Host string
+	Err  error
+}
+
+func (e *ResolveError) Error() string {
+	return fmt.Sprintf("client: error resolving host %s: %s", e.Host, e.Err)
+}
+
+func (e *ResolveError) Unwrap() error {
+	return e.Err
+}
+
+// DialError is returned when none of the resolved addresses of a Data Streams endpoint host accepted a connection.
+type DialError struct {
+	Host  string
+	Addrs []string
+	Err   error
+}
+
+func (e *DialError) Error() string {
+	return fmt.Sprintf("client: error connecting to host %s addresses %v: %s", e.Host, e.Addrs, e.Err)
+}
+
+func (e *DialError) Unwrap() error {
+	return e.Err
+}
+
+type dnsEntry struct {
+	addrs      []string
+	expires    time.Time
+	refreshing bool
+}
+
+// dnsCache resolves and caches host addresses for dialing.
+type dnsCache struct {
+	ttl      time.Duration
+	resolver HostResolver
+	dial     func(ctx context.Context, network, address string) (net.Conn, error)
+	logger   func(format string, a ...any)
+
+	mu      sync.Mutex
+	entries map[string]*dnsEntry
+}
+
+func newDNSCache(cfg Config) *dnsCache {
+	dialer := &net.Dialer{Timeout: dialTimeout, KeepAlive: dialKeepAlive}
+	d := &dnsCache{
+		ttl:      cfg.DNSCache.TTL,
+		resolver: cfg.DNSCache.Resolver,
+		dial:     dialer.DialContext,
+		logger:   cfg.logDebug,
+		entries:  map[string]*dnsEntry{},
+	}
+	if d.ttl <= 0 {
+		d.ttl = defaultDNSCacheTTL
+	}
+	if d.resolver == nil {
+		d.resolver = net.DefaultResolver
+	}
+	return d
+}
+
+// preresolve resolves the hosts in the background.
+func (d *dnsCache) preresolve(hosts ...string) {
+	for _, host := range hosts {
+		if host == "" || net.ParseIP(host) != nil {
+			continue
+		}
+		go func() {
+			if _, err := d.resolve(context.Background(), host); err != nil {
+				d.logger("client: error pre-resolving host %s: %s", host, err)
+			}
+		}()
+	}
+}
+
+// lookup returns the cached addresses of the host, refreshing expired addresses in the background,
+// or resolves the host if not cached.
+func (d *dnsCache) lookup(ctx context.Context, host string) (addrs []string, err error) {
+	d.mu.Lock()
+	e, ok := d.entries[host]
+	if ok && len(e.addrs) > 0 {
+		addrs = e.addrs
+		if time.Now().After(e.expires) && !e.refreshing {
+			e.refreshing = true
+			go func() {
+				if _, err := d.resolve(context.Background(), host); err != nil {
+					d.logger("client: error refreshing host %s addresses: %s", host, err)
+				}
+			}()
+		}
+		d.mu.Unlock()
+		return addrs, nil
+	}
+	d.mu.Unlock()
+
+	return d.resolve(ctx, host)
+}
+
+// resolve resolves the host and caches its addresses.
+func (d *dnsCache) resolve(ctx context.Context, host string) (addrs []string, err error) {
+	addrs, err = d.resolver.LookupHost(ctx, host)
+	if err == nil && len(addrs) == 0 {
+		err = errors.New("no addresses")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e := d.entries[host]
+	if e == nil {
+		e = &dnsEntry{}
+		d.entries[host] = e
+	}
+	e.refreshing = false
+	if err != nil {
+		return nil, &ResolveError{Host: host, Err: err}
+	}
+	e.addrs, e.expires = addrs, time.Now().Add(d.ttl)
+	return addrs, nil
+}
+
+// dialContext dials the address using the cached host addresses, implementing http.Transport.DialContext.
+func (d *dnsCache) dialContext(ctx context.Context, network, address string) (conn net.Conn, err error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		return d.dial(ctx, network, address)
+	}
+
+	addrs, err := d.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if conn, err = d.dialAny(ctx, network, addrs, port); err == nil {
+		return conn, nil
+	}
+
+	// the cached addresses may be outdated
+	fresh, rerr := d.resolve(ctx, host)
+	if rerr != nil || slices.Equal(fresh, addrs) {
+		return nil, &DialError{Host: host, Addrs: addrs, Err: err}
+	}
+	d.logger("client: host %s addresses changed from %v to %v", host, addrs, fresh)
+	if conn, err = d.dialAny(ctx, network, fresh, port); err != nil {
+		return nil, &DialError{Host: host, Addrs: fresh, Err: err}
+	}
+	return conn, nil
+}
+
+// dialAny dials the addresses in order returning the first connection established. Like Happy Eyeballs,
+// the next address is dialed when an address fails or after dialFallbackDelay, racing the pending dials,
+// so an unresponsive address does not hold the connection for the whole dial timeout.
+func (d *dnsCache) dialAny(ctx context.Context, network string, addrs []string, port string) (conn net.Conn, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type dialed struct {
+		conn net.Conn
+		err  error
+	}
+	results := make(chan dialed, len(addrs))
+	next, pending := 0, 0
+	dialNext := func() {
+		addr := net.JoinHostPort(addrs[next], port)
+		next++
+		pending++
+		go func() {
+			conn, err := d.dial(ctx, network, addr)
+			results <- dialed{conn, err}
+		}()
+	}
+
+	dialNext()
+	fallback := time.NewTimer(dialFallbackDelay)
+	defer fallback.Stop()
+	for pending > 0 {
+		select {
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				// close the connections of the dials racing to completion
+				go func(n int) {
+					for ; n > 0; n-- {
+						if r := <-results; r.conn != nil {
+							_ = r.conn.Close()
+						}
+					}
+				}(pending)
+				return r.conn, nil
+			}
+			err = r.err
+		case <-fallback.C:
+		}
+
+		if next < len(addrs) && ctx.Err() == nil {
+			dialNext()
+			fallback.Reset(dialFallbackDelay)
+		}
+	}
+	return nil, err
+}
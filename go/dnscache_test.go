@@ -0,0 +1,162 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mockResolver struct {
+	mu      sync.Mutex
+	addrs   [][]string // addresses returned by each lookup, the last one is repeated
+	err     error
+	lookups int
+}
+
+func (r *mockResolver) LookupHost(_ context.Context, _ string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.addrs[min(r.lookups, len(r.addrs))-1], nil
+}
+
+func (r *mockResolver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lookups
+}
+
+func TestDNSCache_lookup(t *testing.T) {
+	resolver := &mockResolver{addrs: [][]string{{"10.0.0.1"}, {"10.0.0.2"}}}
+	d := newDNSCache(Config{DNSCache: &DNSCacheConfig{TTL: 50 * time.Millisecond, Resolver: resolver}})
+
+	for x := 0; x < 3; x++ {
+		addrs, err := d.lookup(context.Background(), "streams.test")
+		if err != nil || addrs[0] != "10.0.0.1" {
+			t.Fatalf("lookup() = %v, %v, want [10.0.0.1]", addrs, err)
+		}
+	}
+	if n := resolver.count(); n != 1 {
+		t.Errorf("lookups = %d, want 1", n)
+	}
+
+	// expired addresses are used while refreshed
+	time.Sleep(60 * time.Millisecond)
+	if addrs, err := d.lookup(context.Background(), "streams.test"); err != nil || addrs[0] != "10.0.0.1" {
+		t.Fatalf("lookup() = %v, %v, want the expired [10.0.0.1]", addrs, err)
+	}
+	for deadline := time.Now().Add(time.Second); resolver.count() < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if addrs, _ := d.lookup(context.Background(), "streams.test"); addrs[0] == "10.0.0.2" {
+			return
+		}
+	}
+	t.Errorf("addresses not refreshed")
+}
+
+func TestClient_DNSCache(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"feeds":[]}`))
+	})
+	defer ms.Close()
+
+	u, _ := url.Parse(ms.server.URL)
+	_, port, _ := net.SplitHostPort(u.Host)
+	// the server only listens on 127.0.0.1, connections to 127.0.0.2 are refused where it is a local address
+	unreachable := "192.0.2.1"
+	if ln, err := net.Listen("tcp", "127.0.0.2:0"); err == nil {
+		_ = ln.Close()
+		unreachable = "127.0.0.2"
+	}
+
+	tests := []struct {
+		name     string
+		resolver *mockResolver
+		wantErr  any
+	}{
+		{name: "resolved", resolver: &mockResolver{addrs: [][]string{{"127.0.0.1"}}}},
+		{name: "changed addresses", resolver: &mockResolver{addrs: [][]string{{unreachable}, {"127.0.0.1"}}}},
+		{name: "resolve error", resolver: &mockResolver{err: errors.New("no such host")}, wantErr: new(*ResolveError)},
+		{name: "dial error", resolver: &mockResolver{addrs: [][]string{{unreachable}}}, wantErr: new(*DialError)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streamsClient, err := New(Config{
+				RestURL:   "http://streams.test:" + port,
+				ApiKey:    "apiKey",
+				ApiSecret: "apiSecret",
+				DNSCache:  &DNSCacheConfig{Resolver: tt.resolver},
+			})
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err = streamsClient.GetFeeds(ctx)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("GetFeeds() error = %s", err)
+				}
+				return
+			}
+			if !errors.As(err, tt.wantErr) {
+				t.Errorf("GetFeeds() error = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDNSCache_dialAny(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+	defer ln.Close()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	d := newDNSCache(Config{DNSCache: &DNSCacheConfig{}})
+	canceled := make(chan struct{})
+	d.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		switch address {
+		case net.JoinHostPort("10.0.0.1", port):
+			// unresponsive address
+			<-ctx.Done()
+			close(canceled)
+			return nil, ctx.Err()
+		case net.JoinHostPort("10.0.0.2", port):
+			return nil, errors.New("connection refused")
+		}
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, address)
+	}
+
+	start := time.Now()
+	conn, err := d.dialAny(context.Background(), "tcp", []string{"10.0.0.1", "10.0.0.2", "127.0.0.1"}, port)
+	if err != nil {
+		t.Fatalf("dialAny() error = %s", err)
+	}
+	defer conn.Close()
+	if elapsed := time.Since(start); elapsed > 2*dialFallbackDelay {
+		t.Errorf("dialAny() connected after %s, want less than %s", elapsed, 2*dialFallbackDelay)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Errorf("unresponsive address dial not canceled")
+	}
+
+	if _, err = d.dialAny(context.Background(), "tcp", []string{"10.0.0.2"}, port); err == nil {
+		t.Errorf("dialAny() error = nil, want error")
+	}
+}
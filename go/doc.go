@@ -1,4 +1,7 @@
 // Package streams implements a client library for the Data Streams API providing
 // a domain oriented abstraction for both report Streams and point in time
 // retrieval with fault tolerant capabilities.
+//
+// Stream websockets use nhooyr.io/websocket by default. Building with the
+// gorilla tag selects github.com/gorilla/websocket instead.
 package streams
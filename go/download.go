@@ -0,0 +1,239 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// ErrDownloadBudgetExceeded is returned by Downloader.Run when the wall-clock budget
+// is exhausted before all the tasks are completed.
+var ErrDownloadBudgetExceeded = fmt.Errorf("client: download budget exceeded")
+
+// DownloadTask is a historical download of the reports of a feed
+// observed in the [Start, End) timestamp range.
+type DownloadTask struct {
+	FeedID feed.ID
+	Start  uint64
+	End    uint64
+}
+
+func (t DownloadTask) key() string {
+	return fmt.Sprintf("%s:%d:%d", t.FeedID.String(), t.Start, t.End)
+}
+
+// DownloadStore persists the download progress so an interrupted download can be resumed.
+type DownloadStore interface {
+	// Load returns the saved next page timestamp per task key, empty if nothing was saved.
+	Load(ctx context.Context) (progress map[string]uint64, err error)
+
+	// Save stores the next page timestamp per task key.
+	Save(ctx context.Context, progress map[string]uint64) error
+}
+
+// DownloadConfig specifies the Downloader tasks and limits.
+type DownloadConfig struct {
+	Tasks       []DownloadTask // Tasks to download
+	Concurrency int            // Number of tasks downloaded concurrently, defaults to 1
+	Rate        float64        // Maximum page requests per second, unlimited if 0
+	Budget      time.Duration  // Maximum wall-clock duration of a Run, unlimited if 0
+	Store       DownloadStore  // Progress store, progress is not persisted if nil
+
+	// Sink receives each downloaded page of reports of a task, oldest first.
+	// Calls are serialized. The progress is saved after Sink returns, a page may be
+	// delivered again when resuming a download interrupted before the progress was saved.
+	Sink func(ctx context.Context, task DownloadTask, reports []*ReportResponse) error
+}
+
+// DownloadResult summarizes a Downloader Run.
+type DownloadResult struct {
+	Completed []DownloadTask // Tasks fully downloaded
+	Pending   []DownloadTask // Tasks not completed
+	Pages     int            // Number of pages requested
+	Reports   int            // Number of reports delivered to the Sink
+}
+
+// Downloader schedules historical report downloads within rate limits
+// and a wall-clock budget, persisting the progress to resume after crashes.
+// Page requests have PriorityLow unless the Run context sets a Priority.
+type Downloader struct {
+	client Client
+	config DownloadConfig
+
+	mu       sync.Mutex
+	progress map[string]uint64
+	result   DownloadResult
+}
+
+// NewDownloader creates a new Downloader using the given Client.
+func NewDownloader(c Client, cfg DownloadConfig) (d *Downloader, err error) {
+	if cfg.Sink == nil {
+		return nil, fmt.Errorf("client: download sink not provided")
+	}
+
+	for _, t := range cfg.Tasks {
+		if t.Start >= t.End {
+			return nil, fmt.Errorf("client: invalid download task %s range [%d, %d)", t.FeedID.String(), t.Start, t.End)
+		}
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+
+	return &Downloader{client: c, config: cfg}, nil
+}
+
+// Run downloads the tasks resuming from the saved progress.
+// Run returns ErrDownloadBudgetExceeded if the budget is exhausted, the
+// result lists the tasks still pending. Run must not be called concurrently.
+func (d *Downloader) Run(ctx context.Context) (r DownloadResult, err error) {
+	d.progress = map[string]uint64{}
+	d.result = DownloadResult{}
+	if d.config.Store != nil {
+		progress, err := d.config.Store.Load(ctx)
+		if err != nil {
+			return r, fmt.Errorf("client: download progress load: %w", err)
+		}
+		for k, v := range progress {
+			d.progress[k] = v
+		}
+	}
+
+	// backfills yield to the other requests of the client unless a priority is set
+	ctx = withDefaultPriority(ctx, PriorityLow)
+
+	if d.config.Budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.config.Budget)
+		defer cancel()
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var limit <-chan time.Time
+	if d.config.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / d.config.Rate))
+		defer ticker.Stop()
+		limit = ticker.C
+	}
+
+	tasks := make(chan DownloadTask)
+	var wg sync.WaitGroup
+	for x := 0; x < d.config.Concurrency; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tasks {
+				if err := d.download(ctx, t, limit); err != nil {
+					cancel(err)
+				}
+			}
+		}()
+	}
+
+schedule:
+	for _, t := range d.config.Tasks {
+		select {
+		case <-ctx.Done():
+			break schedule
+		case tasks <- t:
+		}
+	}
+	close(tasks)
+	wg.Wait()
+
+	for _, t := range d.config.Tasks {
+		if d.progress[t.key()] >= t.End {
+			d.result.Completed = append(d.result.Completed, t)
+		} else {
+			d.result.Pending = append(d.result.Pending, t)
+		}
+	}
+
+	err = context.Cause(ctx)
+	if errors.Is(err, context.DeadlineExceeded) && d.config.Budget > 0 {
+		err = ErrDownloadBudgetExceeded
+	}
+	return d.result, err
+}
+
+// download fetches the pages of the task from its saved progress.
+func (d *Downloader) download(ctx context.Context, t DownloadTask, limit <-chan time.Time) (err error) {
+	d.mu.Lock()
+	ts := max(d.progress[t.key()], t.Start)
+	d.mu.Unlock()
+
+	for ts < t.End {
+		if limit != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-limit:
+			}
+		}
+
+		page, err := d.client.GetReportPage(ctx, t.FeedID, ts)
+		if err != nil {
+			return err
+		}
+
+		var reports []*ReportResponse
+		for _, rep := range page.Reports {
+			if rep.ObservationsTimestamp >= ts && rep.ObservationsTimestamp < t.End {
+				reports = append(reports, rep)
+			}
+		}
+
+		next := page.NextPageTS
+		if len(page.Reports) == 0 || next <= ts {
+			next = t.End
+		}
+
+		if err = d.commit(ctx, t, reports, min(next, t.End)); err != nil {
+			return err
+		}
+		ts = next
+	}
+	return nil
+}
+
+// commit delivers the page reports to the sink and saves the task progress.
+func (d *Downloader) commit(ctx context.Context, t DownloadTask, reports []*ReportResponse, next uint64) (err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.result.Pages++
+	if len(reports) > 0 {
+		if err = d.config.Sink(ctx, t, reports); err != nil {
+			return fmt.Errorf("client: download sink: %w", err)
+		}
+		d.result.Reports += len(reports)
+	}
+
+	d.progress[t.key()] = next
+	if d.config.Store != nil {
+		if err = d.config.Store.Save(ctx, d.progress); err != nil {
+			return fmt.Errorf("client: download progress save: %w", err)
+		}
+	}
+	return nil
+}
+
+// FileDownloadStore implements a DownloadStore using a json file.
+type FileDownloadStore struct {
+	Path string
+}
+
+func (f FileDownloadStore) Load(_ context.Context) (progress map[string]uint64, err error) {
+	_, err = readJSONFile(f.Path, &progress)
+	return progress, err
+}
+
+func (f FileDownloadStore) Save(_ context.Context, progress map[string]uint64) (err error) {
+	return writeJSONFile(f.Path, progress)
+}
@@ -0,0 +1,127 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+func TestDownloader(t *testing.T) {
+	// one report per second from 100 to 199 for each feed
+	serverReports := map[string][]*ReportResponse{}
+	for _, id := range []feed.ID{feed1, feed2} {
+		for ts := uint64(100); ts < 200; ts++ {
+			serverReports[id.String()] = append(serverReports[id.String()], &ReportResponse{FeedID: id, ObservationsTimestamp: ts})
+		}
+	}
+
+	var requests atomic.Int32
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		startTS, err := strconv.ParseUint(r.URL.Query().Get("startTimestamp"), 10, 64)
+		if err != nil {
+			t.Errorf("error parsing startTimestamp: %s", err)
+		}
+
+		page := &ReportPage{Reports: []*ReportResponse{}}
+		for _, rep := range serverReports[r.URL.Query().Get("feedID")] {
+			if rep.ObservationsTimestamp >= startTS && len(page.Reports) < 10 {
+				page.Reports = append(page.Reports, rep)
+			}
+		}
+
+		if err = json.NewEncoder(w).Encode(page); err != nil {
+			t.Errorf("failed to encode response: %s", err)
+		}
+	})
+	defer ms.Close()
+
+	client, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	tasks := []DownloadTask{
+		{FeedID: feed1, Start: 110, End: 150},
+		{FeedID: feed2, Start: 180, End: 300},
+	}
+	store := FileDownloadStore{Path: filepath.Join(t.TempDir(), "progress.json")}
+	got := map[string][]*ReportResponse{}
+	sink := func(_ context.Context, task DownloadTask, reports []*ReportResponse) error {
+		got[task.FeedID.String()] = append(got[task.FeedID.String()], reports...)
+		return nil
+	}
+
+	// interrupt the first run after 2 pages
+	failing := errors.New("sink error")
+	d, err := NewDownloader(client, DownloadConfig{
+		Tasks: tasks,
+		Store: store,
+		Sink: func(ctx context.Context, task DownloadTask, reports []*ReportResponse) error {
+			if len(got[task.FeedID.String()]) >= 20 {
+				return failing
+			}
+			return sink(ctx, task, reports)
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewDownloader() error = %v", err)
+	}
+	res, err := d.Run(context.Background())
+	if !errors.Is(err, failing) {
+		t.Fatalf("Run() error = %v, want %v", err, failing)
+	}
+	if len(res.Pending) != 2 {
+		t.Errorf("Run() pending = %v, want %v", res.Pending, tasks)
+	}
+
+	// resume
+	d, err = NewDownloader(client, DownloadConfig{Tasks: tasks, Store: store, Concurrency: 2, Rate: 1000, Sink: sink})
+	if err != nil {
+		t.Fatalf("NewDownloader() error = %v", err)
+	}
+	res, err = d.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !reflect.DeepEqual(res.Completed, tasks) || res.Reports != 40 {
+		t.Errorf("Run() = %+v, want all tasks completed and 40 reports", res)
+	}
+
+	expected := map[string][]*ReportResponse{
+		feed1.String(): serverReports[feed1.String()][10:50],
+		feed2.String(): serverReports[feed2.String()][80:],
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("downloaded reports = %v, want %v", got, expected)
+	}
+
+	// completed tasks are not downloaded again
+	requests.Store(0)
+	if res, err = d.Run(context.Background()); err != nil || len(res.Completed) != 2 || requests.Load() != 0 {
+		t.Errorf("Run() = %+v, %v with %d requests, want completed tasks and no requests", res, err, requests.Load())
+	}
+
+	// budget
+	d, err = NewDownloader(client, DownloadConfig{
+		Tasks:  []DownloadTask{{FeedID: feed1, Start: 0, End: 200}},
+		Rate:   10,
+		Budget: 150 * time.Millisecond,
+		Sink:   func(context.Context, DownloadTask, []*ReportResponse) error { return nil },
+	})
+	if err != nil {
+		t.Fatalf("NewDownloader() error = %v", err)
+	}
+	if res, err = d.Run(context.Background()); !errors.Is(err, ErrDownloadBudgetExceeded) || len(res.Pending) != 1 {
+		t.Errorf("Run() = %+v, %v, want %v and a pending task", res, err, ErrDownloadBudgetExceeded)
+	}
+}
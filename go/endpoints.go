@@ -1,6 +1,10 @@
 package streams
 
-import "net/textproto"
+import (
+	"net/textproto"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/auth"
+)
 
 const (
 	apiV1WS            = "/api/v1/ws"
@@ -14,16 +18,37 @@ const (
 	// to pass in a custom http headers in a http.Header to be used by the client.
 	// Custom header values will overwrite client headers if they have the same key.
 	CustomHeadersCtxKey CtxKey = "CustomHeaders"
+
+	// RequestIDCtxKey is used as key in the context.Context object to pass in a string request ID
+	// sent in the X-Request-Id header and included in logs and errors.
+	// A random request ID is generated for each request if not provided.
+	RequestIDCtxKey CtxKey = "RequestID"
+
+	// LabelsCtxKey is used as key in the context.Context object to pass in the Labels
+	// of a Stream consumer, included in the Stream logs and callbacks.
+	LabelsCtxKey CtxKey = "Labels"
+
+	// PriorityCtxKey is used as key in the context.Context object to pass in the Priority
+	// of a rest request, PriorityNormal if not provided. See Config.RestConcurrency.
+	PriorityCtxKey CtxKey = "Priority"
+
+	// InspectHttpResponseCtxKey is used as key in the context.Context object to pass in a func(*http.Response)
+	// inspecting the responses of the rest requests made with the context instead of Config.InspectHttpResponse.
+	// Set when creating a Stream, it inspects the websocket handshake responses of the Stream connections.
+	InspectHttpResponseCtxKey CtxKey = "InspectHttpResponse"
 )
 
 var (
 	cllAvailOriginsHeader = textproto.CanonicalMIMEHeaderKey("X-Cll-Available-Origins")
 	cllOriginHeader       = textproto.CanonicalMIMEHeaderKey("X-Cll-Origin")
 	cllIntHeader          = textproto.CanonicalMIMEHeaderKey("X-Cll-Eng-Int")
-	authzHeader           = textproto.CanonicalMIMEHeaderKey("Authorization")
-	authzTSHeader         = textproto.CanonicalMIMEHeaderKey("X-Authorization-Timestamp")
-	authzSigHeader        = textproto.CanonicalMIMEHeaderKey("X-Authorization-Signature-SHA256")
+	authzHeader           = textproto.CanonicalMIMEHeaderKey(auth.HeaderAPIKey)
+	authzTSHeader         = textproto.CanonicalMIMEHeaderKey(auth.HeaderTimestamp)
+	authzSigHeader        = textproto.CanonicalMIMEHeaderKey(auth.HeaderSignature)
 	hostHeader            = textproto.CanonicalMIMEHeaderKey("Host")
+	requestIDHeader       = textproto.CanonicalMIMEHeaderKey("X-Request-Id")
+	acceptEncodingHeader  = textproto.CanonicalMIMEHeaderKey("Accept-Encoding")
+	userAgentHeader       = textproto.CanonicalMIMEHeaderKey("User-Agent")
 )
 
 // CtxKey type for context values
@@ -0,0 +1,132 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+const (
+	defaultEntitlementInterval = 5 * time.Minute
+	entitlementRequestTimeout  = 30 * time.Second
+	revocationChecks           = 2 // consecutive checks a feed must be missing from to be revoked
+)
+
+// ErrFeedsRevoked is returned when a Stream was closed because the access to all its feeds was revoked.
+var ErrFeedsRevoked = fmt.Errorf("client: stream closed, access to all feeds revoked")
+
+// EntitlementConfig enables checking periodically with GetFeeds that the Stream feeds are still
+// accessible to the account. Feeds missing from the listed feeds in 2 consecutive checks are dropped
+// from the Stream, an EventFeedRevoked event is published for each of them and the connections are
+// reconnected one at a time with the remaining feeds, as servers reject connections subscribing to
+// feeds not entitled. Empty feed lists are ignored. The Stream is closed with ErrFeedsRevoked once
+// no feed remains.
+type EntitlementConfig struct {
+	Interval time.Duration // Interval between checks, defaults to 5 minutes
+}
+
+// feeds returns the feeds currently subscribed, the returned slice must not be modified.
+func (s *stream) feeds() []feed.ID {
+	s.feedsMu.Lock()
+	defer s.feedsMu.Unlock()
+	return s.feedIDs
+}
+
+func (s *stream) monitorEntitlements(getFeeds func(ctx context.Context) ([]*feed.Feed, error)) {
+	interval := s.config.Entitlement.Interval
+	if interval <= 0 {
+		interval = defaultEntitlementInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	missing := map[feed.ID]int{}
+	for {
+		select {
+		case <-s.streamCtx.Done():
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(s.streamCtx, entitlementRequestTimeout)
+			entitled, err := getFeeds(ctx)
+			cancel()
+			if err != nil {
+				if s.streamCtx.Err() == nil {
+					s.config.logInfo("client: stream error checking feed entitlements: %s", err)
+				}
+				continue
+			}
+			// an empty list is more likely a server error than every feed being revoked
+			if len(entitled) == 0 {
+				s.config.logInfo("client: stream ignoring empty feed entitlements")
+				continue
+			}
+			if !s.pruneFeeds(entitled, missing) {
+				return
+			}
+		}
+	}
+}
+
+// pruneFeeds drops the feeds not entitled in revocationChecks consecutive checks, counted in missing,
+// reconnecting the connections if any feed was dropped. Returns false if the stream was closed
+// because no feed remains.
+func (s *stream) pruneFeeds(entitled []*feed.Feed, missing map[feed.ID]int) (ok bool) {
+	ids := make(map[feed.ID]bool, len(entitled))
+	for _, f := range entitled {
+		ids[f.FeedID] = true
+	}
+
+	s.feedsMu.Lock()
+	var revoked []feed.ID
+	remaining := slices.DeleteFunc(slices.Clone(s.feedIDs), func(id feed.ID) bool {
+		if ids[id] {
+			delete(missing, id)
+			return false
+		}
+		missing[id]++
+		if missing[id] < revocationChecks {
+			return false
+		}
+		delete(missing, id)
+		revoked = append(revoked, id)
+		return true
+	})
+	if len(revoked) > 0 {
+		s.feedIDs = remaining
+	}
+	s.feedsMu.Unlock()
+
+	if len(revoked) == 0 {
+		return true
+	}
+
+	s.config.logInfo("client: stream access to feeds %s revoked", strings.Join(feedIdsToStringList(revoked), ","))
+	for _, id := range revoked {
+		s.event(Event{
+			Type:    EventFeedRevoked,
+			Message: "access to feed " + id.String() + " revoked",
+			Time:    time.Now(),
+			Labels:  s.labels,
+			FeedID:  id,
+		})
+		if s.slo != nil {
+			s.slo.remove(id)
+		}
+		if s.latency != nil {
+			s.latency.remove(id.String())
+		}
+	}
+
+	if len(remaining) == 0 {
+		s.fail(ErrFeedsRevoked)
+		return false
+	}
+
+	// reconnect subscribing to the remaining feeds
+	s.redial(s.streamCtx)
+	return true
+}
@@ -0,0 +1,101 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestClient_StreamEntitlement(t *testing.T) {
+	var entitled atomic.Value
+	entitled.Store(`{"feeds":[{"feedID":"` + feed1.String() + `"},{"feedID":"` + feed2.String() + `"}]}`)
+	var partial atomic.Bool // lists only feed1 in the next check
+	subscriptions := make(chan string, 16)
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead:
+			return
+		case r.URL.Path == apiV1Feeds && partial.CompareAndSwap(true, false):
+			_, _ = w.Write([]byte(`{"feeds":[{"feedID":"` + feed1.String() + `"}]}`))
+			return
+		case r.URL.Path == apiV1Feeds:
+			_, _ = w.Write([]byte(entitled.Load().(string)))
+			return
+		}
+
+		subscriptions <- r.URL.Query().Get("feedIDs")
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).config.Entitlement = &EntitlementConfig{Interval: 20 * time.Millisecond}
+
+	sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1, feed2}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	if got, want := <-subscriptions, feed1.String()+","+feed2.String(); got != want {
+		t.Fatalf("subscribed feeds = %s, want %s", got, want)
+	}
+
+	// empty lists and feeds missing from a single check are ignored
+	entitled.Store(`{"feeds":[]}`)
+	time.Sleep(100 * time.Millisecond)
+	entitled.Store(`{"feeds":[{"feedID":"` + feed1.String() + `"},{"feedID":"` + feed2.String() + `"}]}`)
+	partial.Store(true)
+	for partial.Load() {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case e := <-sub.Events():
+		t.Fatalf("unexpected event %s %s", e.Type, e.FeedID.String())
+	case got := <-subscriptions:
+		t.Fatalf("unexpected resubscription to %s", got)
+	default:
+	}
+
+	entitled.Store(`{"feeds":[{"feedID":"` + feed1.String() + `"}]}`)
+	select {
+	case e := <-sub.Events():
+		if e.Type != EventFeedRevoked || e.FeedID != feed2 {
+			t.Errorf("event = %s %s, want %s %s", e.Type, e.FeedID.String(), EventFeedRevoked, feed2.String())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("feed revoked event not published")
+	}
+	if got, want := <-subscriptions, feed1.String(); got != want {
+		t.Errorf("resubscribed feeds = %s, want %s", got, want)
+	}
+
+	entitled.Store(`{"feeds":[{"feedID":"` + feed2.String() + `"}]}`)
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("stream not closed")
+	}
+	if !errors.Is(sub.Err(), ErrFeedsRevoked) {
+		t.Errorf("Err() = %v, want %v", sub.Err(), ErrFeedsRevoked)
+	}
+}
@@ -0,0 +1,117 @@
+package streams
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+)
+
+const defaultEstimateSignatures = 4
+
+// FeedRate is the expected report rate of a feed.
+type FeedRate struct {
+	FeedID           feed.ID
+	ReportsPerSecond float64
+}
+
+// SubscriptionPlan describes a planned Stream subscription for EstimateSubscription.
+type SubscriptionPlan struct {
+	// Feeds are the subscribed feeds with their expected report rates.
+	Feeds []FeedRate
+	// Connections is the number of Stream connections, each receiving every report, defaults to 1.
+	// HA Streams open a connection per origin, see Config.WsHA.
+	Connections int
+	// Signatures is the number of signatures of each report, defaults to 4.
+	Signatures int
+	// RestPollInterval is the interval the latest report of each feed is also fetched at with
+	// GetLatestReport, no polling if 0.
+	RestPollInterval time.Duration
+	// EntitlementInterval is the interval the Stream feeds entitlement is checked at, see EntitlementConfig.
+	// No checks if 0.
+	EntitlementInterval time.Duration
+}
+
+// SubscriptionEstimate is the expected load of a Stream subscription.
+type SubscriptionEstimate struct {
+	ReportsPerSecond      float64 // Reports delivered to the application, after deduplication
+	MessagesPerSecond     float64 // Websocket messages received across all the connections
+	BytesPerSecond        float64 // Websocket bytes received across all the connections, before compression
+	RestRequestsPerMinute float64 // Rest requests sent for polling and entitlement checks
+}
+
+// BytesPerDay returns the websocket bytes received per day.
+func (e SubscriptionEstimate) BytesPerDay() float64 {
+	return e.BytesPerSecond * (24 * time.Hour).Seconds()
+}
+
+func (e SubscriptionEstimate) String() string {
+	return fmt.Sprintf("reports: %.1f/s, messages: %.1f/s, bandwidth: %.0f B/s (%.1f GB/day), rest requests: %.1f/min",
+		e.ReportsPerSecond, e.MessagesPerSecond, e.BytesPerSecond, e.BytesPerDay()/1e9, e.RestRequestsPerMinute)
+}
+
+// EstimateSubscription estimates the message rate, bandwidth and rest requests rate of the planned
+// Stream subscription for capacity planning. The websocket message sizes are derived from the report
+// schema of each feed version, the bandwidth is an upper bound when websocket compression is enabled.
+func EstimateSubscription(plan SubscriptionPlan) (e SubscriptionEstimate, err error) {
+	connections, signatures := plan.Connections, plan.Signatures
+	if connections <= 0 {
+		connections = 1
+	}
+	if signatures <= 0 {
+		signatures = defaultEstimateSignatures
+	}
+
+	sizes := map[feed.FeedVersion]int{}
+	for _, f := range plan.Feeds {
+		if f.ReportsPerSecond < 0 {
+			return e, fmt.Errorf("client: feed %s: negative report rate %g", f.FeedID.String(), f.ReportsPerSecond)
+		}
+
+		v := f.FeedID.Version()
+		size, ok := sizes[v]
+		if !ok {
+			if size, err = messageSize(v, signatures); err != nil {
+				return e, fmt.Errorf("client: feed %s: %w", f.FeedID.String(), err)
+			}
+			sizes[v] = size
+		}
+
+		e.ReportsPerSecond += f.ReportsPerSecond
+		e.BytesPerSecond += f.ReportsPerSecond * float64(size)
+	}
+	e.MessagesPerSecond = e.ReportsPerSecond * float64(connections)
+	e.BytesPerSecond *= float64(connections)
+
+	if plan.RestPollInterval > 0 {
+		e.RestRequestsPerMinute += float64(len(plan.Feeds)) * time.Minute.Seconds() / plan.RestPollInterval.Seconds()
+	}
+	if plan.EntitlementInterval > 0 {
+		e.RestRequestsPerMinute += time.Minute.Seconds() / plan.EntitlementInterval.Seconds()
+	}
+	return e, nil
+}
+
+// messageSize returns the size of the websocket message of a report of the feed version.
+func messageSize(v feed.FeedVersion, signatures int) (int, error) {
+	size, err := report.FullReportSize(v, signatures)
+	if err != nil {
+		return 0, err
+	}
+
+	var id feed.ID
+	b, err := json.Marshal(struct {
+		Report *ReportResponse `json:"report"`
+	}{&ReportResponse{
+		FeedID:                id,
+		FullReport:            make([]byte, size),
+		ValidFromTimestamp:    uint64(time.Now().Unix()),
+		ObservationsTimestamp: uint64(time.Now().Unix()),
+	}})
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
@@ -0,0 +1,64 @@
+package streams
+
+import (
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+func TestEstimateSubscription(t *testing.T) {
+	feedV3 := mustFeedIDfromString("0x00030ffa644e6c585a5bec0e25ca476b6666666666e22b6240957720dcba0e14")
+	sizeV2, err := messageSize(feed.FeedVersion2, 4)
+	if err != nil {
+		t.Fatalf("messageSize() error = %s", err)
+	}
+	sizeV3, err := messageSize(feed.FeedVersion3, 4)
+	if err != nil {
+		t.Fatalf("messageSize() error = %s", err)
+	}
+
+	// a v3 report with 4 signatures is 864 bytes, hex encoded in the message
+	if want := 2 * 864; sizeV3 < want || sizeV3 > want+250 {
+		t.Errorf("messageSize(v3) = %d, want about %d", sizeV3, want)
+	}
+
+	e, err := EstimateSubscription(SubscriptionPlan{
+		Feeds:               []FeedRate{{FeedID: feed1, ReportsPerSecond: 1}, {FeedID: feedV3, ReportsPerSecond: 2}},
+		Connections:         2,
+		RestPollInterval:    10 * time.Second,
+		EntitlementInterval: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("EstimateSubscription() error = %s", err)
+	}
+	want := SubscriptionEstimate{
+		ReportsPerSecond:      3,
+		MessagesPerSecond:     6,
+		BytesPerSecond:        float64(2 * (sizeV2 + 2*sizeV3)),
+		RestRequestsPerMinute: 2*6 + 1,
+	}
+	if e != want {
+		t.Errorf("EstimateSubscription() = %+v, want %+v", e, want)
+	}
+	if got := e.BytesPerDay(); math.Abs(got-want.BytesPerSecond*86400) > 1 {
+		t.Errorf("BytesPerDay() = %g, want %g", got, want.BytesPerSecond*86400)
+	}
+	if !strings.Contains(e.String(), "messages: 6.0/s") {
+		t.Errorf("String() = %s", e)
+	}
+
+	var unsupported feed.ID
+	_, _ = hex.Decode(unsupported[:2], []byte("ffff"))
+	for _, plan := range []SubscriptionPlan{
+		{Feeds: []FeedRate{{FeedID: feed1, ReportsPerSecond: -1}}},
+		{Feeds: []FeedRate{{FeedID: unsupported, ReportsPerSecond: 1}}},
+	} {
+		if _, err = EstimateSubscription(plan); err == nil {
+			t.Errorf("EstimateSubscription(%+v) error = nil, want error", plan)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package streams
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/common"
+)
+
+const eventsBufferSize = 64
+
+// EventType is the type of a Stream control Event.
+type EventType string
+
+const (
+	EventHeartbeat    EventType = "heartbeat"     // Server heartbeat
+	EventNotice       EventType = "notice"        // Server notice
+	EventMaintenance  EventType = "maintenance"   // Impending server maintenance
+	EventMarketStatus EventType = "market_status" // Feed market status transition, see MarketStatusConfig
+	EventFeedRevoked  EventType = "feed_revoked"  // Access to a feed revoked, see EntitlementConfig
+	EventUnknown      EventType = "unknown"       // Unrecognized server message
+)
+
+// Event is a control message received on a Stream connection.
+type Event struct {
+	Type    EventType // Event type
+	Message string    // Event message, if any
+	Time    time.Time // Event time, for maintenance events the maintenance start, zero if not provided
+	Host    string    // Connection host
+	Origin  string    // Connection origin, empty when not in HA mode
+	Raw     []byte    // Raw message
+	Labels  Labels    // Stream labels
+
+	FeedID       feed.ID             // Feed of a market status or feed revoked event
+	MarketStatus common.MarketStatus // New market status of a market status event
+}
+
+// controlMessage is a non report message sent by the server.
+type controlMessage struct {
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+func parseEvent(b []byte, host, origin string, labels Labels) (e Event) {
+	e = Event{Type: EventUnknown, Host: host, Origin: origin, Raw: b, Labels: labels}
+
+	var m controlMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return e
+	}
+
+	switch t := EventType(m.Type); t {
+	case EventHeartbeat, EventNotice, EventMaintenance:
+		e.Type = t
+	}
+	e.Message = m.Message
+	if m.Timestamp > 0 {
+		e.Time = time.Unix(m.Timestamp, 0)
+	}
+	return e
+}
+
+func (s *stream) Events() <-chan Event {
+	return s.events
+}
+
+// event publishes the event without blocking, events are dropped if the events channel is full.
+func (s *stream) event(e Event) {
+	s.config.logDebug("client: stream websocket %s: %s event: %s", e.Origin, e.Type, e.Message)
+	s.recent.add(e)
+	select {
+	case s.events <- e:
+	default:
+		s.config.logDebug("client: stream events channel full, %s event dropped", e.Type)
+	}
+}
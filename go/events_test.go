@@ -0,0 +1,94 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestClient_StreamEvents(t *testing.T) {
+	expectedReport := &ReportResponse{FeedID: feed1, ObservationsTimestamp: 12344}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(
+			w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover},
+		)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		b, err := json.Marshal(&message{expectedReport})
+		if err != nil {
+			t.Errorf("failed to serialize message: %s", err)
+		}
+
+		for _, m := range [][]byte{
+			[]byte(`{"type":"heartbeat"}`),
+			[]byte(`{"type":"maintenance","message":"restarting","timestamp":1700000000}`),
+			{0x01, 0x02},
+			b,
+		} {
+			if err = conn.Write(context.Background(), websocket.MessageBinary, m); err != nil {
+				return
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	report, err := sub.Read(context.Background())
+	if err != nil {
+		t.Fatalf("error reading report %s", err)
+	}
+	if !reflect.DeepEqual(report, expectedReport) {
+		t.Errorf("Read() = %v, want %v", report, expectedReport)
+	}
+
+	var events []Event
+	for x := 0; x < 3; x++ {
+		e := <-sub.Events()
+		if e.Host == "" || e.Raw == nil {
+			t.Errorf("Events() event = %v, want host and raw message", e)
+		}
+		e.Host, e.Raw = "", nil
+		events = append(events, e)
+	}
+
+	expectedEvents := []Event{
+		{Type: EventHeartbeat},
+		{Type: EventMaintenance, Message: "restarting", Time: time.Unix(1700000000, 0)},
+		{Type: EventUnknown},
+	}
+	if !reflect.DeepEqual(events, expectedEvents) {
+		t.Errorf("Events() = %v, want %v", events, expectedEvents)
+	}
+
+	if stats := sub.Stats(); stats.PartialReconnects+stats.FullReconnects != 0 {
+		t.Errorf("Stats() = %s, want no reconnects", stats)
+	}
+}
@@ -0,0 +1,134 @@
+package feed
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+)
+
+// AssetClass is the asset class of a feed.
+type AssetClass string
+
+const (
+	AssetClassUnknown     AssetClass = "unknown"      // Unclassified feed
+	AssetClassCrypto      AssetClass = "crypto"       // Crypto asset prices
+	AssetClassRWA         AssetClass = "rwa"          // Real world assets with market hours
+	AssetClassEquity      AssetClass = "equity"       // Equities, a real world asset class
+	AssetClassFX          AssetClass = "fx"           // Foreign exchange rates, a real world asset class
+	AssetClassDEXState    AssetClass = "dex_state"    // Decentralized exchange state
+	AssetClassFundingRate AssetClass = "funding_rate" // Perpetual futures funding rates
+)
+
+// IsRWA reports whether the asset class is a real world asset class, traded during market hours.
+func (c AssetClass) IsRWA() bool {
+	return c == AssetClassRWA || c == AssetClassEquity || c == AssetClassFX
+}
+
+// DefaultTaxonomy is the Taxonomy consulted by ClassOf, empty until populated with the asset classes
+// of the feeds, for example with SetFeeds from the feeds returned by GetFeeds or from registry metadata.
+var DefaultTaxonomy = NewTaxonomy(nil)
+
+// ClassOf returns the asset class of the feed in DefaultTaxonomy. Feeds not in DefaultTaxonomy fall back
+// to the asset class implied by the feed schema version: v1, v2 and v3 feeds are assumed to be crypto feeds
+// and v4 feeds real world assets feeds. The fallback is a guess, the schema version does not identify
+// the asset class: a v3 feed may be a DEX state feed and a v4 feed an equity or FX feed.
+func ClassOf(id ID) AssetClass {
+	if c, ok := DefaultTaxonomy.lookup(id); ok {
+		return c
+	}
+	return versionClass(id)
+}
+
+// versionClass returns the asset class guessed from the feed schema version.
+func versionClass(id ID) AssetClass {
+	switch id.Version() {
+	case FeedVersion1, FeedVersion2, FeedVersion3:
+		return AssetClassCrypto
+	case FeedVersion4:
+		return AssetClassRWA
+	default:
+		return AssetClassUnknown
+	}
+}
+
+// Taxonomy classifies feeds by asset class from their known asset classes, typically from GetFeeds
+// or registry metadata, for example distinguishing equity and FX feeds, falling back to the schema
+// version classification guessed by ClassOf for the other feeds.
+// A Taxonomy is safe for concurrent usage.
+type Taxonomy struct {
+	mu      sync.RWMutex
+	classes map[ID]AssetClass
+}
+
+// NewTaxonomy creates a Taxonomy with the given feeds asset classes.
+func NewTaxonomy(classes map[ID]AssetClass) (t *Taxonomy) {
+	t = &Taxonomy{classes: make(map[ID]AssetClass, len(classes))}
+	for id, c := range classes {
+		t.classes[id] = c
+	}
+	return t
+}
+
+// Set sets the asset class of the feed.
+func (t *Taxonomy) Set(id ID, c AssetClass) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.classes[id] = c
+}
+
+// SetFeeds sets the asset class of the feeds from their field in Feed.RawExtra, for example
+// the asset class returned by GetFeeds when the client captures unknown fields.
+// Feeds without the field, or with a field that is not a string, are left unset.
+// Returns the number of feeds set.
+func (t *Taxonomy) SetFeeds(feeds []*Feed, field string) (n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, f := range feeds {
+		var c string
+		if raw, ok := f.RawExtra[field]; !ok || json.Unmarshal(raw, &c) != nil || c == "" {
+			continue
+		}
+		t.classes[f.FeedID] = AssetClass(c)
+		n++
+	}
+	return n
+}
+
+// Class returns the asset class of the feed, defaulting to the asset class guessed from its schema version.
+func (t *Taxonomy) Class(id ID) AssetClass {
+	if c, ok := t.lookup(id); ok {
+		return c
+	}
+	return versionClass(id)
+}
+
+// lookup returns the asset class set for the feed.
+func (t *Taxonomy) lookup(id ID) (c AssetClass, ok bool) {
+	if t == nil {
+		return "", false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	c, ok = t.classes[id]
+	return c, ok
+}
+
+// Filter returns the feeds of the given asset classes.
+func (t *Taxonomy) Filter(feeds []*Feed, classes ...AssetClass) (filtered []*Feed) {
+	for _, f := range feeds {
+		if slices.Contains(classes, t.Class(f.FeedID)) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// Group returns the feeds grouped by asset class.
+func (t *Taxonomy) Group(feeds []*Feed) (groups map[AssetClass][]*Feed) {
+	groups = map[AssetClass][]*Feed{}
+	for _, f := range feeds {
+		c := t.Class(f.FeedID)
+		groups[c] = append(groups[c], f)
+	}
+	return groups
+}
@@ -0,0 +1,79 @@
+package feed
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestClassOf(t *testing.T) {
+	v5FeedID := v4FeedID
+	v5FeedID[1] = 5
+
+	tests := []struct {
+		id   ID
+		want AssetClass
+	}{
+		{id: v1FeedID, want: AssetClassCrypto},
+		{id: v2FeedID, want: AssetClassCrypto},
+		{id: v3FeedID, want: AssetClassCrypto},
+		{id: v4FeedID, want: AssetClassRWA},
+		{id: v5FeedID, want: AssetClassUnknown},
+	}
+	for _, tt := range tests {
+		if got := ClassOf(tt.id); got != tt.want {
+			t.Errorf("ClassOf(%s) = %s, want %s", tt.id.String(), got, tt.want)
+		}
+	}
+
+	DefaultTaxonomy.Set(v4FeedID, AssetClassFX)
+	defer func() { DefaultTaxonomy = NewTaxonomy(nil) }()
+	if got := ClassOf(v4FeedID); got != AssetClassFX {
+		t.Errorf("ClassOf(%s) = %s, want %s from DefaultTaxonomy", v4FeedID.String(), got, AssetClassFX)
+	}
+}
+
+func TestTaxonomy(t *testing.T) {
+	equityFeedID := v4FeedID
+	equityFeedID[31] = 0
+
+	tx := NewTaxonomy(map[ID]AssetClass{equityFeedID: AssetClassEquity})
+	tx.Set(v2FeedID, AssetClassDEXState)
+
+	feeds := []*Feed{{FeedID: v2FeedID}, {FeedID: v3FeedID}, {FeedID: v4FeedID}, {FeedID: equityFeedID}}
+
+	if got := tx.Class(equityFeedID); got != AssetClassEquity || !got.IsRWA() {
+		t.Errorf("Class() = %s, want %s", got, AssetClassEquity)
+	}
+
+	got := tx.Filter(feeds, AssetClassRWA, AssetClassEquity)
+	if want := feeds[2:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter() = %v, want %v", got, want)
+	}
+
+	groups := tx.Group(feeds)
+	want := map[AssetClass][]*Feed{
+		AssetClassDEXState: feeds[:1],
+		AssetClassCrypto:   feeds[1:2],
+		AssetClassRWA:      feeds[2:3],
+		AssetClassEquity:   feeds[3:],
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("Group() = %v, want %v", groups, want)
+	}
+
+	feeds = []*Feed{
+		{FeedID: v1FeedID, RawExtra: map[string]json.RawMessage{"assetClass": []byte(`"funding_rate"`)}},
+		{FeedID: v3FeedID, RawExtra: map[string]json.RawMessage{"assetClass": []byte(`1`)}},
+		{FeedID: v4FeedID},
+	}
+	if n := tx.SetFeeds(feeds, "assetClass"); n != 1 {
+		t.Errorf("SetFeeds() = %d, want 1", n)
+	}
+	if got := tx.Class(v1FeedID); got != AssetClassFundingRate {
+		t.Errorf("Class() = %s, want %s", got, AssetClassFundingRate)
+	}
+	if got := tx.Class(v3FeedID); got != AssetClassCrypto {
+		t.Errorf("Class() = %s, want %s", got, AssetClassCrypto)
+	}
+}
@@ -3,21 +3,13 @@ package feed
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 )
 
 // FeedVersion represents the feed report schema version
 type FeedVersion uint16
 
-const (
-	_ FeedVersion = iota
-	FeedVersion1
-	FeedVersion2
-	FeedVersion3
-	FeedVersion4
-	_
-)
-
 // ID type
 type ID [32]byte
 
@@ -65,6 +57,9 @@ func (f *ID) MarshalJSON() (b []byte, err error) {
 // Feed identifies the report stream ID.
 type Feed struct {
 	FeedID ID `json:"feedID"`
+
+	// RawExtra holds the server fields not modeled by Feed when the client captures unknown fields.
+	RawExtra map[string]json.RawMessage `json:"-"`
 }
 
 func (f *ID) Version() FeedVersion {
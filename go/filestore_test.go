@@ -0,0 +1,38 @@
+package streams
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	var got map[string]uint64
+	if ok, err := readJSONFile(path, &got); ok || err != nil {
+		t.Fatalf("readJSONFile() of missing file = %v, %v, want false, nil", ok, err)
+	}
+
+	for _, want := range []map[string]uint64{{"a": 1}, {"a": 2, "b": 3}} {
+		if err := writeJSONFile(path, want); err != nil {
+			t.Fatalf("writeJSONFile() error = %s", err)
+		}
+		got = nil
+		if ok, err := readJSONFile(path, &got); !ok || err != nil {
+			t.Fatalf("readJSONFile() = %v, %v, want true, nil", ok, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("readJSONFile() = %v, want %v", got, want)
+		}
+	}
+
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left after writeJSONFile(), stat error = %v", err)
+	}
+
+	if err := writeJSONFile(filepath.Join(path, "invalid.json"), 1); err == nil {
+		t.Errorf("writeJSONFile() under a file error = nil, want error")
+	}
+}
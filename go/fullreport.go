@@ -0,0 +1,119 @@
+package streams
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"sync"
+)
+
+// maxPooledReadBuffer is the capacity above which connection read buffers are not reused.
+const maxPooledReadBuffer = 1 << 20
+
+// readBufferPool pools the connection read buffers, messages are decoded before their buffer is reused.
+var readBufferPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// hexReport decodes a 0x prefixed hex encoded full report directly from the JSON string,
+// without allocating an intermediate string. Invalid values are ignored.
+type hexReport []byte
+
+func (h *hexReport) UnmarshalJSON(b []byte) error {
+	s, ok := hexString(b)
+	if !ok {
+		return nil
+	}
+	out := make([]byte, hex.DecodedLen(len(s)))
+	if _, err := hex.Decode(out, s); err != nil {
+		return nil
+	}
+	*h = out
+	return nil
+}
+
+// hexString returns the hex digits of a 0x prefixed JSON string.
+func hexString(b []byte) (s []byte, ok bool) {
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return nil, false
+	}
+	s = b[1 : len(b)-1]
+	if len(s) < 3 {
+		return nil, false
+	}
+	return s[2:], true
+}
+
+// lazyReportResponse decodes a ReportResponse keeping its full report hex encoded.
+type lazyReportResponse ReportResponse
+
+func (r *lazyReportResponse) UnmarshalJSON(b []byte) (err error) {
+	type Alias ReportResponse
+	aux := &struct {
+		FullReport json.RawMessage `json:"fullReport"`
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	if err := json.Unmarshal(b, aux); err != nil {
+		return err
+	}
+
+	if s, ok := hexString(aux.FullReport); ok {
+		r.fullReportHex = s
+	}
+	return nil
+}
+
+// lazyMessage is a message decoded with a hex encoded full report.
+type lazyMessage struct {
+	Report *lazyReportResponse `json:"report"`
+}
+
+// FullReportBytes returns the full report. When Config.LazyFullReport is set Stream reports
+// keep their full report hex encoded, FullReport is nil and FullReportBytes decodes it on each call
+// without modifying the report, so it is safe for concurrent use.
+// Reports with an invalid full report encoding have a nil full report.
+func (r *ReportResponse) FullReportBytes() []byte {
+	if r.FullReport == nil && r.fullReportHex != nil {
+		out, err := hex.AppendDecode(nil, r.fullReportHex)
+		if err != nil {
+			return nil
+		}
+		return out
+	}
+	return r.FullReport
+}
+
+// copyInto copies the report into dst, reusing the dst full report buffer.
+func (r *ReportResponse) copyInto(dst *ReportResponse) {
+	buf := dst.FullReport[:0]
+	if r.FullReport == nil && r.fullReportHex != nil {
+		buf = slices.Grow(buf, hex.DecodedLen(len(r.fullReportHex)))
+		n, err := hex.Decode(buf[:cap(buf)], r.fullReportHex)
+		if err != nil {
+			n = 0
+		}
+		buf = buf[:n]
+	} else {
+		buf = append(buf, r.FullReport...)
+	}
+
+	*dst = *r
+	dst.FullReport, dst.fullReportHex = buf, nil
+}
+
+// decodeMessage decodes a stream message, keeping the full report hex encoded if Config.LazyFullReport is set.
+// The message bytes are not retained.
+func (s *stream) decodeMessage(b []byte) (m *message, err error) {
+	if !s.config.LazyFullReport {
+		m = &message{}
+		return m, json.Unmarshal(b, m)
+	}
+
+	lm := &lazyMessage{}
+	if err = json.Unmarshal(b, lm); err != nil {
+		return nil, err
+	}
+	return &message{Report: (*ReportResponse)(lm.Report)}, nil
+}
@@ -0,0 +1,130 @@
+package streams
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestStream_decodeMessage(t *testing.T) {
+	want := &ReportResponse{FeedID: feed1, FullReport: []byte{0xde, 0xad, 0xbe, 0xef}, ObservationsTimestamp: 10}
+	b, err := (&message{want}).Report.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %s", err)
+	}
+	msg := append(append([]byte(`{"report":`), b...), '}')
+
+	for _, lazy := range []bool{false, true} {
+		s := &stream{config: Config{LazyFullReport: lazy}}
+		buf := bytes.Clone(msg)
+		m, err := s.decodeMessage(buf)
+		if err != nil {
+			t.Fatalf("decodeMessage() error = %s", err)
+		}
+		// the message buffer is reused once decoded
+		for x := range buf {
+			buf[x] = 0
+		}
+
+		if lazy != (m.Report.FullReport == nil) {
+			t.Errorf("lazy %v: FullReport = %x", lazy, m.Report.FullReport)
+		}
+		if got, _ := m.Report.MarshalJSON(); !bytes.Equal(got, b) {
+			t.Errorf("lazy %v: MarshalJSON() = %s, want %s", lazy, got, b)
+		}
+		// concurrent encoders of the delivered report decode its full report
+		var wg sync.WaitGroup
+		for x := 0; x < 4; x++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if got := m.Report.FullReportBytes(); !bytes.Equal(got, want.FullReport) {
+					t.Errorf("lazy %v: FullReportBytes() = %x, want %x", lazy, got, want.FullReport)
+				}
+			}()
+		}
+		wg.Wait()
+
+		got := *m.Report
+		got.FullReport, got.fullReportHex = got.FullReportBytes(), nil
+		if !reflect.DeepEqual(&got, want) {
+			t.Errorf("lazy %v: decodeMessage() = %#v, want %#v", lazy, &got, want)
+		}
+	}
+}
+
+func TestReportResponse_UnmarshalJSONFullReport(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []byte
+	}{
+		{name: "valid", json: `{"fullReport":"0x0102"}`, want: []byte{1, 2}},
+		{name: "invalid hex", json: `{"fullReport":"0xzz"}`},
+		{name: "too short", json: `{"fullReport":"0x"}`},
+		{name: "null", json: `{"fullReport":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ReportResponse{}
+			if err := r.UnmarshalJSON([]byte(tt.json)); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %s", err)
+			}
+			if !bytes.Equal(r.FullReport, tt.want) {
+				t.Errorf("FullReport = %x, want %x", r.FullReport, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkStream_decodeMessage(b *testing.B) {
+	r := &ReportResponse{FeedID: feed1, FullReport: bytes.Repeat([]byte{0xab}, 1024), ObservationsTimestamp: 10}
+	rb, _ := r.MarshalJSON()
+	msg := append(append([]byte(`{"report":`), rb...), '}')
+
+	for _, lazy := range []bool{false, true} {
+		s := &stream{config: Config{LazyFullReport: lazy}}
+		name := "eager"
+		if lazy {
+			name = "lazy"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.decodeMessage(msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestReportResponse_copyInto(t *testing.T) {
+	want := &ReportResponse{FeedID: feed1, FullReport: []byte{0xde, 0xad, 0xbe, 0xef}, ObservationsTimestamp: 10}
+	b, err := want.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %s", err)
+	}
+	msg := append(append([]byte(`{"report":`), b...), '}')
+
+	for _, lazy := range []bool{false, true} {
+		s := &stream{config: Config{LazyFullReport: lazy}}
+		dst := &ReportResponse{FullReport: make([]byte, 0, 16)}
+		buf := dst.FullReport[:1]
+
+		for range 2 {
+			m, err := s.decodeMessage(bytes.Clone(msg))
+			if err != nil {
+				t.Fatalf("decodeMessage() error = %s", err)
+			}
+			m.Report.copyInto(dst)
+			if !reflect.DeepEqual(dst, want) {
+				t.Errorf("lazy %v: copyInto() = %#v, want %#v", lazy, dst, want)
+			}
+			if &dst.FullReport[0] != &buf[0] {
+				t.Errorf("lazy %v: copyInto() did not reuse the full report buffer", lazy)
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+const defaultHandlerConcurrency = 4
+
+// ErrHandlerStream is returned by Read and ReadInto on Streams created with StreamWithHandler.
+var ErrHandlerStream = fmt.Errorf("client: reports of a handler stream are delivered to its handler, Read not supported")
+
+func (c Config) handlerConcurrency() int {
+	if c.HandlerConcurrency <= 0 {
+		return defaultHandlerConcurrency
+	}
+	return c.HandlerConcurrency
+}
+
+// handlerStream is a Stream delivering its reports to a handler.
+type handlerStream struct {
+	ExtendedStream
+	workers sync.WaitGroup
+}
+
+func (h *handlerStream) Read(context.Context) (*ReportResponse, error) {
+	return nil, ErrHandlerStream
+}
+
+func (h *handlerStream) ReadInto(context.Context, *ReportResponse) error {
+	return ErrHandlerStream
+}
+
+// Close closes the Stream and waits for the handler workers to handle the reports already read.
+func (h *handlerStream) Close() error {
+	err := h.ExtendedStream.Close()
+	h.workers.Wait()
+	return err
+}
+
+func (c *client) StreamWithHandler(ctx context.Context, ids []feed.ID,
+	handler func(*ReportResponse)) (s ExtendedStream, err error) {
+	stream, err := c.StreamWithConnStatusCallback(ctx, ids, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &handlerStream{ExtendedStream: stream}
+	workers := make([]chan *ReportResponse, c.config.handlerConcurrency())
+	for x := range workers {
+		workers[x] = make(chan *ReportResponse, 1)
+		h.workers.Add(1)
+		go func() {
+			defer h.workers.Done()
+			handle(workers[x], handler, c.config)
+		}()
+	}
+
+	go func() {
+		defer func() {
+			for _, w := range workers {
+				close(w)
+			}
+		}()
+		for {
+			// read from the wrapped stream, Read is disabled on the handler stream
+			r, err := stream.Read(context.Background())
+			if err != nil {
+				return
+			}
+			// reports of a feed are always handled by the same worker to preserve their order
+			hash := fnv.New32a()
+			_, _ = hash.Write(r.FeedID[:])
+			workers[hash.Sum32()%uint32(len(workers))] <- r
+		}
+	}()
+
+	return h, nil
+}
+
+// handle calls the handler with the reports of a worker in order.
+func handle(reports <-chan *ReportResponse, handler func(*ReportResponse), cfg Config) {
+	for r := range reports {
+		func() {
+			defer func() {
+				if err := recover(); err != nil {
+					cfg.logInfo("client: stream handler panic on report %s: %v", r.FeedID.String(), err)
+				}
+			}()
+			handler(r)
+		}()
+	}
+}
@@ -0,0 +1,149 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestClient_StreamWithHandler(t *testing.T) {
+	const n = 50
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for ts := uint64(1); ts <= n; ts++ {
+			for _, id := range []feed.ID{feed1, feed2} {
+				b, err := json.Marshal(&message{&ReportResponse{FeedID: id, ObservationsTimestamp: ts}})
+				if err != nil {
+					t.Errorf("failed to serialize message: %s", err)
+				}
+				if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+					return
+				}
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).config.HandlerConcurrency = 2
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(2 * n)
+	handled := map[feed.ID][]uint64{}
+	sub, err := streamsClient.StreamWithHandler(context.Background(), []feed.ID{feed1, feed2}, func(r *ReportResponse) {
+		defer wg.Done()
+		if r.ObservationsTimestamp == 1 {
+			panic("handler panic")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		handled[r.FeedID] = append(handled[r.FeedID], r.ObservationsTimestamp)
+	})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	if _, err = sub.Read(context.Background()); !errors.Is(err, ErrHandlerStream) {
+		t.Errorf("Read() error = %v, want %v", err, ErrHandlerStream)
+	}
+	if err = sub.ReadInto(context.Background(), &ReportResponse{}); !errors.Is(err, ErrHandlerStream) {
+		t.Errorf("ReadInto() error = %v, want %v", err, ErrHandlerStream)
+	}
+
+	wg.Wait()
+
+	var want []uint64
+	for ts := uint64(2); ts <= n; ts++ {
+		want = append(want, ts)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, id := range []feed.ID{feed1, feed2} {
+		if !reflect.DeepEqual(handled[id], want) {
+			t.Errorf("feed %s handled %v, want %v", id.String(), handled[id], want)
+		}
+	}
+	if st := sub.Stats(); st.Accepted != 2*n {
+		t.Errorf("Stats().Accepted = %d, want %d", st.Accepted, 2*n)
+	}
+}
+
+func TestClient_StreamWithHandlerClose(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for ts := uint64(1); ; ts++ {
+			b, err := json.Marshal(&message{&ReportResponse{FeedID: feed1, ObservationsTimestamp: ts}})
+			if err != nil {
+				t.Errorf("failed to serialize message: %s", err)
+			}
+			if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	var started, handled atomic.Int64
+	sub, err := streamsClient.StreamWithHandler(context.Background(), []feed.ID{feed1}, func(r *ReportResponse) {
+		started.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		handled.Add(1)
+	})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+
+	for started.Load() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	if err = sub.Close(); err != nil {
+		t.Fatalf("Close() error = %s", err)
+	}
+
+	// the reports being handled are handled before Close returns
+	if s, h := started.Load(), handled.Load(); s != h {
+		t.Errorf("after Close() %d reports handled, want %d", h, s)
+	}
+}
@@ -0,0 +1,212 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+const (
+	handoverPollInterval = 250 * time.Millisecond
+	standbyBufferSize    = 64
+)
+
+// HandoverStore persists the delivery watermarks exchanged between the process
+// handing over a Stream and the standby process taking it over.
+// The store must be reachable by both processes.
+type HandoverStore interface {
+	// Save stores the per feed watermarks of the last delivered reports.
+	Save(ctx context.Context, waterMarks map[string]uint64) error
+
+	// Take returns and removes the saved watermarks.
+	// ok is false if no watermarks have been saved yet.
+	Take(ctx context.Context) (waterMarks map[string]uint64, ok bool, err error)
+}
+
+// StandbyStream is a Stream started in standby mode.
+// A standby Stream is connected and receiving reports but does not deliver
+// them until TakeOver is called.
+type StandbyStream interface {
+	ExtendedStream
+
+	// TakeOver waits for the watermarks saved by ExtendedStream.Handover on the previous
+	// process and starts delivering reports newer than the handed over watermarks,
+	// including the reports received while in standby.
+	// TakeOver blocks until the watermarks are available or the context is done.
+	// While in standby only the last 64 reports of each feed are kept, older reports are dropped
+	// and counted in StatsV2 as StatStandbyDropped.
+	// TakeOver fails once the stream has taken over.
+	TakeOver(ctx context.Context) error
+}
+
+// standby is owned by the stream dispatcher, except released.
+type standby struct {
+	active   bool
+	released atomic.Bool // set once active, read by TakeOver
+	store    HandoverStore
+	pending  map[string][]*ReportResponse
+	dropped  map[string]uint64
+}
+
+// hold buffers the report if the stream is still in standby.
+// The oldest report of the feed is dropped when its buffer is full.
+func (sb *standby) hold(r *ReportResponse) (held, dropped bool) {
+	if sb.active {
+		return false, false
+	}
+
+	id := r.FeedID.String()
+	buf := append(sb.pending[id], r)
+	if len(buf) > standbyBufferSize {
+		buf = buf[len(buf)-standbyBufferSize:]
+		sb.dropped[id]++
+		dropped = true
+	}
+	sb.pending[id] = buf
+	return true, dropped
+}
+
+func withStandby(store HandoverStore) streamOption {
+	return func(s *stream) {
+		s.standby = &standby{
+			store:   store,
+			pending: make(map[string][]*ReportResponse),
+			dropped: make(map[string]uint64),
+		}
+	}
+}
+
+func (c *client) StreamStandby(ctx context.Context, ids []feed.ID, store HandoverStore) (s StandbyStream, err error) {
+	origins, err := c.streamOrigins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.newStream(ctx, c.http, ids, origins, nil, withStandby(store))
+}
+
+func (s *stream) TakeOver(ctx context.Context) (err error) {
+	if s.standby == nil {
+		return fmt.Errorf("client: stream is not in standby")
+	}
+	if s.standby.released.Load() {
+		return fmt.Errorf("client: stream already taken over")
+	}
+
+	var waterMarks map[string]uint64
+	ticker := time.NewTicker(handoverPollInterval)
+	defer ticker.Stop()
+	for {
+		var ok bool
+		if waterMarks, ok, err = s.standby.store.Take(ctx); err != nil {
+			return fmt.Errorf("client: stream takeover: %w", err)
+		}
+		if ok {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("client: stream takeover: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+
+	// the backlog is queued ahead of live reports by the dispatcher
+	var takenOver bool
+	if err = s.do(ctx, func() {
+		if s.standby.active {
+			return
+		}
+		s.standby.active, takenOver = true, true
+		s.standby.released.Store(true)
+
+		// live reports at or below the handed over watermarks were delivered by the previous process
+		for id, wm := range waterMarks {
+			if wm > s.waterMark[id] {
+				s.waterMark[id] = wm
+			}
+		}
+
+		var backlog []*ReportResponse
+		for id, reports := range s.standby.pending {
+			for _, r := range reports {
+				if r.ObservationsTimestamp > waterMarks[id] {
+					backlog = append(backlog, r)
+				}
+			}
+		}
+		s.standby.pending = nil
+		for id, n := range s.standby.dropped {
+			s.config.logInfo("client: stream takeover, %d reports of feed %s dropped in standby", n, id)
+		}
+		sort.SliceStable(backlog, func(i, j int) bool {
+			return backlog[i].ObservationsTimestamp < backlog[j].ObservationsTimestamp
+		})
+		s.config.logInfo("client: stream takeover, delivering %d reports received in standby", len(backlog))
+		queue := make([]*queued, 0, len(backlog)+len(s.queue))
+		for _, r := range backlog {
+			queue = append(queue, s.enqueue(r))
+		}
+		s.queue = append(queue, s.queue...)
+	}); err != nil {
+		return err
+	}
+	if !takenOver {
+		return fmt.Errorf("client: stream already taken over")
+	}
+	return nil
+}
+
+func (s *stream) Handover(ctx context.Context, store HandoverStore) (err error) {
+	s.deliveredMu.Lock()
+	s.handedOver = true
+	waterMarks := make(map[string]uint64, len(s.delivered))
+	for k, v := range s.delivered {
+		waterMarks[k] = v
+	}
+	s.deliveredMu.Unlock()
+
+	closeErr := s.Close()
+	if err = store.Save(ctx, waterMarks); err != nil {
+		return fmt.Errorf("client: stream handover: %w", err)
+	}
+	return closeErr
+}
+
+// FileHandoverStore implements a HandoverStore using a file located on
+// storage shared by both processes.
+type FileHandoverStore struct {
+	Path string
+}
+
+func (f FileHandoverStore) Save(_ context.Context, waterMarks map[string]uint64) (err error) {
+	return writeJSONFile(f.Path, waterMarks)
+}
+
+func (f FileHandoverStore) Take(_ context.Context) (waterMarks map[string]uint64, ok bool, err error) {
+	taken := f.Path + ".taken"
+	if err = os.Rename(f.Path, taken); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	defer os.Remove(taken)
+
+	b, err := os.ReadFile(taken)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err = json.Unmarshal(b, &waterMarks); err != nil {
+		return nil, false, err
+	}
+	return waterMarks, true, nil
+}
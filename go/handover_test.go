@@ -0,0 +1,210 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestClient_StreamHandover(t *testing.T) {
+	reports := []*ReportResponse{
+		{FeedID: feed1, ObservationsTimestamp: 1},
+		{FeedID: feed1, ObservationsTimestamp: 2},
+		{FeedID: feed1, ObservationsTimestamp: 3},
+		{FeedID: feed1, ObservationsTimestamp: 4},
+	}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(
+			w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover},
+		)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for x := 0; x < len(reports); x++ {
+			b, err := json.Marshal(&message{reports[x]})
+			if err != nil {
+				t.Errorf("failed to serialize message: %s", err)
+			}
+
+			if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+				return
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	store := FileHandoverStore{Path: filepath.Join(t.TempDir(), "handover.json")}
+
+	current, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer current.Close()
+
+	standby, err := streamsClient.StreamStandby(context.Background(), []feed.ID{feed1}, store)
+	if err != nil {
+		t.Fatalf("error subscribing standby %s", err)
+	}
+	defer standby.Close()
+
+	for x := 0; x < 2; x++ {
+		if _, err = current.Read(context.Background()); err != nil {
+			t.Fatalf("error reading report %s", err)
+		}
+	}
+
+	for standby.Stats().TotalReceived != uint64(len(reports)) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err = current.Handover(context.Background(), store); err != nil {
+		t.Fatalf("Handover() error = %s", err)
+	}
+
+	if _, err = current.Read(context.Background()); err == nil {
+		t.Errorf("expected error reading from a handed over stream")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err = standby.TakeOver(ctx); err != nil {
+		t.Fatalf("TakeOver() error = %s", err)
+	}
+
+	var got []*ReportResponse
+	for x := 0; x < 2; x++ {
+		r, err := standby.Read(ctx)
+		if err != nil {
+			t.Fatalf("error reading report %s", err)
+		}
+		got = append(got, r)
+	}
+
+	if !reflect.DeepEqual(got, reports[2:]) {
+		t.Errorf("Read() = %v, want %v", got, reports[2:])
+	}
+
+	if err = standby.TakeOver(ctx); err == nil {
+		t.Errorf("second TakeOver() error = nil, want error")
+	}
+}
+
+func TestStandby_Hold(t *testing.T) {
+	sb := &standby{pending: make(map[string][]*ReportResponse), dropped: make(map[string]uint64)}
+	var dropped int
+	for x := 1; x <= standbyBufferSize+2; x++ {
+		held, d := sb.hold(&ReportResponse{FeedID: feed1, ObservationsTimestamp: uint64(x)})
+		if !held {
+			t.Fatalf("hold() held = false, want true")
+		}
+		if d {
+			dropped++
+		}
+	}
+
+	buf := sb.pending[feed1.String()]
+	if dropped != 2 || sb.dropped[feed1.String()] != 2 || len(buf) != standbyBufferSize || buf[0].ObservationsTimestamp != 3 {
+		t.Errorf("hold() dropped %d, kept %d reports from %d, want the 2 oldest dropped", dropped, len(buf), buf[0].ObservationsTimestamp)
+	}
+
+	sb.active = true
+	if held, d := sb.hold(&ReportResponse{FeedID: feed1}); held || d {
+		t.Errorf("hold() once active = %v, %v, want false, false", held, d)
+	}
+}
+
+func TestClient_StreamTakeOverLiveReports(t *testing.T) {
+	reports := []*ReportResponse{
+		{FeedID: feed1, ObservationsTimestamp: 1},
+		{FeedID: feed1, ObservationsTimestamp: 2},
+		{FeedID: feed1, ObservationsTimestamp: 3},
+		{FeedID: feed1, ObservationsTimestamp: 4},
+	}
+	takenOver := make(chan struct{})
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		// no report is received in standby
+		<-takenOver
+		for x := 0; x < len(reports); x++ {
+			b, err := json.Marshal(&message{reports[x]})
+			if err != nil {
+				t.Errorf("failed to serialize message: %s", err)
+			}
+			if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+				return
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	store := FileHandoverStore{Path: filepath.Join(t.TempDir(), "handover.json")}
+	if err = store.Save(context.Background(), map[string]uint64{feed1.String(): 2}); err != nil {
+		t.Fatalf("Save() error = %s", err)
+	}
+
+	standby, err := streamsClient.StreamStandby(context.Background(), []feed.ID{feed1}, store)
+	if err != nil {
+		t.Fatalf("error subscribing standby %s", err)
+	}
+	defer standby.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err = standby.TakeOver(ctx); err != nil {
+		t.Fatalf("TakeOver() error = %s", err)
+	}
+	close(takenOver)
+
+	// the live reports delivered by the previous process are skipped
+	var got []*ReportResponse
+	for x := 0; x < 2; x++ {
+		r, err := standby.Read(ctx)
+		if err != nil {
+			t.Fatalf("error reading report %s", err)
+		}
+		got = append(got, r)
+	}
+	if !reflect.DeepEqual(got, reports[2:]) {
+		t.Errorf("Read() = %v, want %v", got, reports[2:])
+	}
+}
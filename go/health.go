@@ -0,0 +1,157 @@
+package streams
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// Handler returns an http.Handler serving the operational endpoints of the client and the given Streams
+// by name as JSON, to be mounted with http.StripPrefix under a prefix if needed:
+//
+//   - /healthz: the health of each Stream, status 200 if all the Streams are healthy and 503 otherwise.
+//     A Stream is healthy while it is open with at least one active connection.
+//   - /stats: the Stats and SLOStatus of each Stream, the client TransferStats and ClockEstimate.
+//   - /feeds: the feeds of each Stream with the ObservationsTimestamp of their last delivered report.
+//
+// The state, error, labels and SLO status of the Streams not implementing ExtendedStream are not reported,
+// nor the TransferStats and ClockEstimate of a Client not implementing ExtendedClient.
+// The map must not be modified while the handler is in use.
+func Handler(c Client, streams map[string]Stream) http.Handler {
+	h := &healthHandler{client: c, streams: streams}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", h.healthz)
+	mux.HandleFunc("GET /stats", h.stats)
+	mux.HandleFunc("GET /feeds", h.feeds)
+	return mux
+}
+
+type healthHandler struct {
+	client  Client
+	streams map[string]Stream
+}
+
+type healthResponse struct {
+	Healthy bool                    `json:"healthy"`
+	Streams map[string]streamHealth `json:"streams"`
+}
+
+type streamHealth struct {
+	Healthy           bool   `json:"healthy"`
+	State             string `json:"state"`
+	Error             string `json:"error,omitempty"`
+	ActiveConnections uint64 `json:"activeConnections"`
+	SLOBreached       bool   `json:"sloBreached,omitempty"`
+}
+
+type statsResponse struct {
+	Time     time.Time              `json:"time"`
+	Transfer TransferStats          `json:"transfer"`
+	Clock    ClockEstimate          `json:"clock"`
+	Streams  map[string]streamStats `json:"streams"`
+}
+
+type streamStats struct {
+	Labels   Labels                 `json:"labels,omitempty"`
+	Stats    Stats                  `json:"stats"`
+	Counters map[StatCounter]uint64 `json:"counters,omitempty"`
+	Latency  *LatencyStats          `json:"latency,omitempty"`
+	SLO      *SLOStatus             `json:"slo,omitempty"`
+}
+
+type feedStatus struct {
+	FeedID        string           `json:"feedID"`
+	Version       feed.FeedVersion `json:"version"`
+	LastDelivered uint64           `json:"lastDelivered,omitempty"`
+}
+
+func (h *healthHandler) healthz(w http.ResponseWriter, _ *http.Request) {
+	resp := healthResponse{Healthy: true, Streams: map[string]streamHealth{}}
+	for name, s := range h.streams {
+		st := streamHealth{ActiveConnections: s.Stats().ActiveConnections}
+		if es, ok := s.(ExtendedStream); ok {
+			st.State = es.State().String()
+			if err := es.Err(); err != nil {
+				st.Error = err.Error()
+			}
+			slo := es.SLO()
+			st.SLOBreached = slo.UptimeBreached || slo.StalenessBreached
+		}
+		st.Healthy = st.Error == "" && st.ActiveConnections > 0
+
+		resp.Healthy = resp.Healthy && st.Healthy
+		resp.Streams[name] = st
+	}
+
+	status := http.StatusOK
+	if !resp.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, resp)
+}
+
+func (h *healthHandler) stats(w http.ResponseWriter, _ *http.Request) {
+	resp := statsResponse{Time: time.Now().UTC(), Streams: map[string]streamStats{}}
+	if ec, ok := h.client.(ExtendedClient); ok {
+		resp.Transfer, resp.Clock = ec.TransferStats(), ec.ClockEstimate()
+	}
+	for name, s := range h.streams {
+		st := streamStats{Stats: s.Stats()}
+		if es, ok := s.(ExtendedStream); ok {
+			st.Labels = es.Labels()
+			v2 := es.StatsV2()
+			st.Counters, st.Latency = v2.Counters, v2.Latency
+			if slo := es.SLO(); slo.Samples > 0 {
+				st.SLO = &slo
+			}
+		}
+		resp.Streams[name] = st
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *healthHandler) feeds(w http.ResponseWriter, _ *http.Request) {
+	resp := map[string][]feedStatus{}
+	for name, s := range h.streams {
+		resp[name] = []feedStatus{}
+		is := internalStream(s)
+		if is == nil {
+			continue
+		}
+
+		is.deliveredMu.Lock()
+		for _, id := range is.feeds() {
+			resp[name] = append(resp[name], feedStatus{
+				FeedID:        id.String(),
+				Version:       id.Version(),
+				LastDelivered: is.delivered[id.String()],
+			})
+		}
+		is.deliveredMu.Unlock()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// internalStream returns the stream implementing the Stream, nil if not implemented by this package.
+func internalStream(s Stream) *stream {
+	switch s := s.(type) {
+	case *stream:
+		return s
+	case *handlerStream:
+		return internalStream(s.ExtendedStream)
+	case *routedStream:
+		return internalStream(s.ExtendedStream)
+	default:
+		return nil
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
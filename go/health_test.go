@@ -0,0 +1,112 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestHandler(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		b, _ := json.Marshal(&message{&ReportResponse{FeedID: feed1, ObservationsTimestamp: 12344}})
+		if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+			t.Errorf("failed to write message: %s", err)
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1, feed2})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+	if _, err = sub.Read(context.Background()); err != nil {
+		t.Fatalf("Read() error = %s", err)
+	}
+
+	routed, err := streamsClient.StreamRouted(context.Background(), map[feed.ID]func(*ReportResponse){feed1: func(*ReportResponse) {}})
+	if err != nil {
+		t.Fatalf("error subscribing routed %s", err)
+	}
+	defer routed.Close()
+
+	srv := httptest.NewServer(Handler(streamsClient, map[string]Stream{"prices": sub, "routed": routed}))
+	defer srv.Close()
+
+	get := func(path string, wantStatus int, v any) {
+		t.Helper()
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s error = %s", path, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != wantStatus {
+			t.Errorf("GET %s status = %d, want %d", path, resp.StatusCode, wantStatus)
+		}
+		if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("GET %s error decoding response: %s", path, err)
+		}
+	}
+
+	var health healthResponse
+	get("/healthz", http.StatusOK, &health)
+	if !health.Healthy || !health.Streams["prices"].Healthy || health.Streams["prices"].ActiveConnections != 1 ||
+		health.Streams["prices"].State != "connected" {
+		t.Errorf("GET /healthz = %+v, want healthy", health)
+	}
+
+	var stats statsResponse
+	get("/stats", http.StatusOK, &stats)
+	if stats.Streams["prices"].Stats.Accepted != 1 {
+		t.Errorf("GET /stats accepted = %d, want 1", stats.Streams["prices"].Stats.Accepted)
+	}
+	if got := stats.Streams["prices"].Counters[StatAccepted]; got != 1 {
+		t.Errorf("GET /stats counters accepted = %d, want 1", got)
+	}
+
+	var feeds map[string][]feedStatus
+	get("/feeds", http.StatusOK, &feeds)
+	want := []feedStatus{
+		{FeedID: feed1.String(), Version: feed1.Version(), LastDelivered: 12344},
+		{FeedID: feed2.String(), Version: feed2.Version()},
+	}
+	if got := feeds["prices"]; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("GET /feeds = %+v, want %+v", got, want)
+	}
+	if got := feeds["routed"]; len(got) != 1 || got[0].FeedID != feed1.String() {
+		t.Errorf("GET /feeds routed = %+v, want feed %s", got, feed1.String())
+	}
+
+	_ = sub.Close()
+	_ = routed.Close()
+	health = healthResponse{}
+	get("/healthz", http.StatusServiceUnavailable, &health)
+	if health.Healthy || health.Streams["prices"].Error != ErrStreamClosed.Error() || health.Streams["prices"].State != "closed" {
+		t.Errorf("GET /healthz = %+v, want unhealthy", health)
+	}
+}
@@ -0,0 +1,19 @@
+package streams
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+)
+
+// IdempotencyKey returns a key uniquely identifying the report content, the hex encoded SHA-256 hash
+// of its feed ID, observations timestamp and full report.
+// The key is the same for every delivery of the report, across connections, Streams and restarts,
+// so downstream writers can use it as a unique key to avoid storing duplicates.
+func (r *ReportResponse) IdempotencyKey() string {
+	h := sha256.New()
+	h.Write(r.FeedID[:])
+	h.Write(binary.BigEndian.AppendUint64(nil, r.ObservationsTimestamp))
+	h.Write(r.FullReportBytes())
+	return hex.EncodeToString(h.Sum(nil))
+}
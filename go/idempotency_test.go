@@ -0,0 +1,28 @@
+package streams
+
+import (
+	"testing"
+)
+
+func TestReportResponse_IdempotencyKey(t *testing.T) {
+	r := &ReportResponse{FeedID: feed1, FullReport: []byte{1, 2, 3}, ObservationsTimestamp: 100}
+	key := r.IdempotencyKey()
+	if len(key) != 64 {
+		t.Fatalf("IdempotencyKey() = %s, want a 64 characters hex string", key)
+	}
+
+	same := &ReportResponse{FeedID: feed1, FullReport: []byte{1, 2, 3}, ObservationsTimestamp: 100, ValidFromTimestamp: 99}
+	if got := same.IdempotencyKey(); got != key {
+		t.Errorf("IdempotencyKey() = %s, want %s for the same report", got, key)
+	}
+
+	for _, other := range []*ReportResponse{
+		{FeedID: feed2, FullReport: []byte{1, 2, 3}, ObservationsTimestamp: 100},
+		{FeedID: feed1, FullReport: []byte{1, 2, 4}, ObservationsTimestamp: 100},
+		{FeedID: feed1, FullReport: []byte{1, 2, 3}, ObservationsTimestamp: 101},
+	} {
+		if got := other.IdempotencyKey(); got == key {
+			t.Errorf("IdempotencyKey() = %s for different report %s", got, other)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+// Package cborenc implements the CBOR (RFC 8949) encoding of the reports as maps keyed by field number:
+// bytes as byte strings, integers of at most 64 bits as CBOR integers and big integers as CBOR integers
+// or bignums when exceeding 64 bits. Every field is encoded, nil big integers as null.
+// Only definite length items are supported.
+package cborenc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// CBOR major types
+const (
+	majorUint   = 0
+	majorNegInt = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorTag    = 6
+	majorSimple = 7
+)
+
+// CBOR simple values and bignum tags
+const (
+	simpleFalse = 20
+	simpleTrue  = 21
+	simpleNull  = 22
+
+	tagPosBignum = 2
+	tagNegBignum = 3
+)
+
+// maxDepth bounds the nesting of the skipped unknown field values.
+const maxDepth = 16
+
+var errTruncated = errors.New("unexpected end of data")
+
+func appendHead(b []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(b, major|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(b, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, major|27), arg)
+	}
+}
+
+// AppendMap appends the header of a map of n fields.
+func AppendMap(b []byte, n int) []byte {
+	return appendHead(b, majorMap, uint64(n))
+}
+
+// AppendBytes appends the byte string field.
+func AppendBytes(b []byte, key uint64, v []byte) []byte {
+	b = appendHead(appendHead(b, majorUint, key), majorBytes, uint64(len(v)))
+	return append(b, v...)
+}
+
+// AppendString appends the text string field.
+func AppendString(b []byte, key uint64, v string) []byte {
+	b = appendHead(appendHead(b, majorUint, key), majorText, uint64(len(v)))
+	return append(b, v...)
+}
+
+// AppendUint appends the unsigned integer field.
+func AppendUint(b []byte, key uint64, v uint64) []byte {
+	return appendHead(appendHead(b, majorUint, key), majorUint, v)
+}
+
+// AppendInt appends the signed integer field.
+func AppendInt(b []byte, key uint64, v int64) []byte {
+	b = appendHead(b, majorUint, key)
+	if v < 0 {
+		return appendHead(b, majorNegInt, ^uint64(v))
+	}
+	return appendHead(b, majorUint, uint64(v))
+}
+
+// AppendBool appends the bool field.
+func AppendBool(b []byte, key uint64, v bool) []byte {
+	b = appendHead(b, majorUint, key)
+	if v {
+		return append(b, majorSimple<<5|simpleTrue)
+	}
+	return append(b, majorSimple<<5|simpleFalse)
+}
+
+// AppendBigInt appends the big integer field as an integer if it fits in 64 bits, a bignum otherwise, null if nil.
+func AppendBigInt(b []byte, key uint64, v *big.Int) []byte {
+	b = appendHead(b, majorUint, key)
+	switch {
+	case v == nil:
+		return append(b, majorSimple<<5|simpleNull)
+	case v.Sign() >= 0 && v.IsUint64():
+		return appendHead(b, majorUint, v.Uint64())
+	case v.Sign() >= 0:
+		return appendBignum(b, tagPosBignum, v.Bytes())
+	}
+	// negative integers are encoded as -1-n
+	n := new(big.Int).Not(v)
+	if n.IsUint64() {
+		return appendHead(b, majorNegInt, n.Uint64())
+	}
+	return appendBignum(b, tagNegBignum, n.Bytes())
+}
+
+func appendBignum(b []byte, tag uint64, v []byte) []byte {
+	b = appendHead(appendHead(b, majorTag, tag), majorBytes, uint64(len(v)))
+	return append(b, v...)
+}
+
+// Field is a decoded field of a map.
+type Field struct {
+	Key    uint64
+	major  byte
+	arg    uint64
+	tag    uint64
+	tagged bool
+	bytes  []byte
+}
+
+// head is a decoded item head.
+type head struct {
+	major byte
+	arg   uint64
+}
+
+// readHead reads the head of the next item of b, returning the rest of b.
+func readHead(b []byte) (h head, rest []byte, err error) {
+	if len(b) == 0 {
+		return h, nil, errTruncated
+	}
+	h.major, h.arg = b[0]>>5, uint64(b[0]&0x1f)
+	b = b[1:]
+	size := 0
+	switch h.arg {
+	case 24:
+		size = 1
+	case 25:
+		size = 2
+	case 26:
+		size = 4
+	case 27:
+		size = 8
+	case 28, 29, 30:
+		return h, nil, fmt.Errorf("invalid additional information %d", h.arg)
+	case 31:
+		return h, nil, errors.New("indefinite length items not supported")
+	}
+	if len(b) < size {
+		return h, nil, errTruncated
+	}
+	if size > 0 {
+		h.arg = 0
+		for _, c := range b[:size] {
+			h.arg = h.arg<<8 | uint64(c)
+		}
+	}
+	return h, b[size:], nil
+}
+
+// readValue reads the value item of a field, returning the rest of b. Nested items are skipped.
+func readValue(b []byte, f *Field, depth int) (rest []byte, err error) {
+	if depth > maxDepth {
+		return nil, errors.New("nesting too deep")
+	}
+	h, b, err := readHead(b)
+	if err != nil {
+		return nil, err
+	}
+	f.major, f.arg = h.major, h.arg
+	switch h.major {
+	case majorBytes, majorText:
+		if uint64(len(b)) < h.arg {
+			return nil, errTruncated
+		}
+		f.bytes = b[:h.arg]
+		return b[h.arg:], nil
+	case majorArray, majorMap:
+		items := h.arg
+		if h.major == majorMap {
+			items *= 2
+		}
+		for ; items > 0; items-- {
+			if b, err = readValue(b, &Field{}, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case majorTag:
+		tag := h.arg
+		if b, err = readValue(b, f, depth+1); err != nil {
+			return nil, err
+		}
+		f.tag, f.tagged = tag, true
+		return b, nil
+	}
+	return b, nil
+}
+
+// Decode calls fn with each field of the map, unknown fields are skipped by fn returning nil.
+// Data following the map fails.
+func Decode(b []byte, fn func(f Field) error) error {
+	h, b, err := readHead(b)
+	if err != nil {
+		return fmt.Errorf("invalid map: %w", err)
+	}
+	if h.major != majorMap {
+		return fmt.Errorf("unexpected major type %d, expected map", h.major)
+	}
+	for n := h.arg; n > 0; n-- {
+		var key head
+		if key, b, err = readHead(b); err != nil {
+			return fmt.Errorf("invalid key: %w", err)
+		}
+		if key.major != majorUint {
+			return fmt.Errorf("unexpected key major type %d", key.major)
+		}
+		f := Field{Key: key.arg}
+		if b, err = readValue(b, &f, 0); err != nil {
+			return fmt.Errorf("invalid field %d: %w", f.Key, err)
+		}
+		if err = fn(f); err != nil {
+			return fmt.Errorf("invalid field %d: %w", f.Key, err)
+		}
+	}
+	if len(b) > 0 {
+		return fmt.Errorf("%d bytes after map", len(b))
+	}
+	return nil
+}
+
+func (f Field) expect(major byte) error {
+	if f.major != major || f.tagged {
+		return fmt.Errorf("unexpected major type %d", f.major)
+	}
+	return nil
+}
+
+// Bytes returns a copy of the byte string field value.
+func (f Field) Bytes() ([]byte, error) {
+	if err := f.expect(majorBytes); err != nil {
+		return nil, err
+	}
+	return append([]byte{}, f.bytes...), nil
+}
+
+// CopyFixed copies the byte string field value to the fixed size array slice dst, failing if their lengths differ.
+func (f Field) CopyFixed(dst []byte) error {
+	if err := f.expect(majorBytes); err != nil {
+		return err
+	}
+	if len(f.bytes) != len(dst) {
+		return fmt.Errorf("invalid length %d, expected %d bytes", len(f.bytes), len(dst))
+	}
+	copy(dst, f.bytes)
+	return nil
+}
+
+// String returns the text string field value.
+func (f Field) String() (string, error) {
+	if err := f.expect(majorText); err != nil {
+		return "", err
+	}
+	return string(f.bytes), nil
+}
+
+// Uint returns the unsigned integer field value, failing if it overflows bits.
+func (f Field) Uint(bits int) (uint64, error) {
+	if err := f.expect(majorUint); err != nil {
+		return 0, err
+	}
+	if bits < 64 && f.arg >= 1<<bits {
+		return 0, fmt.Errorf("integer %d overflows %d bits", f.arg, bits)
+	}
+	return f.arg, nil
+}
+
+// Int returns the signed integer field value, failing if it overflows bits.
+func (f Field) Int(bits int) (int64, error) {
+	if f.major != majorNegInt {
+		if err := f.expect(majorUint); err != nil {
+			return 0, err
+		}
+	}
+	// both n and -1-n fit in bits if n does not exceed the largest positive value
+	if f.tagged || f.arg > math.MaxInt64>>(64-bits) {
+		return 0, fmt.Errorf("integer overflows %d bits", bits)
+	}
+	if f.major == majorNegInt {
+		return ^int64(f.arg), nil
+	}
+	return int64(f.arg), nil
+}
+
+// Bool returns the bool field value.
+func (f Field) Bool() (bool, error) {
+	if f.major != majorSimple || f.tagged || f.arg != simpleFalse && f.arg != simpleTrue {
+		return false, fmt.Errorf("unexpected major type %d, expected bool", f.major)
+	}
+	return f.arg == simpleTrue, nil
+}
+
+// BigInt returns the integer or bignum field value, nil if null.
+func (f Field) BigInt() (*big.Int, error) {
+	switch {
+	case f.major == majorSimple && !f.tagged && f.arg == simpleNull:
+		return nil, nil
+	case f.major == majorUint && !f.tagged:
+		return new(big.Int).SetUint64(f.arg), nil
+	case f.major == majorNegInt && !f.tagged:
+		return new(big.Int).Not(new(big.Int).SetUint64(f.arg)), nil
+	case f.major == majorBytes && f.tagged && f.tag == tagPosBignum:
+		return new(big.Int).SetBytes(f.bytes), nil
+	case f.major == majorBytes && f.tagged && f.tag == tagNegBignum:
+		return new(big.Int).Not(new(big.Int).SetBytes(f.bytes)), nil
+	}
+	return nil, fmt.Errorf("unexpected major type %d, expected integer", f.major)
+}
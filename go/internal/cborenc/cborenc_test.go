@@ -0,0 +1,133 @@
+package cborenc
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestEncoding(t *testing.T) {
+	i, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	var b []byte
+	b = AppendMap(b, 9)
+	b = AppendBytes(b, 1, []byte{0, 1, 2})
+	b = AppendUint(b, 2, 300)
+	b = AppendInt(b, 3, -5)
+	b = AppendBigInt(b, 4, i)
+	b = AppendBigInt(b, 5, nil)
+	b = AppendBigInt(b, 6, big.NewInt(-7))
+	b = AppendBool(b, 7, true)
+	b = AppendString(b, 8, "s")
+	// unknown fields are skipped
+	b = append(b, 0x18, 0x63, 0x82, 0x01, 0xa1, 0x01, 0x02) // 99: [1, {1: 2}]
+
+	got := map[uint64]any{}
+	err := Decode(b, func(f Field) (err error) {
+		var v any
+		switch f.Key {
+		case 1:
+			fixed := make([]byte, 3)
+			err, v = f.CopyFixed(fixed), fixed
+		case 2:
+			v, err = f.Uint(16)
+		case 3:
+			v, err = f.Int(8)
+		case 4, 5, 6:
+			v, err = f.BigInt()
+		case 7:
+			v, err = f.Bool()
+		case 8:
+			v, err = f.String()
+		default:
+			return nil
+		}
+		got[f.Key] = v
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Decode() error = %s", err)
+	}
+	want := map[uint64]any{1: []byte{0, 1, 2}, 2: uint64(300), 3: int64(-5), 4: i, 5: (*big.Int)(nil),
+		6: big.NewInt(-7), 7: true, 8: "s"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %v, want %v", got, want)
+	}
+}
+
+// TestEncoding_RFC checks the encoding against the examples of RFC 8949 appendix A.
+func TestEncoding_RFC(t *testing.T) {
+	bignum, _ := new(big.Int).SetString("18446744073709551616", 10)
+	negBignum := new(big.Int).Neg(bignum)
+	negBignum.Sub(negBignum, big.NewInt(1))
+
+	tests := []struct {
+		name string
+		b    []byte
+		want string
+	}{
+		{name: "uint 23", b: AppendUint(nil, 0, 23), want: "0017"},
+		{name: "uint 24", b: AppendUint(nil, 0, 24), want: "001818"},
+		{name: "uint 1000", b: AppendUint(nil, 0, 1000), want: "001903e8"},
+		{name: "uint 1000000", b: AppendUint(nil, 0, 1000000), want: "001a000f4240"},
+		{name: "uint 1000000000000", b: AppendUint(nil, 0, 1000000000000), want: "001b000000e8d4a51000"},
+		{name: "int -1000", b: AppendInt(nil, 0, -1000), want: "003903e7"},
+		{name: "bigint -18446744073709551616", b: AppendBigInt(nil, 0, new(big.Int).Add(negBignum, big.NewInt(1))),
+			want: "003bffffffffffffffff"},
+		{name: "bignum 18446744073709551616", b: AppendBigInt(nil, 0, bignum), want: "00c249010000000000000000"},
+		{name: "bignum -18446744073709551617", b: AppendBigInt(nil, 0, negBignum), want: "00c349010000000000000000"},
+		{name: "bool", b: AppendBool(nil, 0, false), want: "00f4"},
+		{name: "null", b: AppendBigInt(nil, 0, nil), want: "00f6"},
+		{name: "bytes", b: AppendBytes(nil, 0, []byte{1, 2, 3, 4}), want: "004401020304"},
+		{name: "text", b: AppendString(nil, 0, "IETF"), want: "006449455446"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hex.EncodeToString(tt.b); got != tt.want {
+				t.Errorf("Append() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestField_Errors(t *testing.T) {
+	var b []byte
+	b = AppendMap(b, 5)
+	b = AppendUint(b, 1, 256)
+	b = AppendInt(b, 2, -129)
+	b = AppendString(b, 3, "1")
+	b = AppendBytes(b, 4, []byte{1, 2})
+	b = AppendBigInt(b, 5, new(big.Int).Lsh(big.NewInt(1), 64))
+
+	checks := map[uint64]func(Field) error{
+		1: func(f Field) (err error) { _, err = f.Uint(8); return err },
+		2: func(f Field) (err error) { _, err = f.Int(8); return err },
+		3: func(f Field) (err error) { _, err = f.BigInt(); return err },
+		4: func(f Field) error { return f.CopyFixed(make([]byte, 3)) },
+		5: func(f Field) (err error) { _, err = f.Int(64); return err },
+	}
+	err := Decode(b, func(f Field) error {
+		if err := checks[f.Key](f); err == nil {
+			t.Errorf("field %d error = nil, want error", f.Key)
+		}
+		if _, err := f.Bool(); err == nil {
+			t.Errorf("field %d Bool() error = nil, want major type error", f.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Decode() error = %s", err)
+	}
+
+	for name, b := range map[string][]byte{
+		"truncated":  {0xa1, 0x01, 0x45, 0x01},
+		"not a map":  {0x81, 0x01},
+		"text key":   {0xa1, 0x61, 0x61, 0x01},
+		"trailing":   {0xa0, 0x00},
+		"indefinite": {0xbf, 0xff},
+	} {
+		if err := Decode(b, func(Field) error { return nil }); err == nil {
+			t.Errorf("Decode() of %s map error = nil, want error", name)
+		}
+	}
+}
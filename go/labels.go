@@ -0,0 +1,54 @@
+package streams
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Labels are key value pairs identifying a Stream consumer, such as its service, strategy or environment.
+// Labels are passed in the context.Context under LabelsCtxKey when creating the Stream and
+// are included in the Stream logs, connection status changes, events and SLO breaches
+// so that traffic and errors of shared deployments can be attributed to each consumer.
+type Labels map[string]string
+
+// String returns the labels as comma separated key=value pairs sorted by key.
+func (l Labels) String() string {
+	var b strings.Builder
+	for x, k := range slices.Sorted(maps.Keys(l)) {
+		if x > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(l[k])
+	}
+	return b.String()
+}
+
+// labelsFromContext returns a copy of the labels passed in the context, nil if none.
+func labelsFromContext(ctx context.Context) (l Labels) {
+	switch v := ctx.Value(LabelsCtxKey).(type) {
+	case Labels:
+		l = v
+	case map[string]string:
+		l = v
+	}
+	if len(l) == 0 {
+		return nil
+	}
+	return maps.Clone(l)
+}
+
+// withLabels returns the config with its Logger prefixing all messages with the labels.
+func (c Config) withLabels(l Labels) Config {
+	if c.Logger == nil || len(l) == 0 {
+		return c
+	}
+	logger, prefix := c.Logger, "["+strings.ReplaceAll(l.String(), "%", "%%")+"] "
+	c.Logger = func(format string, a ...any) {
+		logger(prefix+format, a...)
+	}
+	return c
+}
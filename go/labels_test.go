@@ -0,0 +1,105 @@
+package streams
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestLabels_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels Labels
+		want   string
+	}{
+		{name: "nil", labels: nil, want: ""},
+		{name: "single", labels: Labels{"service": "pricer"}, want: "service=pricer"},
+		{
+			name:   "sorted",
+			labels: Labels{"strategy": "arb", "env": "prod", "service": "pricer"},
+			want:   "env=prod,service=pricer,strategy=arb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.labels.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_StreamLabels(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		_ = conn.Write(context.Background(), websocket.MessageText, []byte(`{"type":"notice","message":"hello"}`))
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	var mu sync.Mutex
+	var logs []string
+	streamsClient.(*client).config.Logger = func(format string, a ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+		logs = append(logs, format)
+	}
+
+	labels := Labels{"service": "pricer", "env": "prod"}
+	statuses := make(chan ConnStatus, 1)
+	ctx := context.WithValue(context.Background(), LabelsCtxKey, labels)
+	sub, err := streamsClient.StreamWithConnStatusCallback(ctx, []feed.ID{feed1}, func(st ConnStatus) {
+		statuses <- st
+	})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	labels["service"] = "modified"
+
+	want := Labels{"service": "pricer", "env": "prod"}
+	if got := sub.Labels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Labels() = %v, want %v", got, want)
+	}
+	if e := <-sub.Events(); !reflect.DeepEqual(e.Labels, want) {
+		t.Errorf("Event.Labels = %v, want %v", e.Labels, want)
+	}
+
+	_ = sub.Close()
+	if st := <-statuses; !reflect.DeepEqual(st.Labels, want) {
+		t.Errorf("ConnStatus.Labels = %v, want %v", st.Labels, want)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(logs) == 0 {
+		t.Fatalf("no stream logs")
+	}
+	for _, l := range logs {
+		if !strings.HasPrefix(l, "[env=prod,service=pricer] ") {
+			t.Errorf("log %q not prefixed with labels", l)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package streams
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultLatencyAlpha = 0.1
+
+// LatencyConfig enables the smoothed end-to-end latency of each Stream feed, the exponentially
+// weighted moving average of the delay between the observations timestamp of each accepted report
+// and its reception, reported in StatsV2.Latency. The delays have the second resolution of the
+// observations timestamps and are corrected by the estimated clock offset to the server,
+// see ExtendedClient.ClockEstimate.
+type LatencyConfig struct {
+	// Alpha is the weight of each new delay in the moving average, between 0 and 1, defaults to 0.1.
+	// Higher values follow latency changes faster but smooth less.
+	Alpha float64
+}
+
+// LatencyStats holds the smoothed end-to-end latency of the Stream feeds, see LatencyConfig.
+type LatencyStats struct {
+	Feeds map[string]FeedLatency // Latency by feed ID, feeds without an accepted report are omitted
+}
+
+// FeedLatency is the end-to-end latency of a feed.
+type FeedLatency struct {
+	Smoothed time.Duration // Exponentially weighted moving average of the delays
+	Last     time.Duration // Delay of the last accepted report
+	Samples  uint64        // Number of accepted reports
+}
+
+// latencyTracker tracks the feeds latency, updated by the dispatcher and read by Stats.
+type latencyTracker struct {
+	alpha float64
+
+	mu    sync.Mutex
+	feeds map[string]FeedLatency
+}
+
+func newLatencyTracker(cfg LatencyConfig) *latencyTracker {
+	alpha := cfg.Alpha
+	if alpha <= 0 {
+		alpha = defaultLatencyAlpha
+	}
+	return &latencyTracker{alpha: alpha, feeds: map[string]FeedLatency{}}
+}
+
+// accepted records the delay of the accepted report of the feed.
+func (l *latencyTracker) accepted(id string, observationsTimestamp uint64, now time.Time) {
+	delay := now.Sub(time.Unix(int64(observationsTimestamp), 0))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fl := l.feeds[id]
+	if fl.Samples == 0 {
+		fl.Smoothed = delay
+	} else {
+		fl.Smoothed += time.Duration(l.alpha * float64(delay-fl.Smoothed))
+	}
+	fl.Last = delay
+	fl.Samples++
+	l.feeds[id] = fl
+}
+
+func (l *latencyTracker) stats() *LatencyStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	st := &LatencyStats{Feeds: make(map[string]FeedLatency, len(l.feeds))}
+	for id, fl := range l.feeds {
+		st.Feeds[id] = fl
+	}
+	return st
+}
+
+// remove stops tracking the feed.
+func (l *latencyTracker) remove(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.feeds, id)
+}
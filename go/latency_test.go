@@ -0,0 +1,83 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	l := newLatencyTracker(LatencyConfig{Alpha: 0.5})
+	now := time.Unix(1_700_000_100, 0)
+	id := feed1.String()
+
+	for _, delay := range []int64{4, 2, 2} {
+		l.accepted(id, uint64(now.Unix()-delay), now)
+	}
+	want := FeedLatency{Smoothed: 2500 * time.Millisecond, Last: 2 * time.Second, Samples: 3}
+	if got := l.stats().Feeds[id]; got != want {
+		t.Errorf("stats() = %+v, want %+v", got, want)
+	}
+
+	l.remove(id)
+	if got := l.stats().Feeds; len(got) != 0 {
+		t.Errorf("stats() after remove = %+v, want none", got)
+	}
+}
+
+func TestClient_StreamLatency(t *testing.T) {
+	// the server clock is ahead, the latency is corrected by the clock offset estimated on connection
+	const skew = time.Minute
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		w.Header().Set("Date", time.Now().Add(skew).UTC().Format(http.TimeFormat))
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		m := fmt.Sprintf(`{"report":{"feedID":"%s","fullReport":"0x01","observationsTimestamp":%d}}`,
+			feed1.String(), time.Now().Add(skew).Unix()-10)
+		if err = conn.Write(context.Background(), websocket.MessageBinary, []byte(m)); err != nil {
+			t.Errorf("failed to write message: %s", err)
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).config.Latency = &LatencyConfig{}
+
+	sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err = sub.Read(ctx); err != nil {
+		t.Fatalf("Read() error = %s", err)
+	}
+
+	fl := sub.StatsV2().Latency.Feeds[feed1.String()]
+	if fl.Samples != 1 || fl.Smoothed < 9*time.Second || fl.Smoothed > 12*time.Second {
+		t.Errorf("StatsV2().Latency = %+v, want a 10s delay", fl)
+	}
+}
@@ -0,0 +1,93 @@
+package streams
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+)
+
+const defaultLatestReportCacheTTL = time.Second
+
+// LatestReportCacheConfig enables caching the reports returned by GetLatestReport per feed.
+// A cached report is served for TTL, so newer reports are fetched once it elapses, and never
+// past the ExpiresAt of its report data, so expired reports are never served, e.g. to verification
+// flows. Reports already expired when fetched are not cached. GetFreshReport always fetches,
+// refreshing the cache.
+type LatestReportCacheConfig struct {
+	TTL time.Duration // Cache duration of the reports, defaults to 1 second
+}
+
+type latestEntry struct {
+	r       *ReportResponse
+	expires time.Time
+}
+
+// latestCache caches the latest report of each feed until it expires.
+type latestCache struct {
+	cfg LatestReportCacheConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[feed.ID]latestEntry
+}
+
+func newLatestCache(cfg LatestReportCacheConfig) *latestCache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultLatestReportCacheTTL
+	}
+	return &latestCache{cfg: cfg, now: time.Now, entries: map[feed.ID]latestEntry{}}
+}
+
+// get returns a copy of the cached report of the feed, if not expired.
+func (c *latestCache) get(id feed.ID) (r *ReportResponse, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, id)
+		return nil, false
+	}
+	cp := *e.r
+	return &cp, true
+}
+
+// put caches the report for TTL, capped by its expiry.
+func (c *latestCache) put(r *ReportResponse) {
+	now := c.now()
+	expires := now.Add(c.cfg.TTL)
+	if at, ok := reportExpiry(r); ok && at.Before(expires) {
+		expires = at
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !now.Before(expires) {
+		delete(c.entries, r.FeedID)
+		return
+	}
+	cp := *r
+	c.entries[r.FeedID] = latestEntry{r: &cp, expires: expires}
+}
+
+// reportExpiry returns the ExpiresAt of the report data, false if the data version has no expiry
+// or the report could not be decoded.
+func reportExpiry(r *ReportResponse) (at time.Time, ok bool) {
+	decoded, err := report.DecodeAny(r.FullReportBytes())
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	d, ok := decoded.AnyData().(report.PriceReport)
+	if !ok {
+		return time.Time{}, false
+	}
+	if _, _, expiresAt := d.Timestamps(); expiresAt != 0 {
+		return time.Unix(int64(expiresAt), 0), true
+	}
+	return time.Time{}, false
+}
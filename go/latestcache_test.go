@@ -0,0 +1,87 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+func TestClient_LatestReportCache(t *testing.T) {
+	feedV4 := mustFeedIDfromString("0x00040ffa644e6c585a5bec0e25ca476b6666666666e22b6240957720dcba0e14")
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name         string
+		cfg          LatestReportCacheConfig
+		ts           uint32 // the report expires at ts+100
+		advance      time.Duration
+		wantRequests int64
+	}{
+		{name: "cached for TTL", cfg: LatestReportCacheConfig{TTL: 20 * time.Second}, ts: uint32(now.Unix()) - 50,
+			advance: 19 * time.Second, wantRequests: 1},
+		{name: "TTL elapsed", cfg: LatestReportCacheConfig{TTL: 20 * time.Second}, ts: uint32(now.Unix()) - 50,
+			advance: 20 * time.Second, wantRequests: 2},
+		{name: "default TTL", ts: uint32(now.Unix()) - 50, advance: time.Second, wantRequests: 2},
+		{name: "expired before TTL", cfg: LatestReportCacheConfig{TTL: 20 * time.Second}, ts: uint32(now.Unix()) - 90,
+			advance: 10 * time.Second, wantRequests: 2},
+		{name: "cached until expiry", cfg: LatestReportCacheConfig{TTL: 20 * time.Second}, ts: uint32(now.Unix()) - 90,
+			advance: 9 * time.Second, wantRequests: 1},
+		{name: "expired when fetched", ts: uint32(now.Unix()) - 100, wantRequests: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				_ = json.NewEncoder(w).Encode(map[string]*ReportResponse{"report": {
+					FeedID:     feedV4,
+					FullReport: mustV4FullReport(feedV4, tt.ts, v4.MarketStatusOpen),
+				}})
+			})
+			defer ms.Close()
+
+			streamsClient, err := ms.Client()
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+			cache := newLatestCache(tt.cfg)
+			cache.now = func() time.Time { return now }
+			streamsClient.(*client).latest = cache
+
+			if _, err = streamsClient.GetLatestReport(context.Background(), feedV4); err != nil {
+				t.Fatalf("GetLatestReport() error = %s", err)
+			}
+			cache.now = func() time.Time { return now.Add(tt.advance) }
+			r, err := streamsClient.GetLatestReport(context.Background(), feedV4)
+			if err != nil {
+				t.Fatalf("GetLatestReport() error = %s", err)
+			}
+			if r.FeedID != feedV4 {
+				t.Errorf("GetLatestReport() FeedID = %s, want %s", r.FeedID.String(), feedV4.String())
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestLatestCache_NoExpiry(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cache := newLatestCache(LatestReportCacheConfig{TTL: 2 * time.Second})
+	cache.now = func() time.Time { return now }
+
+	cache.put(&ReportResponse{FeedID: feed1, FullReport: []byte("not a report")})
+	if _, ok := cache.get(feed1); !ok {
+		t.Fatalf("get() not cached, want cached for TTL")
+	}
+	cache.now = func() time.Time { return now.Add(2 * time.Second) }
+	if _, ok := cache.get(feed1); ok {
+		t.Errorf("get() cached after TTL")
+	}
+}
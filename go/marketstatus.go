@@ -0,0 +1,109 @@
+package streams
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/common"
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+const defaultMarketStatusConfirmations = 2
+
+// MarketStatusConfig enables tracking the market status of v4 Stream reports.
+// Each confirmed market status transition of a feed is published as an EventMarketStatus event.
+// Reports with an unknown market status do not change the feed market status.
+type MarketStatusConfig struct {
+	// Suppress drops the reports of a feed while its market is confirmed closed.
+	Suppress bool
+
+	// Confirmations is the number of consecutive reports with a new market status required
+	// to confirm a transition, protecting against flapping status reports. Defaults to 2.
+	// The first known status of a feed is confirmed immediately.
+	Confirmations int
+}
+
+func (c *MarketStatusConfig) confirmations() int {
+	if c.Confirmations <= 0 {
+		return defaultMarketStatusConfirmations
+	}
+	return c.Confirmations
+}
+
+// marketStatus is the market status of a feed.
+type marketStatus struct {
+	confirmed common.MarketStatus // confirmed market status
+	candidate common.MarketStatus // new market status being confirmed
+	count     int                 // number of consecutive candidate reports
+}
+
+// marketStatusTracker tracks the feeds market status, only accessed by the dispatcher.
+type marketStatusTracker struct {
+	cfg   MarketStatusConfig
+	feeds map[feed.ID]*marketStatus
+}
+
+// observe updates the feed market status with the report returning whether the report must be dropped
+// and the confirmed transition, if any.
+func (t *marketStatusTracker) observe(r *ReportResponse) (drop bool, transition *Event, err error) {
+	if r.FeedID.Version() != feed.FeedVersion4 {
+		return false, nil, nil
+	}
+
+	decoded, err := report.Decode[v4.Data](r.FullReportBytes())
+	if err != nil {
+		return false, nil, err
+	}
+	status := decoded.Data.MarketStatus
+
+	st, ok := t.feeds[r.FeedID]
+	switch {
+	case status == common.MarketStatusUnknown:
+	case !ok:
+		st = &marketStatus{confirmed: status}
+		t.feeds[r.FeedID] = st
+		transition = marketStatusEvent(r, common.MarketStatusUnknown, status)
+	case status == st.confirmed:
+		st.candidate, st.count = 0, 0
+	default:
+		if status != st.candidate {
+			st.candidate, st.count = status, 0
+		}
+		if st.count++; st.count >= t.cfg.confirmations() {
+			transition = marketStatusEvent(r, st.confirmed, status)
+			st.confirmed, st.candidate, st.count = status, 0, 0
+		}
+	}
+
+	return t.cfg.Suppress && st != nil && st.confirmed == common.MarketStatusClosed, transition, nil
+}
+
+func marketStatusEvent(r *ReportResponse, from, to common.MarketStatus) *Event {
+	return &Event{
+		Type:         EventMarketStatus,
+		Message:      fmt.Sprintf("market %s -> %s", from, to),
+		Time:         time.Unix(int64(r.ObservationsTimestamp), 0),
+		FeedID:       r.FeedID,
+		MarketStatus: to,
+	}
+}
+
+// checkMarketStatus tracks the report market status returning whether it must be dropped.
+// Must only be called by the dispatcher.
+func (s *stream) checkMarketStatus(r *ReportResponse) (drop bool) {
+	drop, transition, err := s.marketStatus.observe(r)
+	if err != nil {
+		s.config.logInfo("client: stream report %s market status not decoded: %s", r.FeedID.String(), err)
+		return false
+	}
+	if transition != nil {
+		transition.Labels = s.labels
+		s.event(*transition)
+	}
+	if drop {
+		s.stats.suppressed.Add(1)
+	}
+	return drop
+}
@@ -0,0 +1,134 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/common"
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+	"nhooyr.io/websocket"
+)
+
+func mustV4FullReport(id feed.ID, ts uint32, marketStatus common.MarketStatus) []byte {
+	blob, err := v4.Schema().Pack(id, ts, ts, big.NewInt(10), big.NewInt(10), ts+100, big.NewInt(100), marketStatus)
+	if err != nil {
+		panic(err)
+	}
+
+	mustNewType := func(t string) abi.Type {
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		return typ
+	}
+	b, err := abi.Arguments{
+		{Name: "reportContext", Type: mustNewType("bytes32[3]")},
+		{Name: "reportBlob", Type: mustNewType("bytes")},
+		{Name: "rawRs", Type: mustNewType("bytes32[]")},
+		{Name: "rawSs", Type: mustNewType("bytes32[]")},
+		{Name: "rawVs", Type: mustNewType("bytes32")},
+	}.Pack([3][32]byte{}, blob, [][32]byte{}, [][32]byte{}, [32]byte{})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestClient_StreamMarketStatus(t *testing.T) {
+	feedV4 := mustFeedIDfromString("0x00040ffa644e6c585a5bec0e25ca476b6666666666e22b6240957720dcba0e14")
+	closed, open, unknown := v4.MarketStatusClosed, v4.MarketStatusOpen, v4.MarketStatusUnknown
+
+	// open, flapping closed report, closed, unknown, flapping open report, open
+	statuses := []common.MarketStatus{open, closed, open, closed, closed, closed, unknown, open, closed, open, open}
+	var reports []*ReportResponse
+	for x, st := range statuses {
+		ts := uint32(x + 1)
+		reports = append(reports, &ReportResponse{
+			FeedID: feedV4, ObservationsTimestamp: uint64(ts), FullReport: mustV4FullReport(feedV4, ts, st),
+		})
+	}
+	reports = append(reports, &ReportResponse{FeedID: feed1, ObservationsTimestamp: 100})
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for _, rep := range reports {
+			b, err := json.Marshal(&message{rep})
+			if err != nil {
+				t.Errorf("failed to serialize message: %s", err)
+			}
+			if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+				return
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).config.MarketStatus = &MarketStatusConfig{Suppress: true}
+
+	sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feedV4, feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	var delivered []uint64
+	for {
+		r, err := sub.Read(context.Background())
+		if err != nil {
+			t.Fatalf("error reading report %s", err)
+		}
+		if r.FeedID == feed1 {
+			break
+		}
+		delivered = append(delivered, r.ObservationsTimestamp)
+	}
+
+	// reports 5 to 10 are dropped while the market is confirmed closed
+	wantDelivered := []uint64{1, 2, 3, 4, 11}
+	if len(delivered) != len(wantDelivered) {
+		t.Fatalf("delivered = %v, want %v", delivered, wantDelivered)
+	}
+	for x := range delivered {
+		if delivered[x] != wantDelivered[x] {
+			t.Fatalf("delivered = %v, want %v", delivered, wantDelivered)
+		}
+	}
+	if got := sub.StatsV2().Counter(StatSuppressed); got != 6 {
+		t.Errorf("StatsV2() suppressed = %d, want 6", got)
+	}
+
+	wantEvents := []struct {
+		status common.MarketStatus
+		at     int64
+	}{{open, 1}, {closed, 5}, {open, 11}}
+	for _, want := range wantEvents {
+		e := <-sub.Events()
+		if e.Type != EventMarketStatus || e.FeedID != feedV4 || e.MarketStatus != want.status || e.Time.Unix() != want.at {
+			t.Errorf("event = %+v, want market status %d at %d", e, want.status, want.at)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package streams
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// FullReportEncoding is the JSON encoding of the full report of a ReportResponse marshaled with MarshalJSONWith.
+type FullReportEncoding int
+
+const (
+	// FullReportHex encodes the full report as a 0x prefixed hex string, as the API does.
+	FullReportHex FullReportEncoding = iota
+	// FullReportBase64 encodes the full report as a standard base64 string.
+	FullReportBase64
+)
+
+// reportFields are the JSON fields of a marshaled ReportResponse, in order.
+var reportFields = []string{"feedID", "fullReport", "validFromTimestamp", "observationsTimestamp"}
+
+// MarshalOptions configures the JSON encoding of a ReportResponse by MarshalJSONWith,
+// the zero value encodes the same fields and values as MarshalJSON.
+type MarshalOptions struct {
+	// Fields are the JSON fields written, all the fields if empty.
+	// The fields are feedID, fullReport, validFromTimestamp and observationsTimestamp.
+	Fields []string
+	// Exclude are JSON fields not written.
+	Exclude []string
+	// StringTimestamps writes the timestamps as decimal strings.
+	StringTimestamps bool
+	// FullReportEncoding is the full report encoding, FullReportHex by default.
+	FullReportEncoding FullReportEncoding
+}
+
+// MarshalJSONWith encodes the report as a JSON object with the options, the fields are always written
+// in the order feedID, fullReport, validFromTimestamp, observationsTimestamp.
+// Unknown field names and encodings fail.
+func (r *ReportResponse) MarshalJSONWith(opts MarshalOptions) (b []byte, err error) {
+	for _, f := range slices.Concat(opts.Fields, opts.Exclude) {
+		if !slices.Contains(reportFields, f) {
+			return nil, fmt.Errorf("client: unknown report field %q", f)
+		}
+	}
+	if opts.FullReportEncoding != FullReportHex && opts.FullReportEncoding != FullReportBase64 {
+		return nil, fmt.Errorf("client: unknown full report encoding %d", opts.FullReportEncoding)
+	}
+
+	b = append(b, '{')
+	for _, f := range reportFields {
+		if len(opts.Fields) > 0 && !slices.Contains(opts.Fields, f) || slices.Contains(opts.Exclude, f) {
+			continue
+		}
+		if len(b) > 1 {
+			b = append(b, ',')
+		}
+		b = append(strconv.AppendQuote(b, f), ':')
+
+		switch f {
+		case "feedID":
+			b = append(append(append(b, `"`...), r.FeedID.String()...), '"')
+		case "fullReport":
+			b = r.appendFullReport(b, opts.FullReportEncoding)
+		case "validFromTimestamp":
+			b = appendTimestamp(b, r.ValidFromTimestamp, opts.StringTimestamps)
+		case "observationsTimestamp":
+			b = appendTimestamp(b, r.ObservationsTimestamp, opts.StringTimestamps)
+		}
+	}
+	return append(b, '}'), nil
+}
+
+// appendFullReport appends the full report JSON string in the encoding, a lazy hex encoded full report
+// is only decoded for other encodings.
+func (r *ReportResponse) appendFullReport(b []byte, enc FullReportEncoding) []byte {
+	b = append(b, '"')
+	switch {
+	case enc == FullReportHex && r.FullReport == nil && r.fullReportHex != nil:
+		b = append(append(b, "0x"...), r.fullReportHex...)
+	case enc == FullReportHex:
+		b = hex.AppendEncode(append(b, "0x"...), r.FullReport)
+	default:
+		raw := r.FullReport
+		if raw == nil && r.fullReportHex != nil {
+			raw, _ = hex.AppendDecode(nil, r.fullReportHex)
+		}
+		b = base64.StdEncoding.AppendEncode(b, raw)
+	}
+	return append(b, '"')
+}
+
+func appendTimestamp(b []byte, ts uint64, quoted bool) []byte {
+	if quoted {
+		return append(strconv.AppendUint(append(b, '"'), ts, 10), '"')
+	}
+	return strconv.AppendUint(b, ts, 10)
+}
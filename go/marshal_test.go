@@ -0,0 +1,65 @@
+package streams
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestReportResponse_MarshalJSONWith(t *testing.T) {
+	r := &ReportResponse{FeedID: feed1, FullReport: []byte{0xde, 0xad, 0xbe, 0xef}, ValidFromTimestamp: 9, ObservationsTimestamp: 10}
+	id := `"` + feed1.String() + `"`
+
+	tests := []struct {
+		name    string
+		r       *ReportResponse
+		opts    MarshalOptions
+		want    string
+		wantErr bool
+	}{
+		{name: "default", r: r,
+			want: `{"feedID":` + id + `,"fullReport":"0xdeadbeef","validFromTimestamp":9,"observationsTimestamp":10}`},
+		{name: "fields", r: r, opts: MarshalOptions{Fields: []string{"observationsTimestamp", "feedID"}},
+			want: `{"feedID":` + id + `,"observationsTimestamp":10}`},
+		{name: "exclude", r: r, opts: MarshalOptions{Exclude: []string{"feedID", "validFromTimestamp"}},
+			want: `{"fullReport":"0xdeadbeef","observationsTimestamp":10}`},
+		{name: "string timestamps", r: r, opts: MarshalOptions{StringTimestamps: true, Fields: []string{"validFromTimestamp", "observationsTimestamp"}},
+			want: `{"validFromTimestamp":"9","observationsTimestamp":"10"}`},
+		{name: "base64", r: r, opts: MarshalOptions{FullReportEncoding: FullReportBase64, Fields: []string{"fullReport"}},
+			want: `{"fullReport":"3q2+7w=="}`},
+		{name: "lazy hex", r: &ReportResponse{fullReportHex: []byte("deadbeef")}, opts: MarshalOptions{Fields: []string{"fullReport"}},
+			want: `{"fullReport":"0xdeadbeef"}`},
+		{name: "lazy base64", r: &ReportResponse{fullReportHex: []byte("deadbeef")},
+			opts: MarshalOptions{FullReportEncoding: FullReportBase64, Fields: []string{"fullReport"}},
+			want: `{"fullReport":"3q2+7w=="}`},
+		{name: "unknown field", r: r, opts: MarshalOptions{Exclude: []string{"validFrom"}}, wantErr: true},
+		{name: "unknown encoding", r: r, opts: MarshalOptions{FullReportEncoding: 2}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.MarshalJSONWith(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MarshalJSONWith() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalJSONWith() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportResponse_MarshalJSONWithDefault(t *testing.T) {
+	r := &ReportResponse{FeedID: feed1, FullReport: []byte{0xde, 0xad, 0xbe, 0xef}, ValidFromTimestamp: 9, ObservationsTimestamp: 10}
+	b, err := r.MarshalJSONWith(MarshalOptions{})
+	if err != nil {
+		t.Fatalf("MarshalJSONWith() error = %s", err)
+	}
+
+	got := &ReportResponse{}
+	if err = json.Unmarshal(b, got); err != nil {
+		t.Fatalf("Unmarshal() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("Unmarshal(MarshalJSONWith()) = %#v, want %#v", got, r)
+	}
+}
@@ -0,0 +1,87 @@
+package streams
+
+import (
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// MonotonicityConfig enables checking the per feed ordering of the reports delivered by a Stream:
+// the ObservationsTimestamp of each delivered report must be greater than the previous report of the
+// feed and its ValidFromTimestamp, when set, not lower.
+//
+// Streams guarantee this ordering to a single reader. Reports read concurrently by multiple goroutines
+// may be delivered out of order. Violations are counted in StatsV2 as StatMonotonicityViolations, logged and
+// passed to OnViolation, which is useful to assert the ordering guarantee during server migrations.
+type MonotonicityConfig struct {
+	// OnViolation is called with each violation by the goroutine reading the report, it must not block.
+	OnViolation func(MonotonicityViolation)
+}
+
+// Fields checked for monotonicity
+const (
+	MonotonicObservationsTimestamp = "observationsTimestamp"
+	MonotonicValidFromTimestamp    = "validFromTimestamp"
+)
+
+// MonotonicityViolation describes a delivered report out of order with the previous report of its feed.
+type MonotonicityViolation struct {
+	FeedID   feed.ID
+	Field    string // MonotonicObservationsTimestamp or MonotonicValidFromTimestamp
+	Previous uint64 // Field value of the previous report of the feed
+	Current  uint64 // Field value of the delivered report
+	Labels   Labels // Stream labels
+}
+
+type monotonicLast struct {
+	observationsTimestamp uint64
+	validFromTimestamp    uint64
+}
+
+// monotonicityChecker tracks the last delivered report of each feed.
+type monotonicityChecker struct {
+	cfg  MonotonicityConfig
+	last map[feed.ID]monotonicLast
+}
+
+func newMonotonicityChecker(cfg MonotonicityConfig) *monotonicityChecker {
+	return &monotonicityChecker{cfg: cfg, last: map[feed.ID]monotonicLast{}}
+}
+
+// check records the delivered report returning its violations, if any.
+func (m *monotonicityChecker) check(r *ReportResponse) (violations []MonotonicityViolation) {
+	last, ok := m.last[r.FeedID]
+	m.last[r.FeedID] = monotonicLast{observationsTimestamp: r.ObservationsTimestamp, validFromTimestamp: r.ValidFromTimestamp}
+	if !ok {
+		return nil
+	}
+
+	if r.ObservationsTimestamp <= last.observationsTimestamp {
+		violations = append(violations, MonotonicityViolation{
+			FeedID:   r.FeedID,
+			Field:    MonotonicObservationsTimestamp,
+			Previous: last.observationsTimestamp,
+			Current:  r.ObservationsTimestamp,
+		})
+	}
+	if r.ValidFromTimestamp != 0 && r.ValidFromTimestamp < last.validFromTimestamp {
+		violations = append(violations, MonotonicityViolation{
+			FeedID:   r.FeedID,
+			Field:    MonotonicValidFromTimestamp,
+			Previous: last.validFromTimestamp,
+			Current:  r.ValidFromTimestamp,
+		})
+	}
+	return violations
+}
+
+// monotonicityViolated reports a violation of the delivered reports ordering.
+func (s *stream) monotonicityViolated(v MonotonicityViolation) {
+	s.stats.monotonicityViolations.Add(1)
+	s.config.logInfo(
+		"client: stream report %s %s %d delivered after %d",
+		v.FeedID.String(), v.Field, v.Current, v.Previous,
+	)
+	if s.monotonicity.cfg.OnViolation != nil {
+		v.Labels = s.labels
+		s.monotonicity.cfg.OnViolation(v)
+	}
+}
@@ -0,0 +1,117 @@
+package streams
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestMonotonicityChecker(t *testing.T) {
+	m := newMonotonicityChecker(MonotonicityConfig{})
+
+	tests := []struct {
+		name   string
+		r      *ReportResponse
+		fields []string
+	}{
+		{name: "first", r: &ReportResponse{FeedID: feed1, ObservationsTimestamp: 10, ValidFromTimestamp: 5}},
+		{name: "other feed", r: &ReportResponse{FeedID: feed2, ObservationsTimestamp: 1}},
+		{name: "ordered", r: &ReportResponse{FeedID: feed1, ObservationsTimestamp: 11, ValidFromTimestamp: 5}},
+		{name: "no valid from", r: &ReportResponse{FeedID: feed1, ObservationsTimestamp: 12}},
+		{
+			name:   "repeated",
+			r:      &ReportResponse{FeedID: feed1, ObservationsTimestamp: 12, ValidFromTimestamp: 12},
+			fields: []string{MonotonicObservationsTimestamp},
+		},
+		{
+			name:   "both",
+			r:      &ReportResponse{FeedID: feed1, ObservationsTimestamp: 8, ValidFromTimestamp: 7},
+			fields: []string{MonotonicObservationsTimestamp, MonotonicValidFromTimestamp},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields []string
+			for _, v := range m.check(tt.r) {
+				fields = append(fields, v.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("check() = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestClient_StreamMonotonicity(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for _, m := range []string{
+			`{"report":{"feedID":"` + feed1.String() + `","fullReport":"0x01","observationsTimestamp":2,"validFromTimestamp":2}}`,
+			`{"report":{"feedID":"` + feed1.String() + `","fullReport":"0x02","observationsTimestamp":3,"validFromTimestamp":1}}`,
+		} {
+			if err = conn.Write(context.Background(), websocket.MessageBinary, []byte(m)); err != nil {
+				t.Errorf("failed to write message: %s", err)
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	violations := make(chan MonotonicityViolation, 1)
+	streamsClient.(*client).config.Monotonicity = &MonotonicityConfig{
+		OnViolation: func(v MonotonicityViolation) { violations <- v },
+	}
+
+	ctx := context.WithValue(context.Background(), LabelsCtxKey, Labels{"stream": "test"})
+	sub, err := extendedStream(streamsClient.Stream(ctx, []feed.ID{feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	for x := 0; x < 2; x++ {
+		if _, err = sub.Read(context.Background()); err != nil {
+			t.Fatalf("Read() error = %s", err)
+		}
+	}
+
+	want := MonotonicityViolation{
+		FeedID:   feed1,
+		Field:    MonotonicValidFromTimestamp,
+		Previous: 2,
+		Current:  1,
+		Labels:   Labels{"stream": "test"},
+	}
+	select {
+	case v := <-violations:
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("OnViolation() = %+v, want %+v", v, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnViolation() not called")
+	}
+	if got := sub.StatsV2().Counter(StatMonotonicityViolations); got != 1 {
+		t.Errorf("StatsV2() monotonicity violations = %d, want 1", got)
+	}
+}
@@ -0,0 +1,74 @@
+package streams
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+// OriginPolicy selects the origin of the single Stream connection when Config.WsHA is false,
+// among the origins advertised by the server in the X-Cll-Available-Origins header.
+// The selected origin is sent in the X-Cll-Origin header, including on reconnections.
+type OriginPolicy int
+
+const (
+	OriginPolicyNone      OriginPolicy = iota // No origin is selected, the server chooses the origin
+	OriginPolicyPreferred                     // The first advertised origin of Config.WsPreferredOrigins
+	OriginPolicyRandom                        // A random advertised origin for each Stream
+	OriginPolicySticky                        // A random advertised origin kept for all the Streams of the client while advertised
+)
+
+func (p OriginPolicy) String() string {
+	switch p {
+	case OriginPolicyNone:
+		return "none"
+	case OriginPolicyPreferred:
+		return "preferred"
+	case OriginPolicyRandom:
+		return "random"
+	case OriginPolicySticky:
+		return "sticky"
+	default:
+		return "unknown"
+	}
+}
+
+// originSelector selects the origin of non HA Streams as specified by Config.WsOriginPolicy.
+type originSelector struct {
+	policy    OriginPolicy
+	preferred []string
+
+	mu     sync.Mutex
+	sticky string
+}
+
+// selectOrigin returns the origin selected among the advertised ones, empty if none is selected.
+func (o *originSelector) selectOrigin(advertised []string) string {
+	if len(advertised) == 0 {
+		return ""
+	}
+
+	switch o.policy {
+	case OriginPolicyPreferred:
+		for _, p := range o.preferred {
+			if slices.Contains(advertised, p) {
+				return p
+			}
+		}
+		return ""
+
+	case OriginPolicyRandom:
+		return advertised[rand.IntN(len(advertised))]
+
+	case OriginPolicySticky:
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		if !slices.Contains(advertised, o.sticky) {
+			o.sticky = advertised[rand.IntN(len(advertised))]
+		}
+		return o.sticky
+
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,115 @@
+package streams
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestOriginSelector(t *testing.T) {
+	advertised := []string{"001", "002", "003"}
+
+	tests := []struct {
+		name       string
+		selector   *originSelector
+		advertised []string
+		want       string
+	}{
+		{name: "none", selector: &originSelector{}, advertised: advertised},
+		{name: "preferred", selector: &originSelector{policy: OriginPolicyPreferred, preferred: []string{"004", "002", "001"}}, advertised: advertised, want: "002"},
+		{name: "preferred not advertised", selector: &originSelector{policy: OriginPolicyPreferred, preferred: []string{"004"}}, advertised: advertised},
+		{name: "not advertised", selector: &originSelector{policy: OriginPolicyRandom}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.selector.selectOrigin(tt.advertised); got != tt.want {
+				t.Errorf("selectOrigin() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	random := &originSelector{policy: OriginPolicyRandom}
+	sticky := &originSelector{policy: OriginPolicySticky}
+	first := sticky.selectOrigin(advertised)
+	for x := 0; x < 20; x++ {
+		if got := random.selectOrigin(advertised); !slices.Contains(advertised, got) {
+			t.Errorf("random selectOrigin() = %q, want an advertised origin", got)
+		}
+		if got := sticky.selectOrigin(advertised); got != first {
+			t.Errorf("sticky selectOrigin() = %q, want %q", got, first)
+		}
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(advertised), func(o string) bool { return o == first })
+	if got := sticky.selectOrigin(remaining); got == first || !slices.Contains(remaining, got) {
+		t.Errorf("sticky selectOrigin() = %q after %q was withdrawn, want one of %v", got, first, remaining)
+	}
+}
+
+func TestClient_StreamOriginPolicy(t *testing.T) {
+	var mu sync.Mutex
+	var origins []string
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Add(cllAvailOriginsHeader, "{001,002}")
+			return
+		}
+
+		mu.Lock()
+		origins = append(origins, r.Header.Get(cllOriginHeader))
+		mu.Unlock()
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		_ = conn.CloseNow()
+	})
+	defer ms.Close()
+
+	tests := []struct {
+		name      string
+		policy    OriginPolicy
+		preferred []string
+		want      string
+	}{
+		{name: "none", policy: OriginPolicyNone, want: ""},
+		{name: "preferred", policy: OriginPolicyPreferred, preferred: []string{"002"}, want: "002"},
+		{name: "preferred not advertised", policy: OriginPolicyPreferred, preferred: []string{"003"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			origins = nil
+			mu.Unlock()
+
+			streamsClient, err := ms.Client()
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+			c := streamsClient.(*client)
+			c.config.WsOriginPolicy = tt.policy
+			c.origins = &originSelector{policy: tt.policy, preferred: tt.preferred}
+
+			sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1})
+			if err != nil {
+				t.Fatalf("error subscribing %s", err)
+			}
+			_ = sub.Close()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(origins) == 0 || origins[0] != tt.want {
+				t.Errorf("%s header = %v, want %q", cllOriginHeader, origins, tt.want)
+			}
+			if stats := sub.Stats(); stats.ConfiguredConnections != 1 {
+				t.Errorf("ConfiguredConnections = %d, want 1", stats.ConfiguredConnections)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package streams
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OriginInfo describes a Streams server origin advertised in the X-Cll-Available-Origins header.
+// Origin metadata is optional and advertised as semicolon separated parameters of the origin,
+// for example: {001;region=us-east-1;priority=1,002;region=eu-west-1;priority=2}
+type OriginInfo struct {
+	Name     string            // Origin name, used as the X-Cll-Origin header value
+	Region   string            // Origin region, empty if not advertised
+	Priority int               // Origin priority, 0 if not advertised
+	Params   map[string]string // Other origin parameters, nil if none
+}
+
+// String encodes the origin as a X-Cll-Available-Origins header list element.
+func (o OriginInfo) String() string {
+	var b strings.Builder
+	b.WriteString(o.Name)
+	if o.Region != "" {
+		b.WriteString(";region=" + o.Region)
+	}
+	if o.Priority != 0 {
+		b.WriteString(";priority=" + strconv.Itoa(o.Priority))
+	}
+
+	keys := make([]string, 0, len(o.Params))
+	for k := range o.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteString(";" + k + "=" + o.Params[k])
+	}
+	return b.String()
+}
+
+// ParseOrigins parses the X-Cll-Available-Origins header value.
+// Whitespace and quotes around origins, parameter names and values are ignored,
+// as are empty origins and malformed parameters.
+func ParseOrigins(v string) (origins []OriginInfo) {
+	v = strings.TrimSpace(v)
+	v = strings.TrimPrefix(v, "{")
+	v = strings.TrimSuffix(v, "}")
+
+	for _, element := range strings.Split(v, ",") {
+		parts := strings.Split(element, ";")
+		o := OriginInfo{Name: unquote(parts[0])}
+		if o.Name == "" {
+			continue
+		}
+
+		for _, p := range parts[1:] {
+			k, v, ok := strings.Cut(p, "=")
+			k, v = strings.ToLower(unquote(k)), unquote(v)
+			if !ok || k == "" {
+				continue
+			}
+
+			switch k {
+			case "region":
+				o.Region = v
+			case "priority":
+				if n, err := strconv.Atoi(v); err == nil {
+					o.Priority = n
+				}
+			default:
+				if o.Params == nil {
+					o.Params = map[string]string{}
+				}
+				o.Params[k] = v
+			}
+		}
+		origins = append(origins, o)
+	}
+	return origins
+}
+
+// FormatOrigins encodes the origins as a X-Cll-Available-Origins header value.
+func FormatOrigins(origins []OriginInfo) string {
+	s := make([]string, len(origins))
+	for x, o := range origins {
+		s[x] = o.String()
+	}
+	return "{" + strings.Join(s, ",") + "}"
+}
+
+func unquote(s string) string {
+	return strings.Trim(strings.TrimSpace(s), `"'`)
+}
+
+func (c *client) GetOrigins(ctx context.Context) (origins []OriginInfo, err error) {
+	h, err := c.serverHeaders(ctx, c.endpoints.ws())
+	if err != nil {
+		return nil, err
+	}
+	return ParseOrigins(h.Get(cllAvailOriginsHeader)), nil
+}
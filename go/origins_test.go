@@ -0,0 +1,80 @@
+package streams
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseOrigins(t *testing.T) {
+	tests := []struct {
+		name string
+		v    string
+		want []OriginInfo
+	}{
+		{
+			name: "names only",
+			v:    "{001,002}",
+			want: []OriginInfo{{Name: "001"}, {Name: "002"}},
+		},
+		{
+			name: "metadata",
+			v:    `{001;region=us-east-1;priority=1, "002" ; Region="eu-west-1"; priority=2; zone=b}`,
+			want: []OriginInfo{
+				{Name: "001", Region: "us-east-1", Priority: 1},
+				{Name: "002", Region: "eu-west-1", Priority: 2, Params: map[string]string{"zone": "b"}},
+			},
+		},
+		{
+			name: "malformed metadata",
+			v:    "001;priority=high;region;=x,,",
+			want: []OriginInfo{{Name: "001"}},
+		},
+		{
+			name: "empty",
+			v:    " {} ",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseOrigins(tt.v)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOrigins() = %v, want %v", got, tt.want)
+			}
+
+			if got := ParseOrigins(FormatOrigins(got)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOrigins(FormatOrigins()) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetOrigins(t *testing.T) {
+	expected := []OriginInfo{
+		{Name: "001", Region: "us-east-1", Priority: 1},
+		{Name: "002", Region: "eu-west-1", Priority: 2},
+	}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD request, got %s", r.Method)
+		}
+		w.Header().Add(cllAvailOriginsHeader, FormatOrigins(expected))
+	})
+	defer ms.Close()
+
+	client, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	origins, err := client.GetOrigins(context.Background())
+	if err != nil {
+		t.Fatalf("GetOrigins() error = %v", err)
+	}
+	if !reflect.DeepEqual(origins, expected) {
+		t.Errorf("GetOrigins() = %v, want %v", origins, expected)
+	}
+}
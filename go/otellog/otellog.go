@@ -0,0 +1,96 @@
+// Package otellog emits OpenTelemetry log events for the accepted Stream reports,
+// connected to a Stream through streams.Config.OnAccept.
+package otellog
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+
+	streams "github.com/smartcontractkit/data-streams-sdk/go"
+)
+
+// EventName is the event name of the OpenTelemetry log records emitted for the accepted reports.
+const EventName = "datastreams.report.accepted"
+
+// Config configures an Emitter.
+type Config struct {
+	// Logger emits the records, e.g. from a global.GetLoggerProvider().Logger("datastreams").
+	Logger log.Logger
+
+	// SampleRate is the fraction of the accepted reports emitted, between 0 and 1, defaults to 1.
+	SampleRate float64
+
+	// Labels are added to each record, usually the Stream labels, see streams.Labels.
+	Labels streams.Labels
+}
+
+// Emitter emits a log event named EventName for a sample of the accepted Stream reports,
+// so that the report flow can be analysed along the application traces.
+// Each record has the feed ID, the report timestamps, the end-to-end latency and the labels as attributes:
+//   - datastreams.feed_id: the feed ID hex string,
+//   - datastreams.observations_timestamp and datastreams.valid_from_timestamp: the report timestamps in seconds,
+//   - datastreams.latency_ms: the delay between the observations timestamp and the acceptance in milliseconds,
+//   - datastreams.label.<key>: each label of the Config.
+//
+// Records are emitted from the Stream dispatcher, so the Logger must not block.
+type Emitter struct {
+	logger     log.Logger
+	sampleRate float64
+	labels     []log.KeyValue
+	now        func() time.Time
+}
+
+// New returns an Emitter, connected to a Stream setting streams.Config.OnAccept to its OnAccept method.
+func New(cfg Config) (*Emitter, error) {
+	if cfg.Logger == nil {
+		return nil, fmt.Errorf("otellog: no Logger")
+	}
+	if cfg.SampleRate < 0 || cfg.SampleRate > 1 {
+		return nil, fmt.Errorf("otellog: SampleRate %g out of range", cfg.SampleRate)
+	}
+
+	e := &Emitter{logger: cfg.Logger, sampleRate: cfg.SampleRate, now: time.Now}
+	if e.sampleRate == 0 {
+		e.sampleRate = 1
+	}
+	for k, v := range cfg.Labels {
+		e.labels = append(e.labels, log.String("datastreams.label."+k, v))
+	}
+	return e, nil
+}
+
+// OnAccept emits the record of the accepted report if sampled and delivers the report.
+// Applications filtering or annotating the reports call it from their own streams.Config.OnAccept.
+func (e *Emitter) OnAccept(r *streams.ReportResponse) (deliver bool) {
+	e.Emit(context.Background(), r)
+	return true
+}
+
+// Emit emits the record of the report if sampled.
+func (e *Emitter) Emit(ctx context.Context, r *streams.ReportResponse) {
+	if e.sampleRate < 1 && rand.Float64() >= e.sampleRate {
+		return
+	}
+	if !e.logger.Enabled(ctx, log.EnabledParameters{Severity: log.SeverityInfo, EventName: EventName}) {
+		return
+	}
+
+	now := e.now()
+	var rec log.Record
+	rec.SetEventName(EventName)
+	rec.SetTimestamp(now)
+	rec.SetObservedTimestamp(now)
+	rec.SetSeverity(log.SeverityInfo)
+	rec.AddAttributes(
+		log.String("datastreams.feed_id", r.FeedID.String()),
+		log.Int64("datastreams.observations_timestamp", int64(r.ObservationsTimestamp)),
+		log.Int64("datastreams.valid_from_timestamp", int64(r.ValidFromTimestamp)),
+		log.Int64("datastreams.latency_ms", now.Sub(time.Unix(int64(r.ObservationsTimestamp), 0)).Milliseconds()),
+	)
+	rec.AddAttributes(e.labels...)
+	e.logger.Emit(ctx, rec)
+}
@@ -0,0 +1,148 @@
+package otellog
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+
+	streams "github.com/smartcontractkit/data-streams-sdk/go"
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+var feed1 = func() (id feed.ID) {
+	if err := id.FromString("0x00020ffa644e6c585a5bec0e25ca476b6666666666e22b6240957720dcba0e14"); err != nil {
+		panic(err)
+	}
+	return id
+}()
+
+// recordingLogger records the emitted records.
+type recordingLogger struct {
+	embedded.Logger
+	disabled bool
+
+	mu      sync.Mutex
+	records []log.Record
+}
+
+func (l *recordingLogger) Emit(_ context.Context, r log.Record) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, r)
+}
+
+func (l *recordingLogger) Enabled(context.Context, log.EnabledParameters) bool {
+	return !l.disabled
+}
+
+func (l *recordingLogger) emitted() []log.Record {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]log.Record{}, l.records...)
+}
+
+func recordAttributes(r log.Record) map[string]string {
+	attrs := map[string]string{}
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+	return attrs
+}
+
+func TestEmitter_OnAccept(t *testing.T) {
+	now := time.Unix(1_700_000_100, 0)
+	r := &streams.ReportResponse{FeedID: feed1, ObservationsTimestamp: 1_700_000_098, ValidFromTimestamp: 1_700_000_097}
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want map[string]string
+	}{
+		{
+			name: "all attributes",
+			cfg:  Config{Labels: streams.Labels{"service": "pricer"}},
+			want: map[string]string{
+				"datastreams.feed_id":                feed1.String(),
+				"datastreams.observations_timestamp": "1700000098",
+				"datastreams.valid_from_timestamp":   "1700000097",
+				"datastreams.latency_ms":             "2000",
+				"datastreams.label.service":          "pricer",
+			},
+		},
+		{
+			name: "no labels",
+			cfg:  Config{SampleRate: 1},
+			want: map[string]string{
+				"datastreams.feed_id":                feed1.String(),
+				"datastreams.observations_timestamp": "1700000098",
+				"datastreams.valid_from_timestamp":   "1700000097",
+				"datastreams.latency_ms":             "2000",
+			},
+		},
+		{
+			name: "disabled logger",
+			cfg:  Config{Logger: &recordingLogger{disabled: true}},
+		},
+		{
+			name: "not sampled",
+			cfg:  Config{SampleRate: 1e-300},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, _ := tt.cfg.Logger.(*recordingLogger)
+			if logger == nil {
+				logger = &recordingLogger{}
+				tt.cfg.Logger = logger
+			}
+
+			e, err := New(tt.cfg)
+			if err != nil {
+				t.Fatalf("New() error = %s", err)
+			}
+			e.now = func() time.Time { return now }
+			if !e.OnAccept(r) {
+				t.Errorf("OnAccept() = false, want true")
+			}
+
+			records := logger.emitted()
+			if tt.want == nil {
+				if len(records) != 0 {
+					t.Fatalf("emitted %d records, want none", len(records))
+				}
+				return
+			}
+			if len(records) != 1 {
+				t.Fatalf("emitted %d records, want 1", len(records))
+			}
+			rec := records[0]
+			if rec.EventName() != EventName || !rec.Timestamp().Equal(now) || rec.Severity() != log.SeverityInfo {
+				t.Errorf("record event %q at %s severity %s, want %q at %s severity INFO",
+					rec.EventName(), rec.Timestamp(), rec.Severity(), EventName, now)
+			}
+			got := recordAttributes(rec)
+			if len(got) != len(tt.want) {
+				t.Errorf("attributes = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("attribute %s = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	for _, cfg := range []Config{{}, {Logger: &recordingLogger{}, SampleRate: 2}} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) error = nil, want error", cfg)
+		}
+	}
+}
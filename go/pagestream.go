@@ -0,0 +1,158 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// IterReportPage returns an iterator over the reports of the page of the given feedID and start timestamp,
+// returning at most limit reports if limit is positive. Reports are yielded as they are decoded from
+// the response body, bounding memory usage for very large pages instead of buffering the whole response.
+// The next page start timestamp is the last report ObservationsTimestamp + 1.
+// Iteration stops when the consumer stops iterating or after yielding the first error, with a nil report.
+// Config.InspectHttpResponse, or the InspectHttpResponseCtxKey context hook, is called with the response
+// once iteration stops, its body is not available.
+func (c *client) IterReportPage(ctx context.Context, id feed.ID, startTS uint64, limit int) iter.Seq2[*ReportResponse, error] {
+	return func(yield func(*ReportResponse, error) bool) {
+		req := &request{
+			method: http.MethodGet,
+			path:   apiV1ReportsPage,
+			params: url.Values{
+				"feedID":         {id.String()},
+				"startTimestamp": {strconv.FormatUint(startTS, 10)},
+			},
+		}
+		if limit > 0 {
+			req.params.Set("limit", strconv.Itoa(limit))
+		}
+
+		rid := requestID(ctx)
+		err := c.iterRest(ctx, rid, req, "reports", yield)
+		if err != nil {
+			yield(nil, &RequestError{RequestID: rid, Err: err})
+		}
+	}
+}
+
+// iterRest performs the rest request and decodes the elements of the array under the given key of
+// the json object response one at a time, calling yield with each of them.
+// Returns a nil error if yield returns false.
+func (c *client) iterRest(ctx context.Context, id string, d *request, key string,
+	yield func(*ReportResponse, error) bool) (err error) {
+	start := time.Now()
+	resp, err := c.send(ctx, id, d)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// the body is read at the consumer pace, only the time to the response headers is checked
+	c.checkSlow(id, d, start)
+
+	if inspect := c.inspectHttpResponse(ctx); inspect != nil {
+		defer func() {
+			resp.Body = http.NoBody
+			inspect(resp)
+		}()
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		buf, _ := c.readBody(resp)
+		return fmt.Errorf("client: http status code: %d, response body %s", resp.StatusCode, string(buf))
+	}
+
+	body, err := c.bodyReader(resp)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	if err = expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	var found bool
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("client: deserializing response error: %w", err)
+		}
+
+		// match keys case insensitively, as json.Unmarshal does
+		if k, _ := t.(string); !strings.EqualFold(k, key) {
+			var skip json.RawMessage
+			if err = dec.Decode(&skip); err != nil {
+				return fmt.Errorf("client: deserializing response error: %w", err)
+			}
+			continue
+		}
+
+		found = true
+		if err = expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			r, err := c.decodeReport(dec)
+			if err != nil {
+				return err
+			}
+			if !yield(r, nil) {
+				return nil
+			}
+		}
+		if err = expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return errors.New("client: response data error: reports page list not found")
+	}
+	return nil
+}
+
+// decodeReport decodes the next report of the decoder, handling its unknown fields
+// as specified by Config.UnknownFields.
+func (c *client) decodeReport(dec *json.Decoder) (r *ReportResponse, err error) {
+	r = &ReportResponse{}
+	if c.config.UnknownFields == UnknownFieldsIgnore {
+		if err = dec.Decode(r); err != nil {
+			return nil, fmt.Errorf("client: deserializing response error: %w", err)
+		}
+		return r, nil
+	}
+
+	var raw json.RawMessage
+	if err = dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("client: deserializing response error: %w", err)
+	}
+	if err = json.Unmarshal(raw, r); err != nil {
+		return nil, fmt.Errorf("client: deserializing response error: %w", err)
+	}
+	return r, c.config.checkUnknownFields(raw, r)
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) (err error) {
+	t, err := dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("client: deserializing response error: %w", err)
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("client: deserializing response error: unexpected token %v, want %s", t, delim)
+	}
+	return nil
+}
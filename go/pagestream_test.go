@@ -0,0 +1,105 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestClient_IterReportPage(t *testing.T) {
+	expected := []*ReportResponse{
+		{FeedID: feed1, FullReport: hexutil.Bytes(`report1 payload`), ObservationsTimestamp: 1234567891},
+		{FeedID: feed1, FullReport: hexutil.Bytes(`report2 payload`), ObservationsTimestamp: 1234567898},
+		{FeedID: feed1, FullReport: hexutil.Bytes(`report3 payload`), ObservationsTimestamp: 1234567899},
+	}
+
+	tests := []struct {
+		name    string
+		body    func(w http.ResponseWriter)
+		stop    int
+		want    []*ReportResponse
+		wantErr bool
+	}{
+		{
+			name: "page",
+			body: func(w http.ResponseWriter) {
+				_ = json.NewEncoder(w).Encode(map[string]any{"extra": map[string]any{"a": []int{1}}, "Reports": expected})
+			},
+			want: expected,
+		},
+		{
+			name: "stop early",
+			body: func(w http.ResponseWriter) { _ = json.NewEncoder(w).Encode(ReportPage{Reports: expected}) },
+			stop: 2,
+			want: expected[:2],
+		},
+		{
+			name:    "reports not found",
+			body:    func(w http.ResponseWriter) { _, _ = w.Write([]byte(`{"other":[]}`)) },
+			wantErr: true,
+		},
+		{
+			name: "truncated",
+			body: func(w http.ResponseWriter) {
+				b, _ := json.Marshal(ReportPage{Reports: expected})
+				_, _ = w.Write(b[:len(b)/2])
+			},
+			want:    expected[:1],
+			wantErr: true,
+		},
+		{
+			name: "status error",
+			body: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`bad request`))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != apiV1ReportsPage || r.URL.Query().Get("feedID") != feed1str ||
+					r.URL.Query().Get("limit") != "10" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				tt.body(w)
+			})
+			defer ms.Close()
+
+			streamsClient, err := ms.Client()
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+
+			var got []*ReportResponse
+			var gotErr error
+			for r, err := range streamsClient.IterReportPage(context.Background(), feed1, 1234567891, 10) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				got = append(got, r)
+				if len(got) == tt.stop {
+					break
+				}
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Fatalf("IterReportPage() error = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			var re *RequestError
+			if gotErr != nil && !errors.As(gotErr, &re) {
+				t.Errorf("IterReportPage() error = %T, want *RequestError", gotErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IterReportPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
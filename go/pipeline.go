@@ -0,0 +1,155 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultPipelineBatchSize = 100
+	defaultPipelineMaxWait   = time.Second
+)
+
+// WatermarkStore persists the per feed observations timestamp of the last reports
+// written by a Pipeline sink.
+type WatermarkStore interface {
+	// Load returns the saved watermarks, empty if nothing was saved.
+	Load(ctx context.Context) (waterMarks map[string]uint64, err error)
+
+	// Save stores the watermarks.
+	Save(ctx context.Context, waterMarks map[string]uint64) error
+}
+
+// FileWatermarkStore implements a WatermarkStore using a json file.
+type FileWatermarkStore struct {
+	Path string
+}
+
+func (f FileWatermarkStore) Load(_ context.Context) (waterMarks map[string]uint64, err error) {
+	_, err = readJSONFile(f.Path, &waterMarks)
+	return waterMarks, err
+}
+
+func (f FileWatermarkStore) Save(_ context.Context, waterMarks map[string]uint64) (err error) {
+	return writeJSONFile(f.Path, waterMarks)
+}
+
+// PipelineConfig specifies the Pipeline batching.
+type PipelineConfig struct {
+	BatchSize int           // Maximum number of reports per batch, defaults to 100
+	MaxWait   time.Duration // Maximum wait for a batch to fill after its first report, defaults to 1s
+}
+
+// Pipeline couples reading Stream report batches, writing them to a sink and persisting
+// the written watermarks so consumers neither lose nor double-write reports on crash.
+//
+// Each batch must be written and then acknowledged before reading the next one, Next returns
+// the batch again until it is acknowledged. Reports at
+// or below the persisted watermarks are never returned again, the sink write must be idempotent
+// per feed report to tolerate a crash between the write and the acknowledgement.
+type Pipeline struct {
+	stream Stream
+	store  WatermarkStore
+	config PipelineConfig
+
+	waterMarks map[string]uint64
+	pending    *Batch
+}
+
+// Batch is a batch of reports read by a Pipeline.
+type Batch struct {
+	Reports []*ReportResponse
+
+	pipeline *Pipeline
+	acked    bool
+}
+
+// NewPipeline creates a Pipeline reading from the Stream and resuming from the stored watermarks.
+func NewPipeline(ctx context.Context, s Stream, store WatermarkStore, cfg PipelineConfig) (p *Pipeline, err error) {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultPipelineBatchSize
+	}
+	if cfg.MaxWait <= 0 {
+		cfg.MaxWait = defaultPipelineMaxWait
+	}
+
+	waterMarks, err := store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client: pipeline watermarks load: %w", err)
+	}
+	if waterMarks == nil {
+		waterMarks = map[string]uint64{}
+	}
+
+	return &Pipeline{stream: s, store: store, config: cfg, waterMarks: waterMarks}, nil
+}
+
+// Next reads the next batch of reports newer than the watermarks.
+// If the previous batch was not acknowledged, e.g. its sink write failed, it is returned
+// again so that it can be retried.
+func (p *Pipeline) Next(ctx context.Context) (b *Batch, err error) {
+	if p.pending != nil && !p.pending.acked {
+		return p.pending, nil
+	}
+
+	b = &Batch{pipeline: p}
+	for len(b.Reports) == 0 {
+		reports, err := ReadBatch(ctx, p.stream, p.config.BatchSize, p.config.MaxWait)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range reports {
+			if r.ObservationsTimestamp > p.waterMarks[r.FeedID.String()] {
+				b.Reports = append(b.Reports, r)
+			}
+		}
+	}
+	p.pending = b
+	return b, nil
+}
+
+// Ack persists the batch reports as written.
+func (b *Batch) Ack(ctx context.Context) (err error) {
+	if b.acked {
+		return nil
+	}
+
+	p := b.pipeline
+	waterMarks := make(map[string]uint64, len(p.waterMarks))
+	for k, v := range p.waterMarks {
+		waterMarks[k] = v
+	}
+	for _, r := range b.Reports {
+		id := r.FeedID.String()
+		waterMarks[id] = max(waterMarks[id], r.ObservationsTimestamp)
+	}
+
+	if err = p.store.Save(ctx, waterMarks); err != nil {
+		return fmt.Errorf("client: pipeline watermarks save: %w", err)
+	}
+	p.waterMarks = waterMarks
+	b.acked = true
+	return nil
+}
+
+// Run reads batches writing them with write and acknowledging them once written
+// until the context is done, the Stream fails or write returns an error.
+// A failed batch is not acknowledged and is written again by the next Run or returned by Next.
+func (p *Pipeline) Run(ctx context.Context, write func(ctx context.Context, reports []*ReportResponse) error) (err error) {
+	for {
+		b, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err = write(ctx, b.Reports); err != nil {
+			return fmt.Errorf("client: pipeline write: %w", err)
+		}
+
+		if err = b.Ack(ctx); err != nil {
+			return err
+		}
+	}
+}
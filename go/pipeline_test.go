@@ -0,0 +1,87 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	reports := []*ReportResponse{
+		{FeedID: feed1, ObservationsTimestamp: 1},
+		{FeedID: feed2, ObservationsTimestamp: 1},
+		{FeedID: feed1, ObservationsTimestamp: 2},
+		{FeedID: feed2, ObservationsTimestamp: 2},
+		{FeedID: feed1, ObservationsTimestamp: 3},
+	}
+	store := FileWatermarkStore{Path: filepath.Join(t.TempDir(), "watermarks.json")}
+	ctx := context.Background()
+
+	// the second batch write fails
+	var written []*ReportResponse
+	failing := errors.New("write error")
+	p, err := NewPipeline(ctx, &mockStream{reports: reports}, store, PipelineConfig{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+	err = p.Run(ctx, func(_ context.Context, batch []*ReportResponse) error {
+		if len(written) > 0 {
+			return failing
+		}
+		written = append(written, batch...)
+		return nil
+	})
+	if !errors.Is(err, failing) {
+		t.Fatalf("Run() error = %v, want %v", err, failing)
+	}
+
+	// the failed batch is returned again for retry
+	retry, err := p.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if !reflect.DeepEqual(retry.Reports, reports[2:4]) {
+		t.Errorf("Next() after a failed write = %v, want %v", retry.Reports, reports[2:4])
+	}
+
+	// restart replaying the stream, only the reports not acknowledged are written
+	p, err = NewPipeline(ctx, &mockStream{reports: reports}, store, PipelineConfig{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("NewPipeline() error = %v", err)
+	}
+
+	b, err := p.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if again, err := p.Next(ctx); err != nil || again != b {
+		t.Errorf("Next() before acknowledging = %v, %v, want the same batch", again, err)
+	}
+	written = append(written, b.Reports...)
+	if err = b.Ack(ctx); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+
+	err = p.Run(ctx, func(_ context.Context, batch []*ReportResponse) error {
+		written = append(written, batch...)
+		return nil
+	})
+	if !errors.Is(err, ErrStreamClosed) {
+		t.Fatalf("Run() error = %v, want %v", err, ErrStreamClosed)
+	}
+
+	if !reflect.DeepEqual(written, reports) {
+		t.Errorf("written = %v, want %v", written, reports)
+	}
+
+	waterMarks, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	expected := map[string]uint64{feed1.String(): 3, feed2.String(): 2}
+	if !reflect.DeepEqual(waterMarks, expected) {
+		t.Errorf("Load() = %v, want %v", waterMarks, expected)
+	}
+}
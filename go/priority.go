@@ -0,0 +1,142 @@
+package streams
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// Priority is the priority of a rest request, set in the context with PriorityCtxKey.
+// When Config.RestConcurrency limits the concurrent rest requests, waiting requests are
+// admitted highest priority first, so interactive requests such as GetLatestReport are not
+// delayed by low priority backfills sharing the client.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1 // Backfills and other background requests
+	PriorityNormal Priority = 0  // Default priority
+	PriorityHigh   Priority = 1  // Interactive, latency sensitive requests
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// priorityFromContext returns the Priority set in the context with PriorityCtxKey, PriorityNormal if not set.
+func priorityFromContext(ctx context.Context) Priority {
+	if value := ctx.Value(PriorityCtxKey); value != nil {
+		if p, ok := value.(Priority); ok && p >= PriorityLow && p <= PriorityHigh {
+			return p
+		}
+	}
+	return PriorityNormal
+}
+
+// withDefaultPriority returns the context with the given priority unless one is already set.
+func withDefaultPriority(ctx context.Context, p Priority) context.Context {
+	if _, ok := ctx.Value(PriorityCtxKey).(Priority); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, PriorityCtxKey, p)
+}
+
+// restLimiter limits the concurrent rest requests, admitting the waiting requests
+// highest priority first and in arrival order within the same priority.
+type restLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	active  int
+	waiting [3][]chan struct{} // waiting requests by priority, lowest first
+}
+
+func newRestLimiter(limit int) *restLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &restLimiter{limit: limit}
+}
+
+// acquire waits for a request slot, the returned function releases it.
+// A nil limiter does not limit requests.
+func (l *restLimiter) acquire(ctx context.Context, p Priority) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	if l.active < l.limit && l.idle(p) {
+		l.active++
+		l.mu.Unlock()
+		return l.release, nil
+	}
+	ready := make(chan struct{})
+	q := &l.waiting[p-PriorityLow]
+	*q = append(*q, ready)
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		return l.release, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for x, w := range *q {
+			if w == ready {
+				*q = append((*q)[:x], (*q)[x+1:]...)
+				return nil, ctx.Err()
+			}
+		}
+		// the slot was handed over concurrently, pass it on
+		l.next()
+		return nil, ctx.Err()
+	}
+}
+
+// idle reports whether no request of at least the given priority is waiting.
+func (l *restLimiter) idle(p Priority) bool {
+	for x := int(p - PriorityLow); x < len(l.waiting); x++ {
+		if len(l.waiting[x]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *restLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.next()
+}
+
+// next hands the slot of a finished request over to the highest priority waiting request.
+func (l *restLimiter) next() {
+	for x := len(l.waiting) - 1; x >= 0; x-- {
+		if q := l.waiting[x]; len(q) > 0 {
+			l.waiting[x] = q[1:]
+			close(q[0])
+			return
+		}
+	}
+	l.active--
+}
+
+// limitedBody releases the request slot when the response body is closed.
+type limitedBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *limitedBody) Close() error {
+	b.once.Do(b.release)
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,162 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitQueued waits until n requests are waiting for a slot of the limiter.
+func waitQueued(t *testing.T, l *restLimiter, n int) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		l.mu.Lock()
+		var queued int
+		for _, q := range l.waiting {
+			queued += len(q)
+		}
+		l.mu.Unlock()
+		if queued == n {
+			return
+		}
+	}
+	t.Fatalf("timeout waiting for %d queued requests", n)
+}
+
+func TestRestLimiter(t *testing.T) {
+	l := newRestLimiter(1)
+	release, err := l.acquire(context.Background(), PriorityNormal)
+	if err != nil {
+		t.Fatalf("acquire() error = %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := l.acquire(ctx, PriorityHigh)
+		canceled <- err
+	}()
+	waitQueued(t, l, 1)
+
+	var mu sync.Mutex
+	var got []Priority
+	var wg sync.WaitGroup
+	for x, p := range []Priority{PriorityLow, PriorityNormal, PriorityLow, PriorityHigh} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := l.acquire(context.Background(), p)
+			if err != nil {
+				t.Errorf("acquire() error = %s", err)
+				return
+			}
+			mu.Lock()
+			got = append(got, p)
+			mu.Unlock()
+			release()
+		}()
+		waitQueued(t, l, x+2)
+	}
+
+	cancel()
+	if err = <-canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("acquire() error = %v, want %v", err, context.Canceled)
+	}
+	waitQueued(t, l, 4)
+
+	release()
+	wg.Wait()
+
+	want := []Priority{PriorityHigh, PriorityNormal, PriorityLow, PriorityLow}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("admitted = %v, want %v", got, want)
+	}
+	if l.active != 0 {
+		t.Errorf("active = %d, want 0", l.active)
+	}
+}
+
+func TestClient_RestPriority(t *testing.T) {
+	unblock := make(chan struct{})
+	var mu sync.Mutex
+	var got []string
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if id == "blocking" {
+			<-unblock
+		}
+		mu.Lock()
+		got = append(got, id)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"feeds":[]}`))
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	c := streamsClient.(*client)
+	c.limiter = newRestLimiter(1)
+
+	var wg sync.WaitGroup
+	get := func(id string, p Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := context.WithValue(context.Background(), RequestIDCtxKey, id)
+			ctx = context.WithValue(ctx, PriorityCtxKey, p)
+			if _, err := c.GetFeeds(ctx); err != nil {
+				t.Errorf("GetFeeds() error = %s", err)
+			}
+		}()
+	}
+
+	get("blocking", PriorityNormal)
+	for active := 0; active == 0; time.Sleep(time.Millisecond) {
+		c.limiter.mu.Lock()
+		active = c.limiter.active
+		c.limiter.mu.Unlock()
+	}
+	get("backfill", PriorityLow)
+	waitQueued(t, c.limiter, 1)
+	get("latest", PriorityHigh)
+	waitQueued(t, c.limiter, 2)
+
+	close(unblock)
+	wg.Wait()
+
+	want := []string{"blocking", "latest", "backfill"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+}
+
+func TestPriorityFromContext(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want Priority
+	}{
+		{name: "not set", ctx: context.Background(), want: PriorityNormal},
+		{name: "high", ctx: context.WithValue(context.Background(), PriorityCtxKey, PriorityHigh), want: PriorityHigh},
+		{name: "invalid", ctx: context.WithValue(context.Background(), PriorityCtxKey, Priority(7)), want: PriorityNormal},
+		{name: "default", ctx: withDefaultPriority(context.Background(), PriorityLow), want: PriorityLow},
+		{
+			name: "default not overriding",
+			ctx:  withDefaultPriority(context.WithValue(context.Background(), PriorityCtxKey, PriorityHigh), PriorityLow),
+			want: PriorityHigh,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := priorityFromContext(tt.ctx); got != tt.want {
+				t.Errorf("priorityFromContext() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
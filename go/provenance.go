@@ -0,0 +1,128 @@
+package streams
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+const (
+	defaultProvenanceWindow     = 5 * time.Second
+	defaultProvenanceMaxReports = 10000
+)
+
+// ProvenanceConfig enables recording the connections delivering each accepted Stream report.
+// The connection delivering a report first and the connections delivering the same report,
+// by feed ID and ObservationsTimestamp, within Window are recorded, to audit the health of
+// each origin and prove the confirmation of reports by multiple origins when in HA mode.
+// The provenance of the last MaxReports accepted reports is returned by ExtendedStream.Provenance.
+type ProvenanceConfig struct {
+	Window     time.Duration // Duration other connections delivering a report are recorded, defaults to 5s
+	MaxReports int           // Number of reports the provenance is kept for, defaults to 10000
+}
+
+// Provenance lists the connections that delivered a Stream report.
+type Provenance struct {
+	FeedID                feed.ID
+	ObservationsTimestamp uint64
+	AcceptedAt            time.Time          // Time the report was accepted
+	Sources               []ProvenanceSource // Connections that delivered the report in order, the first one accepted
+}
+
+// ProvenanceSource is a connection that delivered a Stream report.
+type ProvenanceSource struct {
+	ConnID uint64        // Stable identifier of the connection within the Stream
+	Host   string        // Connection host
+	Origin string        // Connection origin, empty when not in HA mode
+	Delay  time.Duration // Delay after the report was accepted, 0 for the accepted delivery
+}
+
+type provenanceKey struct {
+	feedID                feed.ID
+	observationsTimestamp uint64
+}
+
+// provenanceTracker records the provenance of the most recently accepted reports.
+type provenanceTracker struct {
+	cfg ProvenanceConfig
+
+	mu      sync.Mutex
+	reports map[provenanceKey]*Provenance
+	order   []provenanceKey
+}
+
+func newProvenanceTracker(cfg ProvenanceConfig) *provenanceTracker {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultProvenanceWindow
+	}
+	if cfg.MaxReports <= 0 {
+		cfg.MaxReports = defaultProvenanceMaxReports
+	}
+	return &provenanceTracker{cfg: cfg, reports: map[provenanceKey]*Provenance{}}
+}
+
+// accepted records the connection delivering the accepted report.
+func (p *provenanceTracker) accepted(r *ReportResponse, conn *wsConn, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := provenanceKey{r.FeedID, r.ObservationsTimestamp}
+	p.reports[key] = &Provenance{
+		FeedID:                r.FeedID,
+		ObservationsTimestamp: r.ObservationsTimestamp,
+		AcceptedAt:            now,
+		Sources:               []ProvenanceSource{source(conn, 0)},
+	}
+	p.order = append(p.order, key)
+	if len(p.order) > p.cfg.MaxReports {
+		delete(p.reports, p.order[0])
+		p.order = p.order[1:]
+	}
+}
+
+// duplicate records another connection delivering an accepted report within the window.
+func (p *provenanceTracker) duplicate(r *ReportResponse, conn *wsConn, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pr, ok := p.reports[provenanceKey{r.FeedID, r.ObservationsTimestamp}]
+	if !ok {
+		return
+	}
+	delay := now.Sub(pr.AcceptedAt)
+	if delay > p.cfg.Window {
+		return
+	}
+	for _, s := range pr.Sources {
+		if s.ConnID == conn.id {
+			return
+		}
+	}
+	pr.Sources = append(pr.Sources, source(conn, delay))
+}
+
+// get returns a copy of the provenance of the report.
+func (p *provenanceTracker) get(id feed.ID, observationsTimestamp uint64) (pr Provenance, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	r, ok := p.reports[provenanceKey{id, observationsTimestamp}]
+	if !ok {
+		return pr, false
+	}
+	pr = *r
+	pr.Sources = append([]ProvenanceSource(nil), r.Sources...)
+	return pr, true
+}
+
+func source(conn *wsConn, delay time.Duration) ProvenanceSource {
+	return ProvenanceSource{ConnID: conn.id, Host: conn.host, Origin: conn.origin, Delay: delay}
+}
+
+func (s *stream) Provenance(id feed.ID, observationsTimestamp uint64) (p Provenance, ok bool) {
+	if s.provenance == nil {
+		return p, false
+	}
+	return s.provenance.get(id, observationsTimestamp)
+}
@@ -0,0 +1,127 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestProvenanceTracker(t *testing.T) {
+	p := newProvenanceTracker(ProvenanceConfig{Window: time.Second, MaxReports: 2})
+	conn1 := &wsConn{id: 1, host: "host", origin: "001"}
+	conn2 := &wsConn{id: 2, host: "host", origin: "002"}
+	conn3 := &wsConn{id: 3, host: "host", origin: "003"}
+	now := time.Now()
+
+	r1 := &ReportResponse{FeedID: feed1, ObservationsTimestamp: 1}
+	p.accepted(r1, conn1, now)
+	p.duplicate(r1, conn1, now.Add(time.Millisecond))
+	p.duplicate(r1, conn2, now.Add(10*time.Millisecond))
+	p.duplicate(r1, conn3, now.Add(2*time.Second))
+
+	pr, ok := p.get(feed1, 1)
+	if !ok {
+		t.Fatalf("get() not found")
+	}
+	want := []ProvenanceSource{
+		{ConnID: 1, Host: "host", Origin: "001"},
+		{ConnID: 2, Host: "host", Origin: "002", Delay: 10 * time.Millisecond},
+	}
+	if len(pr.Sources) != len(want) || pr.Sources[0] != want[0] || pr.Sources[1] != want[1] {
+		t.Errorf("get() sources = %+v, want %+v", pr.Sources, want)
+	}
+	if pr.FeedID != feed1 || pr.ObservationsTimestamp != 1 || !pr.AcceptedAt.Equal(now) {
+		t.Errorf("get() = %+v", pr)
+	}
+
+	// returned provenance is a copy
+	pr.Sources[0].ConnID = 5
+	if pr, _ = p.get(feed1, 1); pr.Sources[0].ConnID != 1 {
+		t.Errorf("get() returned a shared copy")
+	}
+
+	p.accepted(&ReportResponse{FeedID: feed1, ObservationsTimestamp: 2}, conn2, now)
+	p.accepted(&ReportResponse{FeedID: feed2, ObservationsTimestamp: 2}, conn2, now)
+	if _, ok = p.get(feed1, 1); ok {
+		t.Errorf("get() found evicted report")
+	}
+	if _, ok = p.get(feed2, 2); !ok {
+		t.Errorf("get() not found")
+	}
+}
+
+func TestClient_StreamProvenance(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Add(cllAvailOriginsHeader, "{001,002}")
+			w.WriteHeader(200)
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		b, err := json.Marshal(&message{&ReportResponse{FeedID: feed1, ObservationsTimestamp: 1}})
+		if err != nil {
+			t.Errorf("failed to serialize message: %s", err)
+		}
+		if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+			t.Errorf("failed to write message: %s", err)
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	cc := streamsClient.(*client)
+	cc.config.WsHA = true
+	cc.config.Provenance = &ProvenanceConfig{}
+
+	sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	if _, err = sub.Read(context.Background()); err != nil {
+		t.Fatalf("Read() error = %s", err)
+	}
+	for deadline := time.Now().Add(time.Second); sub.Stats().Deduplicated == 0; {
+		if time.Now().After(deadline) {
+			t.Fatalf("duplicate report not received")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	pr, ok := sub.Provenance(feed1, 1)
+	if !ok {
+		t.Fatalf("Provenance() not found")
+	}
+	var origins []string
+	for _, s := range pr.Sources {
+		origins = append(origins, s.Origin)
+	}
+	sort.Strings(origins)
+	if len(origins) != 2 || origins[0] != "001" || origins[1] != "002" {
+		t.Errorf("Provenance() origins = %v, want [001 002]", origins)
+	}
+
+	if _, ok = sub.Provenance(feed1, 2); ok {
+		t.Errorf("Provenance() found unknown report")
+	}
+}
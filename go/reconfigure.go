@@ -0,0 +1,169 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	defaultConfigWatchInterval = 10 * time.Second
+	redialPollInterval         = 10 * time.Millisecond
+)
+
+// ConfigUpdate holds the config values applied at runtime by ExtendedClient.Reconfigure,
+// empty values are left unchanged. The credentials are updated together.
+type ConfigUpdate struct {
+	ApiKey    string `json:"apiKey,omitempty"`
+	ApiSecret string `json:"apiSecret,omitempty"`
+	RestURL   string `json:"restURL,omitempty"`
+	WsURL     string `json:"wsURL,omitempty"`
+}
+
+// endpoints holds the rest and websocket urls, updated by Reconfigure.
+type endpoints struct {
+	mu      sync.RWMutex
+	restURL *url.URL
+	wsURL   *url.URL
+}
+
+func newEndpoints(cfg Config) *endpoints {
+	// urls are validated
+	e := &endpoints{}
+	e.restURL, _ = url.Parse(cfg.RestURL)
+	e.wsURL, _ = url.Parse(cfg.WsURL)
+	return e
+}
+
+func (e *endpoints) rest() *url.URL {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.restURL
+}
+
+func (e *endpoints) ws() *url.URL {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.wsURL
+}
+
+func (c *client) Reconfigure(ctx context.Context, u ConfigUpdate) (err error) {
+	if (u.ApiKey == "") != (u.ApiSecret == "") {
+		return fmt.Errorf("client: ApiKey and ApiSecret must be updated together")
+	}
+
+	cfg := c.config
+	cfg.RestURL, cfg.WsURL = c.endpoints.rest().String(), c.endpoints.ws().String()
+	apiKey, apiSecret, err := c.creds.get(ctx)
+	if err != nil {
+		return err
+	}
+	cfg.ApiKey, cfg.ApiSecret = apiKey, apiSecret
+	if u.ApiKey != "" {
+		cfg.ApiKey, cfg.ApiSecret = u.ApiKey, u.ApiSecret
+	}
+	if u.RestURL != "" {
+		cfg.RestURL = u.RestURL
+	}
+	if u.WsURL != "" {
+		cfg.WsURL = u.WsURL
+	}
+	if err = cfg.Validate(); err != nil {
+		return err
+	}
+
+	credsChanged := cfg.ApiKey != apiKey || cfg.ApiSecret != apiSecret
+	wsChanged := cfg.WsURL != c.endpoints.ws().String()
+	next := newEndpoints(cfg)
+	c.endpoints.mu.Lock()
+	c.endpoints.restURL, c.endpoints.wsURL = next.restURL, next.wsURL
+	c.endpoints.mu.Unlock()
+	if credsChanged {
+		c.creds.set(cfg.ApiKey, cfg.ApiSecret)
+	}
+	if !credsChanged && !wsChanged {
+		return nil
+	}
+
+	c.config.logInfo("client: config updated, credentials changed: %t, websocket url changed: %t, redialing streams",
+		credsChanged, wsChanged)
+	var wg sync.WaitGroup
+	for _, s := range c.streams.list() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.redial(ctx)
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// redial reconnects the stream connections one at a time, waiting for each connection to
+// reconnect before the next one, so HA streams keep receiving reports during the redial.
+func (s *stream) redial(ctx context.Context) {
+	ticker := time.NewTicker(redialPollInterval)
+	defer ticker.Stop()
+
+	for _, conn := range s.conns {
+		// reconnecting connections already use the updated config
+		if ConnState(conn.state.Load()) != ConnConnected {
+			continue
+		}
+		old := conn.current()
+		_ = old.CloseNow()
+
+		for conn.current() == old || ConnState(conn.state.Load()) == ConnReconnecting {
+			if ConnState(conn.state.Load()) == ConnClosed {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.streamCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// WatchConfigFile applies the JSON encoded ConfigUpdate of the file, such as a Kubernetes mounted secret,
+// with ExtendedClient.Reconfigure on start and each time its content changes until the context is done.
+// The file is checked every interval, defaults to 10 seconds. Errors reading or applying the file
+// are passed to onError, if set, and the current config is kept.
+func WatchConfigFile(ctx context.Context, c ExtendedClient, path string, interval time.Duration,
+	onError func(error)) error {
+	if interval <= 0 {
+		interval = defaultConfigWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var applied *ConfigUpdate
+	for {
+		var u ConfigUpdate
+		ok, err := readJSONFile(path, &u)
+		switch {
+		case err != nil:
+			err = fmt.Errorf("client: error reading config file %s: %w", path, err)
+		case !ok:
+			err = fmt.Errorf("client: config file %s not found", path)
+		case applied == nil || u != *applied:
+			if err = c.Reconfigure(ctx, u); err == nil {
+				applied = &u
+			}
+		}
+		if err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
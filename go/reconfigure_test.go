@@ -0,0 +1,153 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+// newAuthRecordingServer returns a mock server sending the api key of each rest request and stream dial on keys.
+func newAuthRecordingServer(t *testing.T, name string, keys chan<- string) *mockServer {
+	return newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		keys <- name + ":" + r.Header.Get(authzHeader)
+		if r.URL.Path == apiV1Feeds {
+			_, _ = w.Write([]byte(`{"feeds":[]}`))
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Errorf("error accepting connection: %s", err)
+			return
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+}
+
+func TestClient_Reconfigure(t *testing.T) {
+	keys := make(chan string, 16)
+	ms1 := newAuthRecordingServer(t, "ms1", keys)
+	defer ms1.Close()
+	ms2 := newAuthRecordingServer(t, "ms2", keys)
+	defer ms2.Close()
+
+	streamsClient, err := ms1.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+	if got := <-keys; got != "ms1:apiKey" {
+		t.Fatalf("stream dial = %s, want ms1:apiKey", got)
+	}
+
+	if err = streamsClient.Reconfigure(context.Background(), ConfigUpdate{WsURL: "invalid"}); err == nil {
+		t.Errorf("Reconfigure() with invalid url error = nil, want error")
+	}
+	if err = streamsClient.Reconfigure(context.Background(), ConfigUpdate{ApiKey: "rotated"}); err == nil {
+		t.Errorf("Reconfigure() without ApiSecret error = nil, want error")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = streamsClient.Reconfigure(ctx, ConfigUpdate{ApiKey: "rotated", ApiSecret: "rotatedSecret", WsURL: ms2.server.URL})
+	if err != nil {
+		t.Fatalf("Reconfigure() error = %s", err)
+	}
+	if got := <-keys; got != "ms2:rotated" {
+		t.Errorf("stream redial = %s, want ms2:rotated", got)
+	}
+	if st := sub.Stats(); st.ActiveConnections != 1 || st.FullReconnects != 1 {
+		t.Errorf("Stats() = %s, want 1 active connection after a full reconnect", st)
+	}
+
+	if _, err = streamsClient.GetFeeds(context.Background()); err != nil {
+		t.Fatalf("GetFeeds() error = %s", err)
+	}
+	if got := <-keys; got != "ms1:rotated" {
+		t.Errorf("rest request = %s, want ms1:rotated", got)
+	}
+}
+
+func TestWatchConfigFile(t *testing.T) {
+	keys := make(chan string, 16)
+	ms := newAuthRecordingServer(t, "ms", keys)
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	write := func(u ConfigUpdate) {
+		b, err := json.Marshal(u)
+		if err != nil {
+			t.Fatalf("failed to encode config: %s", err)
+		}
+		if err = os.WriteFile(path, b, 0o600); err != nil {
+			t.Fatalf("failed to write config: %s", err)
+		}
+	}
+	// waitKey sends rest requests until the server receives the api key
+	waitKey := func(want string) {
+		t.Helper()
+		for deadline := time.Now().Add(2 * time.Second); ; {
+			if _, err := streamsClient.GetFeeds(context.Background()); err != nil {
+				t.Fatalf("GetFeeds() error = %s", err)
+			}
+			if got := <-keys; got == "ms:"+want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("api key %s not applied", want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	errs := make(chan error, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- WatchConfigFile(ctx, streamsClient, path, 10*time.Millisecond, func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		})
+	}()
+
+	if err = <-errs; err == nil {
+		t.Errorf("WatchConfigFile() missing file error = nil, want error")
+	}
+
+	write(ConfigUpdate{ApiKey: "key1", ApiSecret: "secret1"})
+	waitKey("key1")
+
+	write(ConfigUpdate{ApiKey: "key2", ApiSecret: "secret2"})
+	waitKey("key2")
+
+	cancel()
+	if err = <-done; err != context.Canceled {
+		t.Errorf("WatchConfigFile() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,68 @@
+package streams
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+)
+
+const maxRedirects = 10
+
+// RedirectPolicy specifies how HTTP redirects are handled.
+// Redirects change the signed request path which invalidates the request signature.
+type RedirectPolicy int
+
+const (
+	// RedirectFollow follows redirects keeping the original signature, the default.
+	RedirectFollow RedirectPolicy = iota
+	// RedirectDisable returns an error on redirects.
+	RedirectDisable
+	// RedirectResign follows redirects to the hosts in Config.RedirectHosts,
+	// signing the request for the redirected path.
+	RedirectResign
+)
+
+// checkRedirect implements the http.Client CheckRedirect for the configured RedirectPolicy.
+func (c *client) checkRedirect(req *http.Request, via []*http.Request) (err error) {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("client: stopped after %d redirects", maxRedirects)
+	}
+
+	switch c.config.Redirects {
+	case RedirectDisable:
+		return fmt.Errorf("client: redirect to %s not followed", req.URL.Redacted())
+
+	case RedirectResign:
+		if !slices.Contains(c.config.RedirectHosts, req.URL.Host) {
+			return fmt.Errorf("client: redirect to host %s not allowed", req.URL.Host)
+		}
+
+		var body []byte
+		if req.GetBody != nil {
+			rc, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			if body, err = io.ReadAll(rc); err != nil {
+				return err
+			}
+		}
+
+		apiKey, apiSecret, err := c.creds.get(req.Context())
+		if err != nil {
+			return err
+		}
+		for _, h := range []string{authzHeader, authzTSHeader, authzSigHeader} {
+			req.Header.Del(h)
+		}
+		generateAuthHeaders(c.config.authScheme(), req.Header, req.Method, req.URL.RequestURI(), body,
+			apiKey, apiSecret, c.clock.now().UnixMilli())
+		c.config.logDebug("client: request re-signed for redirect to %s", req.URL.Redacted())
+		return nil
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+func TestClient_Redirects(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == apiV1Feeds {
+			http.Redirect(w, r, "/moved/feeds", http.StatusFound)
+			return
+		}
+
+		ts, _ := strconv.ParseInt(r.Header.Get(authzTSHeader), 10, 64)
+		if sig := generateHMAC(r.Method, r.URL.RequestURI(), nil, "apiKey", ts, "apiSecret"); r.Header.Get(authzSigHeader) != sig {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(&feedsResponse{Feeds: []*feed.Feed{}}); err != nil {
+			t.Errorf("failed to encode response: %s", err)
+		}
+	})
+	defer ms.Close()
+
+	u, _ := url.Parse(ms.server.URL)
+	tests := []struct {
+		name    string
+		policy  RedirectPolicy
+		hosts   []string
+		wantErr bool
+	}{
+		{name: "follow keeps the invalid signature", policy: RedirectFollow, wantErr: true},
+		{name: "disable", policy: RedirectDisable, wantErr: true},
+		{name: "resign disallowed host", policy: RedirectResign, hosts: []string{"other.host"}, wantErr: true},
+		{name: "resign", policy: RedirectResign, hosts: []string{u.Host}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New(Config{
+				RestURL:       ms.server.URL,
+				ApiKey:        "apiKey",
+				ApiSecret:     "apiSecret",
+				Redirects:     tt.policy,
+				RedirectHosts: tt.hosts,
+			})
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+
+			if _, err = client.GetFeeds(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("GetFeeds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
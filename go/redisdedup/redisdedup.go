@@ -0,0 +1,34 @@
+// Package redisdedup implements a streams.SharedDedupStore backed by Redis.
+package redisdedup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultTTL = time.Hour
+
+// Store implements a streams.SharedDedupStore using Redis SET NX.
+// Claimed reports keys expire after TTL.
+type Store struct {
+	Client    redis.Cmdable // Redis client, such as a *redis.Client or *redis.ClusterClient
+	KeyPrefix string        // Claimed reports keys prefix
+	TTL       time.Duration // Claimed reports keys expiration, defaults to 1 hour
+}
+
+// Claim claims the delivery of the feed report, returning true only for the first consumer claiming it.
+func (s *Store) Claim(ctx context.Context, feedID string, observationsTimestamp uint64) (claimed bool, err error) {
+	ttl := s.TTL
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+
+	key := fmt.Sprintf("%s%s:%d", s.KeyPrefix, feedID, observationsTimestamp)
+	if claimed, err = s.Client.SetNX(ctx, key, 1, ttl).Result(); err != nil {
+		return false, fmt.Errorf("redisdedup: claim %s: %w", key, err)
+	}
+	return claimed, nil
+}
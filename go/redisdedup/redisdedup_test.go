@@ -0,0 +1,46 @@
+package redisdedup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	streams "github.com/smartcontractkit/data-streams-sdk/go"
+)
+
+var _ streams.SharedDedupStore = (*Store)(nil)
+
+func TestStore_Claim(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	ctx := context.Background()
+
+	store := &Store{Client: rdb, KeyPrefix: "test:", TTL: time.Minute}
+	for x, want := range []bool{true, false} {
+		claimed, err := store.Claim(ctx, "0x0003", 100)
+		if err != nil {
+			t.Fatalf("Claim() error = %s", err)
+		}
+		if claimed != want {
+			t.Errorf("Claim() #%d = %t, want %t", x, claimed, want)
+		}
+	}
+	if ttl := mr.TTL("test:0x0003:100"); ttl != time.Minute {
+		t.Errorf("claimed key TTL = %s, want %s", ttl, time.Minute)
+	}
+
+	// claimed again once expired
+	mr.FastForward(time.Minute)
+	if claimed, err := store.Claim(ctx, "0x0003", 100); err != nil || !claimed {
+		t.Errorf("Claim() after expiry = %t, %v, want true", claimed, err)
+	}
+
+	mr.Close()
+	if _, err := store.Claim(ctx, "0x0003", 101); err == nil {
+		t.Errorf("expected error claiming with the server down")
+	}
+}
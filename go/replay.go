@@ -0,0 +1,75 @@
+package streams
+
+import (
+	"context"
+	"iter"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// replayCursor pages the reports of a feed.
+type replayCursor struct {
+	id     feed.ID
+	nextTS uint64
+	buf    []*ReportResponse
+	done   bool
+}
+
+// fill fetches the next page of reports observed before end if the buffer is empty.
+func (rc *replayCursor) fill(ctx context.Context, c Client, end uint64) (err error) {
+	for len(rc.buf) == 0 && !rc.done {
+		page, err := c.GetReportPage(ctx, rc.id, rc.nextTS)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range page.Reports {
+			if r.ObservationsTimestamp >= rc.nextTS && r.ObservationsTimestamp < end {
+				rc.buf = append(rc.buf, r)
+			}
+		}
+
+		if len(page.Reports) == 0 || page.NextPageTS <= rc.nextTS || page.NextPageTS >= end {
+			rc.done = true
+		}
+		rc.nextTS = page.NextPageTS
+	}
+	return nil
+}
+
+// ReplayReports returns an iterator over the reports of the given feeds observed in the [start, end)
+// range ordered by ObservationsTimestamp across feeds, reports observed at the same time are
+// ordered as their feeds. Feeds are paginated lazily as the iteration progresses, only
+// the current page of each feed is held in memory.
+// Iteration stops when the consumer stops iterating or after yielding the first error, with a nil report.
+func ReplayReports(ctx context.Context, c Client, ids []feed.ID, start, end uint64) iter.Seq2[*ReportResponse, error] {
+	return func(yield func(*ReportResponse, error) bool) {
+		cursors := make([]*replayCursor, len(ids))
+		for x, id := range ids {
+			cursors[x] = &replayCursor{id: id, nextTS: start, done: start >= end}
+		}
+
+		for {
+			var next *replayCursor
+			for _, rc := range cursors {
+				if err := rc.fill(ctx, c, end); err != nil {
+					yield(nil, err)
+					return
+				}
+				if len(rc.buf) > 0 && (next == nil || rc.buf[0].ObservationsTimestamp < next.buf[0].ObservationsTimestamp) {
+					next = rc
+				}
+			}
+
+			if next == nil {
+				return
+			}
+
+			r := next.buf[0]
+			next.buf = next.buf[1:]
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+}
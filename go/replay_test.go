@@ -0,0 +1,71 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+func TestReplayReports(t *testing.T) {
+	// feed1 reports every 2 seconds and feed2 every 3 seconds from 100 to 120
+	serverReports := map[string][]*ReportResponse{}
+	for ts := uint64(100); ts < 120; ts++ {
+		if ts%2 == 0 {
+			serverReports[feed1.String()] = append(serverReports[feed1.String()], &ReportResponse{FeedID: feed1, ObservationsTimestamp: ts})
+		}
+		if ts%3 == 0 {
+			serverReports[feed2.String()] = append(serverReports[feed2.String()], &ReportResponse{FeedID: feed2, ObservationsTimestamp: ts})
+		}
+	}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		startTS, err := strconv.ParseUint(r.URL.Query().Get("startTimestamp"), 10, 64)
+		if err != nil {
+			t.Errorf("error parsing startTimestamp: %s", err)
+		}
+
+		page := &ReportPage{Reports: []*ReportResponse{}}
+		for _, rep := range serverReports[r.URL.Query().Get("feedID")] {
+			if rep.ObservationsTimestamp >= startTS && len(page.Reports) < 3 {
+				page.Reports = append(page.Reports, rep)
+			}
+		}
+
+		if err = json.NewEncoder(w).Encode(page); err != nil {
+			t.Errorf("failed to encode response: %s", err)
+		}
+	})
+	defer ms.Close()
+
+	client, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	var got []*ReportResponse
+	for r, err := range ReplayReports(context.Background(), client, []feed.ID{feed1, feed2}, 102, 113) {
+		if err != nil {
+			t.Fatalf("ReplayReports() error = %v", err)
+		}
+		got = append(got, r)
+	}
+
+	var expected []*ReportResponse
+	for ts := uint64(102); ts < 113; ts++ {
+		for _, id := range []feed.ID{feed1, feed2} {
+			for _, r := range serverReports[id.String()] {
+				if r.ObservationsTimestamp == ts {
+					expected = append(expected, r)
+				}
+			}
+		}
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ReplayReports() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi"
+)
+
+// abiParameter is a parameter of the standard ABI JSON format.
+type abiParameter struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// SchemaJSON returns the report data schema of the feed version in the standard ABI JSON format,
+// as the list of parameters of an ABI function inputs, so external tooling such as TypeScript decoders
+// and explorers can be generated from the schemas of this package, e.g. decoding a report blob
+// with ethers AbiCoder.decode.
+func SchemaJSON(v feed.FeedVersion) ([]byte, error) {
+	args, ok := schemaOf(v)
+	if !ok {
+		return nil, fmt.Errorf("report: unsupported feed version %d, latest supported v%d", v, LatestVersion)
+	}
+	return schemaJSON(args)
+}
+
+// EnvelopeSchemaJSON returns the full report schema, the envelope of the report data of every version,
+// in the standard ABI JSON format, see SchemaJSON.
+func EnvelopeSchemaJSON() ([]byte, error) {
+	return schemaJSON(schema)
+}
+
+// ParseSchemaJSON parses a report data schema in the standard ABI JSON format returned by SchemaJSON,
+// e.g. to register the schema of a feed version with RegisterSchema.
+func ParseSchemaJSON(b []byte) (abi.Arguments, error) {
+	var params []abiParameter
+	if err := json.Unmarshal(b, &params); err != nil {
+		return nil, fmt.Errorf("report: invalid schema: %w", err)
+	}
+	args := make(abi.Arguments, len(params))
+	for x, p := range params {
+		t, err := abi.NewType(p.Type, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("report: invalid schema field %s: %w", p.Name, err)
+		}
+		args[x] = abi.Argument{Name: p.Name, Type: t}
+	}
+	return args, nil
+}
+
+func schemaJSON(args abi.Arguments) ([]byte, error) {
+	params := make([]abiParameter, len(args))
+	for x, arg := range args {
+		params[x] = abiParameter{Name: arg.Name, Type: arg.Type.String()}
+	}
+	return json.Marshal(params)
+}
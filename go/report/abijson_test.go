@@ -0,0 +1,56 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+func TestSchemaJSON(t *testing.T) {
+	b, err := os.ReadFile("schemas.json")
+	if err != nil {
+		t.Fatalf("failed to read schemas: %s", err)
+	}
+	var schemas struct {
+		Versions []struct {
+			Version feed.FeedVersion `json:"version"`
+			Fields  []abiParameter   `json:"fields"`
+		} `json:"versions"`
+	}
+	if err = json.Unmarshal(b, &schemas); err != nil {
+		t.Fatalf("failed to decode schemas: %s", err)
+	}
+
+	for _, v := range schemas.Versions {
+		b, err := SchemaJSON(v.Version)
+		if err != nil {
+			t.Fatalf("SchemaJSON(%d) error = %s", v.Version, err)
+		}
+		var got []abiParameter
+		if err = json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("SchemaJSON(%d) = %s, invalid JSON: %s", v.Version, b, err)
+		}
+		if !reflect.DeepEqual(got, v.Fields) {
+			t.Errorf("SchemaJSON(%d) = %+v, want %+v", v.Version, got, v.Fields)
+		}
+	}
+
+	if _, err = SchemaJSON(LatestVersion + 1); err == nil {
+		t.Errorf("SchemaJSON(%d) error = nil, want unsupported version", LatestVersion+1)
+	}
+}
+
+func TestEnvelopeSchemaJSON(t *testing.T) {
+	b, err := EnvelopeSchemaJSON()
+	if err != nil {
+		t.Fatalf("EnvelopeSchemaJSON() error = %s", err)
+	}
+	want := `[{"name":"reportContext","type":"bytes32[3]"},{"name":"reportBlob","type":"bytes"},` +
+		`{"name":"rawRs","type":"bytes32[]"},{"name":"rawSs","type":"bytes32[]"},{"name":"rawVs","type":"bytes32"}]`
+	if string(b) != want {
+		t.Errorf("EnvelopeSchemaJSON() = %s, want %s", b, want)
+	}
+}
@@ -0,0 +1,76 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// AnyReport is a report of any supported data version as returned by DecodeAny,
+// implemented by *Report[T] for each Data type. The concrete type can be recovered
+// with a type switch on the report, e.g. *Report[v3.Data], or on its AnyData.
+type AnyReport interface {
+	// Version returns the feed version of the report data.
+	Version() feed.FeedVersion
+	// FeedID returns the feed ID of the report data.
+	FeedID() feed.ID
+	// AnyData returns the report data, a vN.Data value.
+	AnyData() any
+}
+
+// DecodeAny decodes the report serialized bytes and its data with the data type
+// of the feed version encoded in the report data feed ID, for consumers of feeds
+// of mixed versions. Reports of the versions registered with RegisterSchema are decoded as a *CustomReport.
+func DecodeAny(fullReport []byte) (r AnyReport, err error) {
+	values, err := schema.Unpack(fullReport)
+	if err != nil {
+		return nil, fmt.Errorf("report: failed to unpack: %s", err)
+	}
+
+	// every data version starts with the feed ID
+	blob, _ := values[1].([]byte)
+	var id feed.ID
+	if len(blob) < len(id) {
+		return nil, fmt.Errorf("report: data too short for a feed ID: %d bytes", len(blob))
+	}
+	copy(id[:], blob)
+
+	v := id.Version()
+	if v >= feed.FeedVersion1 && v <= LatestVersion {
+		return decodeVersion(v, fullReport)
+	}
+	if s, ok := registered(v); ok {
+		r, err := s.decode(v, fullReport)
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("report: unsupported feed version %d of feed %s, latest supported v%d",
+		v, id.String(), LatestVersion)
+}
+
+// decodeAny decodes the full report as an AnyReport.
+func decodeAny[T Data](fullReport []byte) (AnyReport, error) {
+	r, err := Decode[T](fullReport)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Version returns the feed version of the report data.
+func (r *Report[T]) Version() feed.FeedVersion {
+	return VersionOf[T]()
+}
+
+// FeedID returns the feed ID of the report data.
+func (r *Report[T]) FeedID() (id feed.ID) {
+	copy(id[:], r.ReportBlob)
+	return id
+}
+
+// AnyData returns the report data.
+func (r *Report[T]) AnyData() any {
+	return r.Data
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
+	v2 "github.com/smartcontractkit/data-streams-sdk/go/report/v2"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+func TestDecodeAny(t *testing.T) {
+	tests := []struct {
+		name    string
+		report  AnyReport
+		version feed.FeedVersion
+		feedID  feed.ID
+	}{
+		{name: "v1", report: v1Report, version: feed.FeedVersion1, feedID: v1Data.FeedID},
+		{name: "v2", report: v2Report, version: feed.FeedVersion2, feedID: v2Data.FeedID},
+		{name: "v3", report: v3Report, version: feed.FeedVersion3, feedID: v3Data.FeedID},
+		{name: "v4", report: v4Report, version: feed.FeedVersion4, feedID: v4Data.FeedID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := packAny(tt.report)
+			if err != nil {
+				t.Fatalf("failed to encode report: %s", err)
+			}
+
+			r, err := DecodeAny(b)
+			if err != nil {
+				t.Fatalf("DecodeAny() error = %s", err)
+			}
+			if !reflect.DeepEqual(r, tt.report) {
+				t.Errorf("DecodeAny() = %#v, want %#v", r, tt.report)
+			}
+			if r.Version() != tt.version {
+				t.Errorf("Version() = %d, want %d", r.Version(), tt.version)
+			}
+			if r.FeedID() != tt.feedID {
+				t.Errorf("FeedID() = %x, want %x", r.FeedID(), tt.feedID)
+			}
+			if !reflect.DeepEqual(r.AnyData(), tt.report.AnyData()) {
+				t.Errorf("AnyData() = %#v, want %#v", r.AnyData(), tt.report.AnyData())
+			}
+		})
+	}
+}
+
+func TestDecodeAny_Errors(t *testing.T) {
+	unsupported := v3Data
+	unsupported.FeedID[1] = 0xff
+	r := *v3Report
+	r.ReportBlob = mustPackData(unsupported)
+
+	tests := []struct {
+		name string
+		blob []byte
+	}{
+		{name: "unsupported version", blob: r.ReportBlob},
+		{name: "short data", blob: []byte{0, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := schema.Pack(r.ReportContext, tt.blob, r.RawRs, r.RawSs, r.RawVs)
+			if err != nil {
+				t.Fatalf("failed to encode report: %s", err)
+			}
+			if _, err = DecodeAny(b); err == nil {
+				t.Errorf("DecodeAny() error = nil, want error")
+			}
+		})
+	}
+
+	if _, err := DecodeAny([]byte{1, 2, 3}); err == nil {
+		t.Errorf("DecodeAny() error = nil, want error")
+	}
+}
+
+func packAny(r AnyReport) ([]byte, error) {
+	switch r := r.(type) {
+	case *Report[v1.Data]:
+		return schema.Pack(r.ReportContext, r.ReportBlob, r.RawRs, r.RawSs, r.RawVs)
+	case *Report[v2.Data]:
+		return schema.Pack(r.ReportContext, r.ReportBlob, r.RawRs, r.RawSs, r.RawVs)
+	case *Report[v3.Data]:
+		return schema.Pack(r.ReportContext, r.ReportBlob, r.RawRs, r.RawSs, r.RawVs)
+	case *Report[v4.Data]:
+		return schema.Pack(r.ReportContext, r.ReportBlob, r.RawRs, r.RawSs, r.RawVs)
+	default:
+		return nil, fmt.Errorf("invalid report type %T", r)
+	}
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchResult is the decoding result of a full report of a DecodeBatch batch.
+type BatchResult[T Data] struct {
+	Report *Report[T]
+	Err    error
+}
+
+// DecodeBatch decodes the full reports concurrently with at most workers goroutines, GOMAXPROCS if workers
+// is not positive, for backfills of historical reports. The results are in the full reports order.
+// Full reports not decoded before the context is done have the context error as result error,
+// the context error is returned.
+func DecodeBatch[T Data](ctx context.Context, fullReports [][]byte, workers int) (results []BatchResult[T], err error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(fullReports))
+
+	results = make([]BatchResult[T], len(fullReports))
+	d := NewDecoder[T]()
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for x := int(next.Add(1) - 1); x < len(fullReports); x = int(next.Add(1) - 1) {
+				if err := ctx.Err(); err != nil {
+					results[x].Err = err
+					continue
+				}
+				results[x].Report, results[x].Err = d.Decode(fullReports[x])
+			}
+		}()
+	}
+	wg.Wait()
+	return results, ctx.Err()
+}
@@ -0,0 +1,63 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+func TestDecodeBatch(t *testing.T) {
+	b, err := Encode(v4Report)
+	if err != nil {
+		t.Fatalf("failed to encode report: %s", err)
+	}
+	want, err := Decode[v4.Data](b)
+	if err != nil {
+		t.Fatalf("failed to decode report: %s", err)
+	}
+
+	fullReports := make([][]byte, 100)
+	for x := range fullReports {
+		fullReports[x] = b
+	}
+	fullReports[42] = []byte("invalid")
+
+	for _, workers := range []int{0, 1, 8, 1000} {
+		results, err := DecodeBatch[v4.Data](context.Background(), fullReports, workers)
+		if err != nil {
+			t.Fatalf("workers %d: DecodeBatch() error = %s", workers, err)
+		}
+		if len(results) != len(fullReports) {
+			t.Fatalf("workers %d: DecodeBatch() results = %d, want %d", workers, len(results), len(fullReports))
+		}
+		for x, r := range results {
+			if x == 42 {
+				if r.Err == nil || r.Report != nil {
+					t.Errorf("workers %d: result %d = %#v, want error", workers, x, r)
+				}
+				continue
+			}
+			if r.Err != nil || !reflect.DeepEqual(r.Report, want) {
+				t.Errorf("workers %d: result %d = %#v, want %#v", workers, x, r, want)
+			}
+		}
+	}
+}
+
+func TestDecodeBatch_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := DecodeBatch[v4.Data](ctx, [][]byte{{1}, {2}}, 2)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DecodeBatch() error = %v, want %s", err, context.Canceled)
+	}
+	for x, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("result %d error = %v, want %s", x, r.Err, context.Canceled)
+		}
+	}
+}
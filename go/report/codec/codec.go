@@ -0,0 +1,43 @@
+// Package codec encodes the report data of the report version packages as JSON and CBOR, the reports as JSON,
+// and converts the reports and their data to and from the protobuf messages of datastreams.proto generated
+// in the datastreamspb package. It is kept apart from the report packages, which only depend on the ABI layer,
+// so that only its importers depend on the protobuf runtime.
+//
+// The data codecs are generated with the report version packages from the report schemas, see the report package.
+package codec
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+)
+
+// MarshalJSON encodes the data as a JSON object keyed by the schema field names,
+// with bytes hex encoded and big integers encoded as decimal strings.
+func MarshalJSON[T report.Data](d T) ([]byte, error) {
+	return marshalJSON(&d)
+}
+
+// UnmarshalJSON decodes the data encoded by MarshalJSON.
+func UnmarshalJSON[T report.Data](b []byte) (d *T, err error) {
+	d = new(T)
+	if err = unmarshalJSON(b, d); err != nil {
+		return nil, fmt.Errorf("codec: invalid data JSON: %w", err)
+	}
+	return d, nil
+}
+
+// MarshalCBOR encodes the data as a CBOR map keyed by the field numbers of its VnData protobuf message,
+// with big integers encoded as integers or bignums.
+func MarshalCBOR[T report.Data](d T) ([]byte, error) {
+	return marshalCBOR(&d)
+}
+
+// UnmarshalCBOR decodes the data encoded by MarshalCBOR, unknown fields are ignored.
+func UnmarshalCBOR[T report.Data](b []byte) (d *T, err error) {
+	d = new(T)
+	if err = unmarshalCBOR(b, d); err != nil {
+		return nil, fmt.Errorf("codec: invalid data CBOR: %w", err)
+	}
+	return d, nil
+}
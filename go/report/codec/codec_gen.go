@@ -0,0 +1,632 @@
+// Code generated by go run ./internal/gen; DO NOT EDIT.
+
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/datastreamspb"
+	"github.com/smartcontractkit/data-streams-sdk/go/internal/cborenc"
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/common"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/jsonenc"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/protoenc"
+	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
+	v2 "github.com/smartcontractkit/data-streams-sdk/go/report/v2"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+// v1JSON is the JSON encoding of v1.Data
+type v1JSON struct {
+	FeedID                jsonenc.Bytes   `json:"feedId"`
+	ObservationsTimestamp uint32          `json:"observationsTimestamp"`
+	BenchmarkPrice        *jsonenc.BigInt `json:"benchmarkPrice"`
+	Bid                   *jsonenc.BigInt `json:"bid"`
+	Ask                   *jsonenc.BigInt `json:"ask"`
+	CurrentBlockNum       uint64          `json:"currentBlockNum"`
+	CurrentBlockHash      jsonenc.Bytes   `json:"currentBlockHash"`
+	ValidFromBlockNum     uint64          `json:"validFromBlockNum"`
+	CurrentBlockTimestamp uint64          `json:"currentBlockTimestamp"`
+}
+
+// marshalV1JSON encodes the data as a JSON object keyed by the schema field names
+func marshalV1JSON(d *v1.Data) ([]byte, error) {
+	return json.Marshal(v1JSON{
+		FeedID:                d.FeedID[:],
+		ObservationsTimestamp: d.ObservationsTimestamp,
+		BenchmarkPrice:        (*jsonenc.BigInt)(d.BenchmarkPrice),
+		Bid:                   (*jsonenc.BigInt)(d.Bid),
+		Ask:                   (*jsonenc.BigInt)(d.Ask),
+		CurrentBlockNum:       d.CurrentBlockNum,
+		CurrentBlockHash:      d.CurrentBlockHash[:],
+		ValidFromBlockNum:     d.ValidFromBlockNum,
+		CurrentBlockTimestamp: d.CurrentBlockTimestamp,
+	})
+}
+
+// unmarshalV1JSON decodes the data encoded by marshalV1JSON
+func unmarshalV1JSON(b []byte, d *v1.Data) error {
+	var j v1JSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if err := jsonenc.CopyFixed(d.FeedID[:], j.FeedID); err != nil {
+		return fmt.Errorf("invalid feedId: %w", err)
+	}
+	d.ObservationsTimestamp = j.ObservationsTimestamp
+	d.BenchmarkPrice = (*big.Int)(j.BenchmarkPrice)
+	d.Bid = (*big.Int)(j.Bid)
+	d.Ask = (*big.Int)(j.Ask)
+	d.CurrentBlockNum = j.CurrentBlockNum
+	if err := jsonenc.CopyFixed(d.CurrentBlockHash[:], j.CurrentBlockHash); err != nil {
+		return fmt.Errorf("invalid currentBlockHash: %w", err)
+	}
+	d.ValidFromBlockNum = j.ValidFromBlockNum
+	d.CurrentBlockTimestamp = j.CurrentBlockTimestamp
+	return nil
+}
+
+// V1ToProto converts the data to its V1Data protobuf message of datastreams.proto
+func V1ToProto(d v1.Data) *datastreamspb.V1Data {
+	return &datastreamspb.V1Data{
+		FeedId:                protoenc.Fixed(d.FeedID[:]),
+		ObservationsTimestamp: d.ObservationsTimestamp,
+		BenchmarkPrice:        protoenc.BigInt(d.BenchmarkPrice),
+		Bid:                   protoenc.BigInt(d.Bid),
+		Ask:                   protoenc.BigInt(d.Ask),
+		CurrentBlockNum:       d.CurrentBlockNum,
+		CurrentBlockHash:      protoenc.Fixed(d.CurrentBlockHash[:]),
+		ValidFromBlockNum:     d.ValidFromBlockNum,
+		CurrentBlockTimestamp: d.CurrentBlockTimestamp,
+	}
+}
+
+// V1FromProto converts the V1Data protobuf message of datastreams.proto to the data,
+// the reverse of V1ToProto
+func V1FromProto(m *datastreamspb.V1Data) (d *v1.Data, err error) {
+	d = new(v1.Data)
+	if err = protoenc.CopyFixed(d.FeedID[:], m.GetFeedId()); err != nil {
+		return nil, fmt.Errorf("invalid feed_id: %w", err)
+	}
+	d.ObservationsTimestamp = m.GetObservationsTimestamp()
+	if d.BenchmarkPrice, err = protoenc.ParseBigInt(m.GetBenchmarkPrice()); err != nil {
+		return nil, fmt.Errorf("invalid benchmark_price: %w", err)
+	}
+	if d.Bid, err = protoenc.ParseBigInt(m.GetBid()); err != nil {
+		return nil, fmt.Errorf("invalid bid: %w", err)
+	}
+	if d.Ask, err = protoenc.ParseBigInt(m.GetAsk()); err != nil {
+		return nil, fmt.Errorf("invalid ask: %w", err)
+	}
+	d.CurrentBlockNum = m.GetCurrentBlockNum()
+	if err = protoenc.CopyFixed(d.CurrentBlockHash[:], m.GetCurrentBlockHash()); err != nil {
+		return nil, fmt.Errorf("invalid current_block_hash: %w", err)
+	}
+	d.ValidFromBlockNum = m.GetValidFromBlockNum()
+	d.CurrentBlockTimestamp = m.GetCurrentBlockTimestamp()
+	return d, nil
+}
+
+// marshalV1CBOR encodes the data as a CBOR map keyed by the field numbers of its V1Data protobuf message
+func marshalV1CBOR(d *v1.Data) []byte {
+	b := cborenc.AppendMap(nil, 9)
+	b = cborenc.AppendBytes(b, 1, d.FeedID[:])
+	b = cborenc.AppendUint(b, 2, uint64(d.ObservationsTimestamp))
+	b = cborenc.AppendBigInt(b, 3, d.BenchmarkPrice)
+	b = cborenc.AppendBigInt(b, 4, d.Bid)
+	b = cborenc.AppendBigInt(b, 5, d.Ask)
+	b = cborenc.AppendUint(b, 6, uint64(d.CurrentBlockNum))
+	b = cborenc.AppendBytes(b, 7, d.CurrentBlockHash[:])
+	b = cborenc.AppendUint(b, 8, uint64(d.ValidFromBlockNum))
+	b = cborenc.AppendUint(b, 9, uint64(d.CurrentBlockTimestamp))
+	return b
+}
+
+// unmarshalV1CBOR decodes the data encoded by marshalV1CBOR, unknown fields are ignored
+func unmarshalV1CBOR(b []byte, d *v1.Data) error {
+	*d = v1.Data{}
+	return cborenc.Decode(b, func(f cborenc.Field) (err error) {
+		switch f.Key {
+		case 1:
+			err = f.CopyFixed(d.FeedID[:])
+		case 2:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ObservationsTimestamp = uint32(v)
+		case 3:
+			d.BenchmarkPrice, err = f.BigInt()
+		case 4:
+			d.Bid, err = f.BigInt()
+		case 5:
+			d.Ask, err = f.BigInt()
+		case 6:
+			var v uint64
+			v, err = f.Uint(64)
+			d.CurrentBlockNum = uint64(v)
+		case 7:
+			err = f.CopyFixed(d.CurrentBlockHash[:])
+		case 8:
+			var v uint64
+			v, err = f.Uint(64)
+			d.ValidFromBlockNum = uint64(v)
+		case 9:
+			var v uint64
+			v, err = f.Uint(64)
+			d.CurrentBlockTimestamp = uint64(v)
+		}
+		return err
+	})
+}
+
+// v2JSON is the JSON encoding of v2.Data
+type v2JSON struct {
+	FeedID                jsonenc.Bytes   `json:"feedId"`
+	ValidFromTimestamp    uint32          `json:"validFromTimestamp"`
+	ObservationsTimestamp uint32          `json:"observationsTimestamp"`
+	NativeFee             *jsonenc.BigInt `json:"nativeFee"`
+	LinkFee               *jsonenc.BigInt `json:"linkFee"`
+	ExpiresAt             uint32          `json:"expiresAt"`
+	BenchmarkPrice        *jsonenc.BigInt `json:"benchmarkPrice"`
+}
+
+// marshalV2JSON encodes the data as a JSON object keyed by the schema field names
+func marshalV2JSON(d *v2.Data) ([]byte, error) {
+	return json.Marshal(v2JSON{
+		FeedID:                d.FeedID[:],
+		ValidFromTimestamp:    d.ValidFromTimestamp,
+		ObservationsTimestamp: d.ObservationsTimestamp,
+		NativeFee:             (*jsonenc.BigInt)(d.NativeFee),
+		LinkFee:               (*jsonenc.BigInt)(d.LinkFee),
+		ExpiresAt:             d.ExpiresAt,
+		BenchmarkPrice:        (*jsonenc.BigInt)(d.BenchmarkPrice),
+	})
+}
+
+// unmarshalV2JSON decodes the data encoded by marshalV2JSON
+func unmarshalV2JSON(b []byte, d *v2.Data) error {
+	var j v2JSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if err := jsonenc.CopyFixed(d.FeedID[:], j.FeedID); err != nil {
+		return fmt.Errorf("invalid feedId: %w", err)
+	}
+	d.ValidFromTimestamp = j.ValidFromTimestamp
+	d.ObservationsTimestamp = j.ObservationsTimestamp
+	d.NativeFee = (*big.Int)(j.NativeFee)
+	d.LinkFee = (*big.Int)(j.LinkFee)
+	d.ExpiresAt = j.ExpiresAt
+	d.BenchmarkPrice = (*big.Int)(j.BenchmarkPrice)
+	return nil
+}
+
+// V2ToProto converts the data to its V2Data protobuf message of datastreams.proto
+func V2ToProto(d v2.Data) *datastreamspb.V2Data {
+	return &datastreamspb.V2Data{
+		FeedId:                protoenc.Fixed(d.FeedID[:]),
+		ValidFromTimestamp:    d.ValidFromTimestamp,
+		ObservationsTimestamp: d.ObservationsTimestamp,
+		NativeFee:             protoenc.BigInt(d.NativeFee),
+		LinkFee:               protoenc.BigInt(d.LinkFee),
+		ExpiresAt:             d.ExpiresAt,
+		BenchmarkPrice:        protoenc.BigInt(d.BenchmarkPrice),
+	}
+}
+
+// V2FromProto converts the V2Data protobuf message of datastreams.proto to the data,
+// the reverse of V2ToProto
+func V2FromProto(m *datastreamspb.V2Data) (d *v2.Data, err error) {
+	d = new(v2.Data)
+	if err = protoenc.CopyFixed(d.FeedID[:], m.GetFeedId()); err != nil {
+		return nil, fmt.Errorf("invalid feed_id: %w", err)
+	}
+	d.ValidFromTimestamp = m.GetValidFromTimestamp()
+	d.ObservationsTimestamp = m.GetObservationsTimestamp()
+	if d.NativeFee, err = protoenc.ParseBigInt(m.GetNativeFee()); err != nil {
+		return nil, fmt.Errorf("invalid native_fee: %w", err)
+	}
+	if d.LinkFee, err = protoenc.ParseBigInt(m.GetLinkFee()); err != nil {
+		return nil, fmt.Errorf("invalid link_fee: %w", err)
+	}
+	d.ExpiresAt = m.GetExpiresAt()
+	if d.BenchmarkPrice, err = protoenc.ParseBigInt(m.GetBenchmarkPrice()); err != nil {
+		return nil, fmt.Errorf("invalid benchmark_price: %w", err)
+	}
+	return d, nil
+}
+
+// marshalV2CBOR encodes the data as a CBOR map keyed by the field numbers of its V2Data protobuf message
+func marshalV2CBOR(d *v2.Data) []byte {
+	b := cborenc.AppendMap(nil, 7)
+	b = cborenc.AppendBytes(b, 1, d.FeedID[:])
+	b = cborenc.AppendUint(b, 2, uint64(d.ValidFromTimestamp))
+	b = cborenc.AppendUint(b, 3, uint64(d.ObservationsTimestamp))
+	b = cborenc.AppendBigInt(b, 4, d.NativeFee)
+	b = cborenc.AppendBigInt(b, 5, d.LinkFee)
+	b = cborenc.AppendUint(b, 6, uint64(d.ExpiresAt))
+	b = cborenc.AppendBigInt(b, 7, d.BenchmarkPrice)
+	return b
+}
+
+// unmarshalV2CBOR decodes the data encoded by marshalV2CBOR, unknown fields are ignored
+func unmarshalV2CBOR(b []byte, d *v2.Data) error {
+	*d = v2.Data{}
+	return cborenc.Decode(b, func(f cborenc.Field) (err error) {
+		switch f.Key {
+		case 1:
+			err = f.CopyFixed(d.FeedID[:])
+		case 2:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ValidFromTimestamp = uint32(v)
+		case 3:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ObservationsTimestamp = uint32(v)
+		case 4:
+			d.NativeFee, err = f.BigInt()
+		case 5:
+			d.LinkFee, err = f.BigInt()
+		case 6:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ExpiresAt = uint32(v)
+		case 7:
+			d.BenchmarkPrice, err = f.BigInt()
+		}
+		return err
+	})
+}
+
+// v3JSON is the JSON encoding of v3.Data
+type v3JSON struct {
+	FeedID                jsonenc.Bytes   `json:"feedId"`
+	ValidFromTimestamp    uint32          `json:"validFromTimestamp"`
+	ObservationsTimestamp uint32          `json:"observationsTimestamp"`
+	NativeFee             *jsonenc.BigInt `json:"nativeFee"`
+	LinkFee               *jsonenc.BigInt `json:"linkFee"`
+	ExpiresAt             uint32          `json:"expiresAt"`
+	BenchmarkPrice        *jsonenc.BigInt `json:"benchmarkPrice"`
+	Bid                   *jsonenc.BigInt `json:"bid"`
+	Ask                   *jsonenc.BigInt `json:"ask"`
+}
+
+// marshalV3JSON encodes the data as a JSON object keyed by the schema field names
+func marshalV3JSON(d *v3.Data) ([]byte, error) {
+	return json.Marshal(v3JSON{
+		FeedID:                d.FeedID[:],
+		ValidFromTimestamp:    d.ValidFromTimestamp,
+		ObservationsTimestamp: d.ObservationsTimestamp,
+		NativeFee:             (*jsonenc.BigInt)(d.NativeFee),
+		LinkFee:               (*jsonenc.BigInt)(d.LinkFee),
+		ExpiresAt:             d.ExpiresAt,
+		BenchmarkPrice:        (*jsonenc.BigInt)(d.BenchmarkPrice),
+		Bid:                   (*jsonenc.BigInt)(d.Bid),
+		Ask:                   (*jsonenc.BigInt)(d.Ask),
+	})
+}
+
+// unmarshalV3JSON decodes the data encoded by marshalV3JSON
+func unmarshalV3JSON(b []byte, d *v3.Data) error {
+	var j v3JSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if err := jsonenc.CopyFixed(d.FeedID[:], j.FeedID); err != nil {
+		return fmt.Errorf("invalid feedId: %w", err)
+	}
+	d.ValidFromTimestamp = j.ValidFromTimestamp
+	d.ObservationsTimestamp = j.ObservationsTimestamp
+	d.NativeFee = (*big.Int)(j.NativeFee)
+	d.LinkFee = (*big.Int)(j.LinkFee)
+	d.ExpiresAt = j.ExpiresAt
+	d.BenchmarkPrice = (*big.Int)(j.BenchmarkPrice)
+	d.Bid = (*big.Int)(j.Bid)
+	d.Ask = (*big.Int)(j.Ask)
+	return nil
+}
+
+// V3ToProto converts the data to its V3Data protobuf message of datastreams.proto
+func V3ToProto(d v3.Data) *datastreamspb.V3Data {
+	return &datastreamspb.V3Data{
+		FeedId:                protoenc.Fixed(d.FeedID[:]),
+		ValidFromTimestamp:    d.ValidFromTimestamp,
+		ObservationsTimestamp: d.ObservationsTimestamp,
+		NativeFee:             protoenc.BigInt(d.NativeFee),
+		LinkFee:               protoenc.BigInt(d.LinkFee),
+		ExpiresAt:             d.ExpiresAt,
+		BenchmarkPrice:        protoenc.BigInt(d.BenchmarkPrice),
+		Bid:                   protoenc.BigInt(d.Bid),
+		Ask:                   protoenc.BigInt(d.Ask),
+	}
+}
+
+// V3FromProto converts the V3Data protobuf message of datastreams.proto to the data,
+// the reverse of V3ToProto
+func V3FromProto(m *datastreamspb.V3Data) (d *v3.Data, err error) {
+	d = new(v3.Data)
+	if err = protoenc.CopyFixed(d.FeedID[:], m.GetFeedId()); err != nil {
+		return nil, fmt.Errorf("invalid feed_id: %w", err)
+	}
+	d.ValidFromTimestamp = m.GetValidFromTimestamp()
+	d.ObservationsTimestamp = m.GetObservationsTimestamp()
+	if d.NativeFee, err = protoenc.ParseBigInt(m.GetNativeFee()); err != nil {
+		return nil, fmt.Errorf("invalid native_fee: %w", err)
+	}
+	if d.LinkFee, err = protoenc.ParseBigInt(m.GetLinkFee()); err != nil {
+		return nil, fmt.Errorf("invalid link_fee: %w", err)
+	}
+	d.ExpiresAt = m.GetExpiresAt()
+	if d.BenchmarkPrice, err = protoenc.ParseBigInt(m.GetBenchmarkPrice()); err != nil {
+		return nil, fmt.Errorf("invalid benchmark_price: %w", err)
+	}
+	if d.Bid, err = protoenc.ParseBigInt(m.GetBid()); err != nil {
+		return nil, fmt.Errorf("invalid bid: %w", err)
+	}
+	if d.Ask, err = protoenc.ParseBigInt(m.GetAsk()); err != nil {
+		return nil, fmt.Errorf("invalid ask: %w", err)
+	}
+	return d, nil
+}
+
+// marshalV3CBOR encodes the data as a CBOR map keyed by the field numbers of its V3Data protobuf message
+func marshalV3CBOR(d *v3.Data) []byte {
+	b := cborenc.AppendMap(nil, 9)
+	b = cborenc.AppendBytes(b, 1, d.FeedID[:])
+	b = cborenc.AppendUint(b, 2, uint64(d.ValidFromTimestamp))
+	b = cborenc.AppendUint(b, 3, uint64(d.ObservationsTimestamp))
+	b = cborenc.AppendBigInt(b, 4, d.NativeFee)
+	b = cborenc.AppendBigInt(b, 5, d.LinkFee)
+	b = cborenc.AppendUint(b, 6, uint64(d.ExpiresAt))
+	b = cborenc.AppendBigInt(b, 7, d.BenchmarkPrice)
+	b = cborenc.AppendBigInt(b, 8, d.Bid)
+	b = cborenc.AppendBigInt(b, 9, d.Ask)
+	return b
+}
+
+// unmarshalV3CBOR decodes the data encoded by marshalV3CBOR, unknown fields are ignored
+func unmarshalV3CBOR(b []byte, d *v3.Data) error {
+	*d = v3.Data{}
+	return cborenc.Decode(b, func(f cborenc.Field) (err error) {
+		switch f.Key {
+		case 1:
+			err = f.CopyFixed(d.FeedID[:])
+		case 2:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ValidFromTimestamp = uint32(v)
+		case 3:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ObservationsTimestamp = uint32(v)
+		case 4:
+			d.NativeFee, err = f.BigInt()
+		case 5:
+			d.LinkFee, err = f.BigInt()
+		case 6:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ExpiresAt = uint32(v)
+		case 7:
+			d.BenchmarkPrice, err = f.BigInt()
+		case 8:
+			d.Bid, err = f.BigInt()
+		case 9:
+			d.Ask, err = f.BigInt()
+		}
+		return err
+	})
+}
+
+// v4JSON is the JSON encoding of v4.Data
+type v4JSON struct {
+	FeedID                jsonenc.Bytes       `json:"feedId"`
+	ValidFromTimestamp    uint32              `json:"validFromTimestamp"`
+	ObservationsTimestamp uint32              `json:"observationsTimestamp"`
+	NativeFee             *jsonenc.BigInt     `json:"nativeFee"`
+	LinkFee               *jsonenc.BigInt     `json:"linkFee"`
+	ExpiresAt             uint32              `json:"expiresAt"`
+	BenchmarkPrice        *jsonenc.BigInt     `json:"benchmarkPrice"`
+	MarketStatus          common.MarketStatus `json:"marketStatus"`
+}
+
+// marshalV4JSON encodes the data as a JSON object keyed by the schema field names
+func marshalV4JSON(d *v4.Data) ([]byte, error) {
+	return json.Marshal(v4JSON{
+		FeedID:                d.FeedID[:],
+		ValidFromTimestamp:    d.ValidFromTimestamp,
+		ObservationsTimestamp: d.ObservationsTimestamp,
+		NativeFee:             (*jsonenc.BigInt)(d.NativeFee),
+		LinkFee:               (*jsonenc.BigInt)(d.LinkFee),
+		ExpiresAt:             d.ExpiresAt,
+		BenchmarkPrice:        (*jsonenc.BigInt)(d.BenchmarkPrice),
+		MarketStatus:          d.MarketStatus,
+	})
+}
+
+// unmarshalV4JSON decodes the data encoded by marshalV4JSON
+func unmarshalV4JSON(b []byte, d *v4.Data) error {
+	var j v4JSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if err := jsonenc.CopyFixed(d.FeedID[:], j.FeedID); err != nil {
+		return fmt.Errorf("invalid feedId: %w", err)
+	}
+	d.ValidFromTimestamp = j.ValidFromTimestamp
+	d.ObservationsTimestamp = j.ObservationsTimestamp
+	d.NativeFee = (*big.Int)(j.NativeFee)
+	d.LinkFee = (*big.Int)(j.LinkFee)
+	d.ExpiresAt = j.ExpiresAt
+	d.BenchmarkPrice = (*big.Int)(j.BenchmarkPrice)
+	d.MarketStatus = j.MarketStatus
+	return nil
+}
+
+// V4ToProto converts the data to its V4Data protobuf message of datastreams.proto
+func V4ToProto(d v4.Data) *datastreamspb.V4Data {
+	return &datastreamspb.V4Data{
+		FeedId:                protoenc.Fixed(d.FeedID[:]),
+		ValidFromTimestamp:    d.ValidFromTimestamp,
+		ObservationsTimestamp: d.ObservationsTimestamp,
+		NativeFee:             protoenc.BigInt(d.NativeFee),
+		LinkFee:               protoenc.BigInt(d.LinkFee),
+		ExpiresAt:             d.ExpiresAt,
+		BenchmarkPrice:        protoenc.BigInt(d.BenchmarkPrice),
+		MarketStatus:          uint32(d.MarketStatus),
+	}
+}
+
+// V4FromProto converts the V4Data protobuf message of datastreams.proto to the data,
+// the reverse of V4ToProto
+func V4FromProto(m *datastreamspb.V4Data) (d *v4.Data, err error) {
+	d = new(v4.Data)
+	if err = protoenc.CopyFixed(d.FeedID[:], m.GetFeedId()); err != nil {
+		return nil, fmt.Errorf("invalid feed_id: %w", err)
+	}
+	d.ValidFromTimestamp = m.GetValidFromTimestamp()
+	d.ObservationsTimestamp = m.GetObservationsTimestamp()
+	if d.NativeFee, err = protoenc.ParseBigInt(m.GetNativeFee()); err != nil {
+		return nil, fmt.Errorf("invalid native_fee: %w", err)
+	}
+	if d.LinkFee, err = protoenc.ParseBigInt(m.GetLinkFee()); err != nil {
+		return nil, fmt.Errorf("invalid link_fee: %w", err)
+	}
+	d.ExpiresAt = m.GetExpiresAt()
+	if d.BenchmarkPrice, err = protoenc.ParseBigInt(m.GetBenchmarkPrice()); err != nil {
+		return nil, fmt.Errorf("invalid benchmark_price: %w", err)
+	}
+	d.MarketStatus = common.MarketStatus(m.GetMarketStatus())
+	return d, nil
+}
+
+// marshalV4CBOR encodes the data as a CBOR map keyed by the field numbers of its V4Data protobuf message
+func marshalV4CBOR(d *v4.Data) []byte {
+	b := cborenc.AppendMap(nil, 8)
+	b = cborenc.AppendBytes(b, 1, d.FeedID[:])
+	b = cborenc.AppendUint(b, 2, uint64(d.ValidFromTimestamp))
+	b = cborenc.AppendUint(b, 3, uint64(d.ObservationsTimestamp))
+	b = cborenc.AppendBigInt(b, 4, d.NativeFee)
+	b = cborenc.AppendBigInt(b, 5, d.LinkFee)
+	b = cborenc.AppendUint(b, 6, uint64(d.ExpiresAt))
+	b = cborenc.AppendBigInt(b, 7, d.BenchmarkPrice)
+	b = cborenc.AppendUint(b, 8, uint64(d.MarketStatus))
+	return b
+}
+
+// unmarshalV4CBOR decodes the data encoded by marshalV4CBOR, unknown fields are ignored
+func unmarshalV4CBOR(b []byte, d *v4.Data) error {
+	*d = v4.Data{}
+	return cborenc.Decode(b, func(f cborenc.Field) (err error) {
+		switch f.Key {
+		case 1:
+			err = f.CopyFixed(d.FeedID[:])
+		case 2:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ValidFromTimestamp = uint32(v)
+		case 3:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ObservationsTimestamp = uint32(v)
+		case 4:
+			d.NativeFee, err = f.BigInt()
+		case 5:
+			d.LinkFee, err = f.BigInt()
+		case 6:
+			var v uint64
+			v, err = f.Uint(32)
+			d.ExpiresAt = uint32(v)
+		case 7:
+			d.BenchmarkPrice, err = f.BigInt()
+		case 8:
+			var v uint64
+			v, err = f.Uint(32)
+			d.MarketStatus = common.MarketStatus(v)
+		}
+		return err
+	})
+}
+
+// marshalJSON encodes the data with the JSON encoding of its version
+func marshalJSON(d any) ([]byte, error) {
+	switch d := d.(type) {
+	case *v1.Data:
+		return marshalV1JSON(d)
+	case *v2.Data:
+		return marshalV2JSON(d)
+	case *v3.Data:
+		return marshalV3JSON(d)
+	case *v4.Data:
+		return marshalV4JSON(d)
+	default:
+		return nil, fmt.Errorf("codec: unsupported data type %T", d)
+	}
+}
+
+// unmarshalJSON decodes the data with the JSON encoding of its version
+func unmarshalJSON(b []byte, d any) error {
+	switch d := d.(type) {
+	case *v1.Data:
+		return unmarshalV1JSON(b, d)
+	case *v2.Data:
+		return unmarshalV2JSON(b, d)
+	case *v3.Data:
+		return unmarshalV3JSON(b, d)
+	case *v4.Data:
+		return unmarshalV4JSON(b, d)
+	default:
+		return fmt.Errorf("codec: unsupported data type %T", d)
+	}
+}
+
+// marshalCBOR encodes the data with the CBOR encoding of its version
+func marshalCBOR(d any) ([]byte, error) {
+	switch d := d.(type) {
+	case *v1.Data:
+		return marshalV1CBOR(d), nil
+	case *v2.Data:
+		return marshalV2CBOR(d), nil
+	case *v3.Data:
+		return marshalV3CBOR(d), nil
+	case *v4.Data:
+		return marshalV4CBOR(d), nil
+	default:
+		return nil, fmt.Errorf("codec: unsupported data type %T", d)
+	}
+}
+
+// unmarshalCBOR decodes the data with the CBOR encoding of its version
+func unmarshalCBOR(b []byte, d any) error {
+	switch d := d.(type) {
+	case *v1.Data:
+		return unmarshalV1CBOR(b, d)
+	case *v2.Data:
+		return unmarshalV2CBOR(b, d)
+	case *v3.Data:
+		return unmarshalV3CBOR(b, d)
+	case *v4.Data:
+		return unmarshalV4CBOR(b, d)
+	default:
+		return fmt.Errorf("codec: unsupported data type %T", d)
+	}
+}
+
+// MarshalAnyReportJSON encodes the report returned by report.DecodeAny as MarshalReportJSON does.
+func MarshalAnyReportJSON(r report.AnyReport) ([]byte, error) {
+	switch r := r.(type) {
+	case *report.Report[v1.Data]:
+		return MarshalReportJSON(r)
+	case *report.Report[v2.Data]:
+		return MarshalReportJSON(r)
+	case *report.Report[v3.Data]:
+		return MarshalReportJSON(r)
+	case *report.Report[v4.Data]:
+		return MarshalReportJSON(r)
+	default:
+		return nil, fmt.Errorf("codec: unsupported report type %T", r)
+	}
+}
@@ -0,0 +1,406 @@
+// Code generated by go run ./internal/gen; DO NOT EDIT.
+
+package codec
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/datastreamspb"
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/common"
+	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
+	v2 "github.com/smartcontractkit/data-streams-sdk/go/report/v2"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestV1_Proto(t *testing.T) {
+	want := &v1.Data{
+		FeedID:                feed.ID{0x00, 0x01, 0x01},
+		ObservationsTimestamp: uint32(1700000002),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(3), 100),
+		Bid:                   new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(4), 100)),
+		Ask:                   new(big.Int).Lsh(big.NewInt(5), 100),
+		CurrentBlockNum:       uint64(1700000006),
+		CurrentBlockHash:      [32]byte{0x07},
+		ValidFromBlockNum:     uint64(1700000008),
+		CurrentBlockTimestamp: uint64(1700000009),
+	}
+
+	b, err := proto.Marshal(V1ToProto(*want))
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %s", err)
+	}
+	m := &datastreamspb.V1Data{}
+	if err = proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %s", err)
+	}
+	got, err := V1FromProto(m)
+	if err != nil {
+		t.Fatalf("V1FromProto() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("V1FromProto() = %#v, want %#v", got, want)
+	}
+
+	if got, err = V1FromProto(nil); err != nil || !reflect.DeepEqual(got, &v1.Data{}) {
+		t.Errorf("V1FromProto(nil) = %#v, %v, want zero data", got, err)
+	}
+}
+
+func TestV1_CBOR(t *testing.T) {
+	want := v1.Data{
+		FeedID:                feed.ID{0x00, 0x01, 0x01},
+		ObservationsTimestamp: uint32(1700000002),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(3), 100),
+		Bid:                   new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(4), 100)),
+		Ask:                   new(big.Int).Lsh(big.NewInt(5), 100),
+		CurrentBlockNum:       uint64(1700000006),
+		CurrentBlockHash:      [32]byte{0x07},
+		ValidFromBlockNum:     uint64(1700000008),
+		CurrentBlockTimestamp: uint64(1700000009),
+	}
+
+	b, err := MarshalCBOR(want)
+	if err != nil {
+		t.Fatalf("MarshalCBOR() error = %s", err)
+	}
+	got, err := UnmarshalCBOR[v1.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalCBOR() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalCBOR() = %#v, want %#v", *got, want)
+	}
+
+	if _, err = UnmarshalCBOR[v1.Data](b[:len(b)-1]); err == nil {
+		t.Errorf("UnmarshalCBOR() of truncated data error = nil, want error")
+	}
+}
+
+func TestV1_JSON(t *testing.T) {
+	want := v1.Data{
+		FeedID:                feed.ID{0x00, 0x01, 0x01},
+		ObservationsTimestamp: uint32(1700000002),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(3), 100),
+		Bid:                   new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(4), 100)),
+		Ask:                   new(big.Int).Lsh(big.NewInt(5), 100),
+		CurrentBlockNum:       uint64(1700000006),
+		CurrentBlockHash:      [32]byte{0x07},
+		ValidFromBlockNum:     uint64(1700000008),
+		CurrentBlockTimestamp: uint64(1700000009),
+	}
+
+	b, err := MarshalJSON(want)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %s", err)
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("invalid JSON %s: %s", b, err)
+	}
+	if got, wantID := fields["feedId"], want.FeedID.String(); got != wantID {
+		t.Errorf("MarshalJSON() feedId = %v, want %s", got, wantID)
+	}
+
+	got, err := UnmarshalJSON[v1.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalJSON() = %#v, want %#v", *got, want)
+	}
+}
+
+func TestV2_Proto(t *testing.T) {
+	want := &v2.Data{
+		FeedID:                feed.ID{0x00, 0x02, 0x01},
+		ValidFromTimestamp:    uint32(1700000002),
+		ObservationsTimestamp: uint32(1700000003),
+		NativeFee:             new(big.Int).Lsh(big.NewInt(4), 100),
+		LinkFee:               new(big.Int).Lsh(big.NewInt(5), 100),
+		ExpiresAt:             uint32(1700000006),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(7), 100),
+	}
+
+	b, err := proto.Marshal(V2ToProto(*want))
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %s", err)
+	}
+	m := &datastreamspb.V2Data{}
+	if err = proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %s", err)
+	}
+	got, err := V2FromProto(m)
+	if err != nil {
+		t.Fatalf("V2FromProto() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("V2FromProto() = %#v, want %#v", got, want)
+	}
+
+	if got, err = V2FromProto(nil); err != nil || !reflect.DeepEqual(got, &v2.Data{}) {
+		t.Errorf("V2FromProto(nil) = %#v, %v, want zero data", got, err)
+	}
+}
+
+func TestV2_CBOR(t *testing.T) {
+	want := v2.Data{
+		FeedID:                feed.ID{0x00, 0x02, 0x01},
+		ValidFromTimestamp:    uint32(1700000002),
+		ObservationsTimestamp: uint32(1700000003),
+		NativeFee:             new(big.Int).Lsh(big.NewInt(4), 100),
+		LinkFee:               new(big.Int).Lsh(big.NewInt(5), 100),
+		ExpiresAt:             uint32(1700000006),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(7), 100),
+	}
+
+	b, err := MarshalCBOR(want)
+	if err != nil {
+		t.Fatalf("MarshalCBOR() error = %s", err)
+	}
+	got, err := UnmarshalCBOR[v2.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalCBOR() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalCBOR() = %#v, want %#v", *got, want)
+	}
+
+	if _, err = UnmarshalCBOR[v2.Data](b[:len(b)-1]); err == nil {
+		t.Errorf("UnmarshalCBOR() of truncated data error = nil, want error")
+	}
+}
+
+func TestV2_JSON(t *testing.T) {
+	want := v2.Data{
+		FeedID:                feed.ID{0x00, 0x02, 0x01},
+		ValidFromTimestamp:    uint32(1700000002),
+		ObservationsTimestamp: uint32(1700000003),
+		NativeFee:             new(big.Int).Lsh(big.NewInt(4), 100),
+		LinkFee:               new(big.Int).Lsh(big.NewInt(5), 100),
+		ExpiresAt:             uint32(1700000006),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(7), 100),
+	}
+
+	b, err := MarshalJSON(want)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %s", err)
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("invalid JSON %s: %s", b, err)
+	}
+	if got, wantID := fields["feedId"], want.FeedID.String(); got != wantID {
+		t.Errorf("MarshalJSON() feedId = %v, want %s", got, wantID)
+	}
+
+	got, err := UnmarshalJSON[v2.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalJSON() = %#v, want %#v", *got, want)
+	}
+}
+
+func TestV3_Proto(t *testing.T) {
+	want := &v3.Data{
+		FeedID:                feed.ID{0x00, 0x03, 0x01},
+		ValidFromTimestamp:    uint32(1700000002),
+		ObservationsTimestamp: uint32(1700000003),
+		NativeFee:             new(big.Int).Lsh(big.NewInt(4), 100),
+		LinkFee:               new(big.Int).Lsh(big.NewInt(5), 100),
+		ExpiresAt:             uint32(1700000006),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(7), 100),
+		Bid:                   new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(8), 100)),
+		Ask:                   new(big.Int).Lsh(big.NewInt(9), 100),
+	}
+
+	b, err := proto.Marshal(V3ToProto(*want))
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %s", err)
+	}
+	m := &datastreamspb.V3Data{}
+	if err = proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %s", err)
+	}
+	got, err := V3FromProto(m)
+	if err != nil {
+		t.Fatalf("V3FromProto() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("V3FromProto() = %#v, want %#v", got, want)
+	}
+
+	if got, err = V3FromProto(nil); err != nil || !reflect.DeepEqual(got, &v3.Data{}) {
+		t.Errorf("V3FromProto(nil) = %#v, %v, want zero data", got, err)
+	}
+}
+
+func TestV3_CBOR(t *testing.T) {
+	want := v3.Data{
+		FeedID:                feed.ID{0x00, 0x03, 0x01},
+		ValidFromTimestamp:    uint32(1700000002),
+		ObservationsTimestamp: uint32(1700000003),
+		NativeFee:             new(big.Int).Lsh(big.NewInt(4), 100),
+		LinkFee:               new(big.Int).Lsh(big.NewInt(5), 100),
+		ExpiresAt:             uint32(1700000006),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(7), 100),
+		Bid:                   new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(8), 100)),
+		Ask:                   new(big.Int).Lsh(big.NewInt(9), 100),
+	}
+
+	b, err := MarshalCBOR(want)
+	if err != nil {
+		t.Fatalf("MarshalCBOR() error = %s", err)
+	}
+	got, err := UnmarshalCBOR[v3.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalCBOR() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalCBOR() = %#v, want %#v", *got, want)
+	}
+
+	if _, err = UnmarshalCBOR[v3.Data](b[:len(b)-1]); err == nil {
+		t.Errorf("UnmarshalCBOR() of truncated data error = nil, want error")
+	}
+}
+
+func TestV3_JSON(t *testing.T) {
+	want := v3.Data{
+		FeedID:                feed.ID{0x00, 0x03, 0x01},
+		ValidFromTimestamp:    uint32(1700000002),
+		ObservationsTimestamp: uint32(1700000003),
+		NativeFee:             new(big.Int).Lsh(big.NewInt(4), 100),
+		LinkFee:               new(big.Int).Lsh(big.NewInt(5), 100),
+		ExpiresAt:             uint32(1700000006),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(7), 100),
+		Bid:                   new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(8), 100)),
+		Ask:                   new(big.Int).Lsh(big.NewInt(9), 100),
+	}
+
+	b, err := MarshalJSON(want)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %s", err)
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("invalid JSON %s: %s", b, err)
+	}
+	if got, wantID := fields["feedId"], want.FeedID.String(); got != wantID {
+		t.Errorf("MarshalJSON() feedId = %v, want %s", got, wantID)
+	}
+
+	got, err := UnmarshalJSON[v3.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalJSON() = %#v, want %#v", *got, want)
+	}
+}
+
+func TestV4_Proto(t *testing.T) {
+	want := &v4.Data{
+		FeedID:                feed.ID{0x00, 0x04, 0x01},
+		ValidFromTimestamp:    uint32(1700000002),
+		ObservationsTimestamp: uint32(1700000003),
+		NativeFee:             new(big.Int).Lsh(big.NewInt(4), 100),
+		LinkFee:               new(big.Int).Lsh(big.NewInt(5), 100),
+		ExpiresAt:             uint32(1700000006),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(7), 100),
+		MarketStatus:          common.MarketStatus(1700000008),
+	}
+
+	b, err := proto.Marshal(V4ToProto(*want))
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %s", err)
+	}
+	m := &datastreamspb.V4Data{}
+	if err = proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %s", err)
+	}
+	got, err := V4FromProto(m)
+	if err != nil {
+		t.Fatalf("V4FromProto() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("V4FromProto() = %#v, want %#v", got, want)
+	}
+
+	if got, err = V4FromProto(nil); err != nil || !reflect.DeepEqual(got, &v4.Data{}) {
+		t.Errorf("V4FromProto(nil) = %#v, %v, want zero data", got, err)
+	}
+}
+
+func TestV4_CBOR(t *testing.T) {
+	want := v4.Data{
+		FeedID:                feed.ID{0x00, 0x04, 0x01},
+		ValidFromTimestamp:    uint32(1700000002),
+		ObservationsTimestamp: uint32(1700000003),
+		NativeFee:             new(big.Int).Lsh(big.NewInt(4), 100),
+		LinkFee:               new(big.Int).Lsh(big.NewInt(5), 100),
+		ExpiresAt:             uint32(1700000006),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(7), 100),
+		MarketStatus:          common.MarketStatus(1700000008),
+	}
+
+	b, err := MarshalCBOR(want)
+	if err != nil {
+		t.Fatalf("MarshalCBOR() error = %s", err)
+	}
+	got, err := UnmarshalCBOR[v4.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalCBOR() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalCBOR() = %#v, want %#v", *got, want)
+	}
+
+	if _, err = UnmarshalCBOR[v4.Data](b[:len(b)-1]); err == nil {
+		t.Errorf("UnmarshalCBOR() of truncated data error = nil, want error")
+	}
+}
+
+func TestV4_JSON(t *testing.T) {
+	want := v4.Data{
+		FeedID:                feed.ID{0x00, 0x04, 0x01},
+		ValidFromTimestamp:    uint32(1700000002),
+		ObservationsTimestamp: uint32(1700000003),
+		NativeFee:             new(big.Int).Lsh(big.NewInt(4), 100),
+		LinkFee:               new(big.Int).Lsh(big.NewInt(5), 100),
+		ExpiresAt:             uint32(1700000006),
+		BenchmarkPrice:        new(big.Int).Lsh(big.NewInt(7), 100),
+		MarketStatus:          common.MarketStatus(1700000008),
+	}
+
+	b, err := MarshalJSON(want)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %s", err)
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("invalid JSON %s: %s", b, err)
+	}
+	if got, wantID := fields["feedId"], want.FeedID.String(); got != wantID {
+		t.Errorf("MarshalJSON() feedId = %v, want %s", got, wantID)
+	}
+
+	got, err := UnmarshalJSON[v4.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalJSON() = %#v, want %#v", *got, want)
+	}
+}
@@ -0,0 +1,38 @@
+package codec
+
+import (
+	"fmt"
+
+	streams "github.com/smartcontractkit/data-streams-sdk/go"
+	"github.com/smartcontractkit/data-streams-sdk/go/datastreamspb"
+)
+
+// ReportResponseToProto converts the report to its ReportResponse protobuf message of datastreams.proto,
+// for forwarding reports over gRPC pipelines. Annotations such as Verification and Metadata are not converted.
+// The message shares the full report bytes of the report.
+// The report data decoded from the full report is converted by the VnToProto functions.
+func ReportResponseToProto(r *streams.ReportResponse) *datastreamspb.ReportResponse {
+	return &datastreamspb.ReportResponse{
+		FeedId:                append([]byte{}, r.FeedID[:]...),
+		FullReport:            r.FullReportBytes(),
+		ValidFromTimestamp:    r.ValidFromTimestamp,
+		ObservationsTimestamp: r.ObservationsTimestamp,
+	}
+}
+
+// ReportResponseFromProto converts the ReportResponse protobuf message of datastreams.proto to the report,
+// the reverse of ReportResponseToProto. The report shares the full report bytes of the message.
+func ReportResponseFromProto(m *datastreamspb.ReportResponse) (r *streams.ReportResponse, err error) {
+	r = &streams.ReportResponse{
+		FullReport:            m.GetFullReport(),
+		ValidFromTimestamp:    m.GetValidFromTimestamp(),
+		ObservationsTimestamp: m.GetObservationsTimestamp(),
+	}
+	if id := m.GetFeedId(); len(id) > 0 {
+		if len(id) != len(r.FeedID) {
+			return nil, fmt.Errorf("codec: invalid report proto feed ID length %d", len(id))
+		}
+		copy(r.FeedID[:], id)
+	}
+	return r, nil
+}
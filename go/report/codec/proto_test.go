@@ -0,0 +1,43 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+
+	streams "github.com/smartcontractkit/data-streams-sdk/go"
+	"github.com/smartcontractkit/data-streams-sdk/go/datastreamspb"
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReportResponseProto(t *testing.T) {
+	want := &streams.ReportResponse{
+		FeedID:                feed.ID{0x00, 0x03, 0x01},
+		FullReport:            []byte{0xde, 0xad, 0xbe, 0xef},
+		ValidFromTimestamp:    9,
+		ObservationsTimestamp: 10,
+	}
+
+	b, err := proto.Marshal(ReportResponseToProto(want))
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %s", err)
+	}
+	m := &datastreamspb.ReportResponse{}
+	if err = proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %s", err)
+	}
+	got, err := ReportResponseFromProto(m)
+	if err != nil {
+		t.Fatalf("ReportResponseFromProto() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReportResponseFromProto() = %#v, want %#v", got, want)
+	}
+
+	if got, err = ReportResponseFromProto(nil); err != nil || !reflect.DeepEqual(got, &streams.ReportResponse{}) {
+		t.Errorf("ReportResponseFromProto(nil) = %#v, %v, want zero report", got, err)
+	}
+	if _, err = ReportResponseFromProto(&datastreamspb.ReportResponse{FeedId: []byte{1}}); err == nil {
+		t.Errorf("ReportResponseFromProto() of an invalid feed ID error = nil, want error")
+	}
+}
@@ -0,0 +1,90 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/jsonenc"
+)
+
+// reportJSON is the JSON encoding of a report.Report
+type reportJSON struct {
+	Data          json.RawMessage  `json:"data,omitempty"`
+	ReportContext [3]jsonenc.Bytes `json:"reportContext"`
+	ReportBlob    jsonenc.Bytes    `json:"reportBlob"`
+	RawRs         []jsonenc.Bytes  `json:"rawRs"`
+	RawSs         []jsonenc.Bytes  `json:"rawSs"`
+	RawVs         jsonenc.Bytes    `json:"rawVs"`
+}
+
+// MarshalReportJSON encodes the report as a JSON object with the data encoded by MarshalJSON,
+// bytes hex encoded and big integers encoded as decimal strings.
+func MarshalReportJSON[T report.Data](r *report.Report[T]) ([]byte, error) {
+	data, err := MarshalJSON(r.Data)
+	if err != nil {
+		return nil, err
+	}
+	j := reportJSON{
+		Data:       data,
+		ReportBlob: r.ReportBlob,
+		RawRs:      make([]jsonenc.Bytes, len(r.RawRs)),
+		RawSs:      make([]jsonenc.Bytes, len(r.RawSs)),
+		RawVs:      r.RawVs[:],
+	}
+	for x := range r.ReportContext {
+		j.ReportContext[x] = r.ReportContext[x][:]
+	}
+	for x := range r.RawRs {
+		j.RawRs[x] = r.RawRs[x][:]
+	}
+	for x := range r.RawSs {
+		j.RawSs[x] = r.RawSs[x][:]
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalReportJSON decodes the report encoded by MarshalReportJSON.
+func UnmarshalReportJSON[T report.Data](b []byte) (r *report.Report[T], err error) {
+	var j reportJSON
+	if err = json.Unmarshal(b, &j); err != nil {
+		return nil, fmt.Errorf("codec: invalid report JSON: %w", err)
+	}
+
+	r = &report.Report[T]{ReportBlob: j.ReportBlob}
+	if len(j.Data) > 0 {
+		d, err := UnmarshalJSON[T](j.Data)
+		if err != nil {
+			return nil, err
+		}
+		r.Data = *d
+	}
+	for x := range j.ReportContext {
+		if err = jsonenc.CopyFixed(r.ReportContext[x][:], j.ReportContext[x]); err != nil {
+			return nil, fmt.Errorf("codec: invalid reportContext[%d]: %w", x, err)
+		}
+	}
+	if r.RawRs, err = fixedList(j.RawRs); err != nil {
+		return nil, fmt.Errorf("codec: invalid rawRs: %w", err)
+	}
+	if r.RawSs, err = fixedList(j.RawSs); err != nil {
+		return nil, fmt.Errorf("codec: invalid rawSs: %w", err)
+	}
+	if err = jsonenc.CopyFixed(r.RawVs[:], j.RawVs); err != nil {
+		return nil, fmt.Errorf("codec: invalid rawVs: %w", err)
+	}
+	return r, nil
+}
+
+func fixedList(list []jsonenc.Bytes) (l [][32]byte, err error) {
+	if list == nil {
+		return nil, nil
+	}
+	l = make([][32]byte, len(list))
+	for x, b := range list {
+		if err = jsonenc.CopyFixed(l[x][:], b); err != nil {
+			return nil, fmt.Errorf("element %d: %w", x, err)
+		}
+	}
+	return l, nil
+}
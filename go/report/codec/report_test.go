@@ -0,0 +1,83 @@
+package codec
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+)
+
+var v3Data = v3.Data{
+	FeedID:                feed.ID{0x00, 0x03, 0x6b},
+	ValidFromTimestamp:    1718885772,
+	ObservationsTimestamp: 1718885772,
+	NativeFee:             big.NewInt(10),
+	LinkFee:               big.NewInt(10),
+	ExpiresAt:             1718885872,
+	BenchmarkPrice:        big.NewInt(100),
+	Bid:                   big.NewInt(99),
+	Ask:                   big.NewInt(101),
+}
+
+func v3Report(t *testing.T) *report.Report[v3.Data] {
+	t.Helper()
+	blob, err := v3.Encode(&v3Data)
+	if err != nil {
+		t.Fatalf("failed to encode data: %s", err)
+	}
+	return &report.Report[v3.Data]{
+		Data:          v3Data,
+		ReportContext: [3][32]byte{{0x00, 0x06}, {0x00, 0x01}, {0x00, 0x02}},
+		ReportBlob:    blob,
+		RawRs:         [][32]byte{{0x01}, {0x02}},
+		RawSs:         [][32]byte{{0x03}, {0x04}},
+		RawVs:         [32]byte{0x00, 0x01, 0x0a, 0x4a, 0x43},
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	r := v3Report(t)
+	b, err := MarshalReportJSON(r)
+	if err != nil {
+		t.Fatalf("MarshalReportJSON() error = %s", err)
+	}
+
+	for _, want := range []string{
+		`"feedId":"` + v3Data.FeedID.String() + `"`,
+		`"benchmarkPrice":"100"`,
+		`"reportBlob":"0x` + hex.EncodeToString(r.ReportBlob[:8]),
+		`"rawVs":"0x00010a4a43`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("MarshalReportJSON() = %s, want it to contain %s", b, want)
+		}
+	}
+
+	decoded, err := UnmarshalReportJSON[v3.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalReportJSON() error = %s", err)
+	}
+	if !reflect.DeepEqual(decoded, r) {
+		t.Errorf("UnmarshalReportJSON() = %#v, want %#v", decoded, r)
+	}
+
+	partial, err := UnmarshalReportJSON[v3.Data]([]byte(`{"data":{"feedId":"` + v3Data.FeedID.String() + `"}}`))
+	if err != nil || partial.Data.FeedID != v3Data.FeedID {
+		t.Errorf("UnmarshalReportJSON() of partial report = %#v, %v", partial, err)
+	}
+	for _, invalid := range []string{
+		`{"rawVs":"0x01"}`,
+		`{"rawRs":["0x01"]}`,
+		`{"data":{"feedId":"0x01"}}`,
+		`{"data":{"bid":"1e5"}}`,
+	} {
+		if _, err = UnmarshalReportJSON[v3.Data]([]byte(invalid)); err == nil {
+			t.Errorf("UnmarshalReportJSON(%s) error = nil, want error", invalid)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+)
+
+// TestVectors decodes the test vectors shared with the other SDKs, see report/internal/vectors.
+func TestVectors(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("..", "testdata", "vectors.json"))
+	if err != nil {
+		t.Fatalf("error reading test vectors: %s", err)
+	}
+	var vectors struct {
+		Vectors []struct {
+			Name       string          `json:"name"`
+			Version    int             `json:"version"`
+			FullReport string          `json:"fullReport"`
+			Report     json.RawMessage `json:"report"`
+		} `json:"vectors"`
+	}
+	if err = json.Unmarshal(b, &vectors); err != nil {
+		t.Fatalf("invalid test vectors: %s", err)
+	}
+	if len(vectors.Vectors) == 0 {
+		t.Fatalf("no test vectors")
+	}
+
+	for _, v := range vectors.Vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			fullReport, err := hex.DecodeString(strings.TrimPrefix(v.FullReport, "0x"))
+			if err != nil {
+				t.Fatalf("invalid fullReport: %s", err)
+			}
+
+			r, err := report.DecodeAny(fullReport)
+			if err != nil {
+				t.Fatalf("DecodeAny() error = %s", err)
+			}
+			if int(r.Version()) != v.Version {
+				t.Errorf("DecodeAny() version = %d, want %d", r.Version(), v.Version)
+			}
+
+			got, err := MarshalAnyReportJSON(r)
+			if err != nil {
+				t.Fatalf("MarshalAnyReportJSON() error = %s", err)
+			}
+			if got, want := vectorValues(t, got), vectorValues(t, v.Report); !reflect.DeepEqual(got, want) {
+				t.Errorf("DecodeAny() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// vectorValues decodes the JSON encoded report with its integers as decimal strings,
+// the vectors encoding of integers whatever their size.
+func vectorValues(t *testing.T, b []byte) (v any) {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		t.Fatalf("invalid report JSON: %s", err)
+	}
+
+	var normalize func(v any) any
+	normalize = func(v any) any {
+		switch v := v.(type) {
+		case json.Number:
+			return v.String()
+		case map[string]any:
+			for k := range v {
+				v[k] = normalize(v[k])
+			}
+		case []any:
+			for x := range v {
+				v[x] = normalize(v[x])
+			}
+		}
+		return v
+	}
+	return normalize(v)
+}
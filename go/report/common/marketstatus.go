@@ -0,0 +1,33 @@
+// Package common holds the report data field types shared across the report data versions.
+package common
+
+import "fmt"
+
+// MarketStatus is the market status of the underlying asset of a report, such as the v4 Data MarketStatus.
+type MarketStatus uint32
+
+// Market statuses
+const (
+	MarketStatusUnknown MarketStatus = iota // Market status not known
+	MarketStatusClosed                      // Market closed, prices are carried over from the last open market
+	MarketStatusOpen                        // Market open
+)
+
+// String returns the lowercase name of the market status.
+func (s MarketStatus) String() string {
+	switch s {
+	case MarketStatusUnknown:
+		return "unknown"
+	case MarketStatusClosed:
+		return "closed"
+	case MarketStatusOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("MarketStatus(%d)", uint32(s))
+	}
+}
+
+// IsOpen reports whether the market is open.
+func (s MarketStatus) IsOpen() bool {
+	return s == MarketStatusOpen
+}
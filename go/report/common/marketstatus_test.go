@@ -0,0 +1,26 @@
+package common
+
+import "testing"
+
+func TestMarketStatus(t *testing.T) {
+	tests := []struct {
+		status MarketStatus
+		name   string
+		open   bool
+	}{
+		{status: MarketStatusUnknown, name: "unknown"},
+		{status: MarketStatusClosed, name: "closed"},
+		{status: MarketStatusOpen, name: "open", open: true},
+		{status: 7, name: "MarketStatus(7)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.String(); got != tt.name {
+				t.Errorf("String() = %s, want %s", got, tt.name)
+			}
+			if got := tt.status.IsOpen(); got != tt.open {
+				t.Errorf("IsOpen() = %v, want %v", got, tt.open)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package report
+
+import (
+	"cmp"
+	"encoding/binary"
+)
+
+// ReportContext is the OCR report context of a report, see ParseReportContext.
+type ReportContext struct {
+	ConfigDigest [32]byte // Digest of the DON configuration the report was generated with
+	Epoch        uint32   // OCR epoch the report was generated in
+	Round        uint8    // OCR round within the epoch
+	ExtraHash    [32]byte // Hash of extra data, unused by most reports
+}
+
+// ParseReportContext parses the raw report context of a Report: the config digest in the first word,
+// the epoch and round in the last 5 bytes of the second word, big endian, and the extra hash
+// in the third word.
+func ParseReportContext(raw [3][32]byte) (c ReportContext) {
+	c.ConfigDigest = raw[0]
+	c.Epoch = binary.BigEndian.Uint32(raw[1][27:31])
+	c.Round = raw[1][31]
+	c.ExtraHash = raw[2]
+	return c
+}
+
+// EpochAndRoundPacked returns the epoch and round as the 40 bits OCR epoch and round counter,
+// the epoch in the upper 32 bits and the round in the lower 8 bits.
+func (c ReportContext) EpochAndRoundPacked() uint64 {
+	return uint64(c.Epoch)<<8 | uint64(c.Round)
+}
+
+// Context returns the parsed report context of the report.
+func (r *Report[T]) Context() ReportContext {
+	return ParseReportContext(r.ReportContext)
+}
+
+// ConfigDigest returns the digest of the DON configuration the report was generated with.
+func (r *Report[T]) ConfigDigest() [32]byte {
+	return r.ReportContext[0]
+}
+
+// EpochAndRound returns the OCR epoch and round the report was generated in.
+func (r *Report[T]) EpochAndRound() (epoch uint32, round uint8) {
+	c := r.Context()
+	return c.Epoch, c.Round
+}
+
+// ExtraHash returns the hash of the extra data of the report context.
+func (r *Report[T]) ExtraHash() [32]byte {
+	return r.ReportContext[2]
+}
+
+// Compare returns -1, 0 or +1 if the report context epoch and round are respectively before,
+// equal or after the other report context epoch and round. Epochs and rounds are only
+// ordered within the same ConfigDigest, an epoch regression of a DON configuration
+// is a report context comparing before a previous one with the same ConfigDigest.
+func (c ReportContext) Compare(o ReportContext) int {
+	if r := cmp.Compare(c.Epoch, o.Epoch); r != 0 {
+		return r
+	}
+	return cmp.Compare(c.Round, o.Round)
+}
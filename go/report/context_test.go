@@ -0,0 +1,54 @@
+package report
+
+import "testing"
+
+func TestParseReportContext(t *testing.T) {
+	raw := [3][32]byte{{0x00, 0x01, 0xaa}, {}, {0xff}}
+	raw[1][27], raw[1][28], raw[1][29], raw[1][30], raw[1][31] = 0x01, 0x02, 0x03, 0x04, 0x05
+
+	got := ParseReportContext(raw)
+	want := ReportContext{ConfigDigest: raw[0], Epoch: 0x01020304, Round: 5, ExtraHash: raw[2]}
+	if got != want {
+		t.Errorf("ParseReportContext() = %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		a, b ReportContext
+		want int
+	}{
+		{name: "equal", a: ReportContext{Epoch: 2, Round: 1}, b: ReportContext{Epoch: 2, Round: 1}, want: 0},
+		{name: "epoch before", a: ReportContext{Epoch: 1, Round: 9}, b: ReportContext{Epoch: 2, Round: 1}, want: -1},
+		{name: "round after", a: ReportContext{Epoch: 2, Round: 2}, b: ReportContext{Epoch: 2, Round: 1}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Compare(tt.b); got != tt.want {
+				t.Errorf("Compare() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_Context(t *testing.T) {
+	r := *v3Report
+	r.ReportContext = [3][32]byte{{0xcd}, {}, {0xef}}
+	r.ReportContext[1][27], r.ReportContext[1][30], r.ReportContext[1][31] = 0x01, 0x02, 0x03
+
+	want := ReportContext{ConfigDigest: [32]byte{0xcd}, Epoch: 0x01000002, Round: 3, ExtraHash: [32]byte{0xef}}
+	if got := r.Context(); got != want {
+		t.Errorf("Context() = %+v, want %+v", got, want)
+	}
+	if got := r.ConfigDigest(); got != want.ConfigDigest {
+		t.Errorf("ConfigDigest() = %x, want %x", got, want.ConfigDigest)
+	}
+	if epoch, round := r.EpochAndRound(); epoch != want.Epoch || round != want.Round {
+		t.Errorf("EpochAndRound() = %d, %d, want %d, %d", epoch, round, want.Epoch, want.Round)
+	}
+	if got := r.ExtraHash(); got != want.ExtraHash {
+		t.Errorf("ExtraHash() = %x, want %x", got, want.ExtraHash)
+	}
+	if got := want.EpochAndRoundPacked(); got != 0x0100000203 {
+		t.Errorf("ReportContext.EpochAndRoundPacked() = %#x, want %#x", got, uint64(0x0100000203))
+	}
+}
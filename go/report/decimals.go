@@ -0,0 +1,144 @@
+package report
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// DefaultDecimals is the number of decimals of the report values of most feeds.
+const DefaultDecimals = 18
+
+// floatPrec is the precision in bits of the big.Float scaled values, above the 192 bits of the report values.
+const floatPrec = 256
+
+// DecimalsRegistry maps feed IDs to the number of decimals of their report values
+// and scales the raw report values accordingly.
+// A DecimalsRegistry is safe for concurrent usage.
+type DecimalsRegistry struct {
+	mu       sync.RWMutex
+	decimals map[feed.ID]uint8
+	fallback *uint8
+}
+
+// NewDecimalsRegistry creates a DecimalsRegistry with the given feed decimals.
+func NewDecimalsRegistry(decimals map[feed.ID]uint8) (r *DecimalsRegistry) {
+	r = &DecimalsRegistry{decimals: make(map[feed.ID]uint8, len(decimals))}
+	for id, d := range decimals {
+		r.decimals[id] = d
+	}
+	return r
+}
+
+// Set sets the decimals of the feed.
+func (r *DecimalsRegistry) Set(id feed.ID, decimals uint8) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decimals[id] = decimals
+}
+
+// SetDefault sets the decimals of the feeds not in the registry.
+// Without a default, scaling the values of unknown feeds fails.
+func (r *DecimalsRegistry) SetDefault(decimals uint8) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = &decimals
+}
+
+// Decimals returns the decimals of the feed.
+func (r *DecimalsRegistry) Decimals(id feed.ID) (decimals uint8, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if decimals, ok = r.decimals[id]; ok {
+		return decimals, true
+	}
+	if r.fallback != nil {
+		return *r.fallback, true
+	}
+	return 0, false
+}
+
+// decimalsOf returns the decimals of the feed, failing for feeds unknown to the registry.
+func (r *DecimalsRegistry) decimalsOf(id feed.ID) (decimals uint8, err error) {
+	decimals, ok := r.Decimals(id)
+	if !ok {
+		return 0, fmt.Errorf("report: unknown decimals for feed %s", id.String())
+	}
+	return decimals, nil
+}
+
+// Scale returns the raw report value of the feed scaled by its decimals, see ScaleValue.
+func (r *DecimalsRegistry) Scale(id feed.ID, v *big.Int) (scaled *big.Rat, err error) {
+	decimals, err := r.decimalsOf(id)
+	if err != nil {
+		return nil, err
+	}
+	return ScaleValue(v, decimals), nil
+}
+
+// FormatValue returns the raw report value of the feed scaled by its decimals
+// formatted as a decimal number, see FormatScaled.
+func (r *DecimalsRegistry) FormatValue(id feed.ID, v *big.Int) (s string, err error) {
+	decimals, err := r.decimalsOf(id)
+	if err != nil {
+		return "", err
+	}
+	return FormatScaled(v, decimals), nil
+}
+
+// Float64 returns the raw report value of the feed scaled by its decimals as the nearest float64,
+// see ScaleFloat64.
+func (r *DecimalsRegistry) Float64(id feed.ID, v *big.Int) (f float64, exact bool, err error) {
+	decimals, err := r.decimalsOf(id)
+	if err != nil {
+		return 0, false, err
+	}
+	f, exact = ScaleFloat64(v, decimals)
+	return f, exact, nil
+}
+
+// Float returns the raw report value of the feed scaled by its decimals as a big.Float, see ScaleFloat.
+func (r *DecimalsRegistry) Float(id feed.ID, v *big.Int) (f *big.Float, err error) {
+	decimals, err := r.decimalsOf(id)
+	if err != nil {
+		return nil, err
+	}
+	return ScaleFloat(v, decimals), nil
+}
+
+// ScaleFloat64 returns the raw report value divided by 10^decimals as the nearest float64,
+// exact reports whether the value is represented exactly. Use DefaultDecimals for most feeds.
+func ScaleFloat64(v *big.Int, decimals uint8) (f float64, exact bool) {
+	return ScaleValue(v, decimals).Float64()
+}
+
+// ScaleFloat returns the raw report value divided by 10^decimals as a big.Float of 256 bits precision.
+func ScaleFloat(v *big.Int, decimals uint8) (f *big.Float) {
+	return new(big.Float).SetPrec(floatPrec).SetRat(ScaleValue(v, decimals))
+}
+
+// FormatScaled returns the raw report value divided by 10^decimals formatted as a decimal number,
+// e.g. "3250.5" for 3250500000000000000000 with 18 decimals, without trailing zero decimals,
+// "" for a nil value.
+func FormatScaled(v *big.Int, decimals uint8) (s string) {
+	if v == nil {
+		return ""
+	}
+	s = ScaleValue(v, decimals).FloatString(int(decimals))
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// ScaleValue returns the raw report value divided by 10^decimals, zero for a nil value.
+func ScaleValue(v *big.Int, decimals uint8) (scaled *big.Rat) {
+	if v == nil {
+		return new(big.Rat)
+	}
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Rat).SetFrac(v, denom)
+}
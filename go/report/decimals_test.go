@@ -0,0 +1,93 @@
+package report
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+func TestDecimalsRegistry(t *testing.T) {
+	eth, btc, unknown := feed.ID{0, 3, 1}, feed.ID{0, 3, 2}, feed.ID{0, 3, 3}
+	r := NewDecimalsRegistry(map[feed.ID]uint8{eth: DefaultDecimals})
+	r.Set(btc, 8)
+
+	tests := []struct {
+		name string
+		id   feed.ID
+		v    *big.Int
+		want string
+	}{
+		{name: "18 decimals", id: eth, v: big.NewInt(3_250_500_000_000_000_000), want: "3.2505"},
+		{name: "8 decimals", id: btc, v: big.NewInt(-6_512_345_678_901), want: "-65123.45678901"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.FormatValue(tt.id, tt.v)
+			if err != nil {
+				t.Fatalf("FormatValue() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatValue() = %s, want %s", got, tt.want)
+			}
+
+			scaled, err := r.Scale(tt.id, tt.v)
+			if err != nil {
+				t.Fatalf("Scale() error = %v", err)
+			}
+			if want, _ := new(big.Rat).SetString(tt.want); scaled.Cmp(want) != 0 {
+				t.Errorf("Scale() = %s, want %s", scaled, want)
+			}
+		})
+	}
+
+	if _, err := r.Scale(unknown, big.NewInt(1)); err == nil {
+		t.Errorf("expected error scaling a value of an unknown feed")
+	}
+
+	r.SetDefault(2)
+	if got, err := r.FormatValue(unknown, big.NewInt(12345)); err != nil || got != "123.45" {
+		t.Errorf("FormatValue() = %s, %v, want 123.45", got, err)
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name       string
+		v          *big.Int
+		decimals   uint8
+		wantString string
+		wantFloat  float64
+		wantExact  bool
+	}{
+		{name: "price", v: big.NewInt(3_250_500_000_000_000_000), decimals: DefaultDecimals, wantString: "3.2505", wantFloat: 3.2505},
+		{name: "integer", v: big.NewInt(-200), decimals: 2, wantString: "-2", wantFloat: -2, wantExact: true},
+		{name: "zero", v: big.NewInt(0), decimals: DefaultDecimals, wantString: "0", wantFloat: 0, wantExact: true},
+		{name: "no decimals", v: big.NewInt(1000), wantString: "1000", wantFloat: 1000, wantExact: true},
+		{name: "nil", v: nil, decimals: DefaultDecimals, wantString: "", wantFloat: 0, wantExact: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatScaled(tt.v, tt.decimals); got != tt.wantString {
+				t.Errorf("FormatScaled() = %s, want %s", got, tt.wantString)
+			}
+			if got, exact := ScaleFloat64(tt.v, tt.decimals); got != tt.wantFloat || exact != tt.wantExact {
+				t.Errorf("ScaleFloat64() = %g, %t, want %g, %t", got, exact, tt.wantFloat, tt.wantExact)
+			}
+			if got, _ := ScaleFloat(tt.v, tt.decimals).Float64(); got != tt.wantFloat {
+				t.Errorf("ScaleFloat() = %g, want %g", got, tt.wantFloat)
+			}
+		})
+	}
+
+	r := NewDecimalsRegistry(map[feed.ID]uint8{{0, 3, 1}: 8})
+	if f, _, err := r.Float64(feed.ID{0, 3, 1}, big.NewInt(6_512_345_678_901)); err != nil || f != 65123.45678901 {
+		t.Errorf("Float64() = %g, %v, want 65123.45678901", f, err)
+	}
+	if s, err := r.FormatValue(feed.ID{0, 3, 1}, nil); err != nil || s != "" {
+		t.Errorf("FormatValue(nil) = %q, %v, want empty", s, err)
+	}
+	if _, err := r.Float(feed.ID{0, 3, 2}, big.NewInt(1)); err == nil {
+		t.Errorf("expected error scaling a value of an unknown feed")
+	}
+}
@@ -0,0 +1,252 @@
+package report
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi"
+)
+
+// wordSize is the size of an ABI word
+const wordSize = 32
+
+// envelopeHeadSize is the size of the static head of the report envelope:
+// the report context, the report blob and signatures offsets and the raw vs.
+const envelopeHeadSize = 7 * wordSize
+
+var (
+	bigType = reflect.TypeOf((*big.Int)(nil))
+	tt256   = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// fieldDecoder decodes a data schema word into a struct field.
+type fieldDecoder struct {
+	name  string
+	index int  // Struct field index
+	kind  byte // ABI type kind
+	size  int  // Bit size of integers, byte size of fixed bytes
+}
+
+// Decoder decodes reports into caller owned reports without the per call ABI unpack allocations of Decode,
+// for applications decoding reports at a high rate.
+// A Decoder is safe for concurrent use, a report decoded into must not be shared between goroutines.
+type Decoder[T Data] struct {
+	fields []fieldDecoder
+	err    error
+}
+
+// NewDecoder returns a Decoder of the data type T.
+func NewDecoder[T Data]() *Decoder[T] {
+	var zero T
+	d := &Decoder[T]{}
+	d.fields, d.err = fieldDecoders(reflect.TypeOf(zero), zero.Schema())
+	return d
+}
+
+// Decode decodes the report serialized bytes and its data into a new report.
+func (d *Decoder[T]) Decode(fullReport []byte) (r *Report[T], err error) {
+	r = &Report[T]{}
+	if err = d.DecodeInto(r, fullReport); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DecodeInto decodes the report serialized bytes and its data into dst, the decoded report is equal to
+// the one returned by Decode. The ReportBlob, RawRs and RawSs buffers and the big integers of dst are reused,
+// once warmed up decoding a report of the same size allocates nothing.
+// Values previously read from dst, such as its big integers, must not be retained across calls.
+// dst is left partially decoded on errors.
+func (d *Decoder[T]) DecodeInto(dst *Report[T], fullReport []byte) (err error) {
+	if d.err != nil {
+		return d.err
+	}
+
+	if err = decodeEnvelope(dst, fullReport); err != nil {
+		recordFailure[T](FailureUnpack, fullReport, nil)
+		return fmt.Errorf("report: failed to unpack: %s", err)
+	}
+	if err = d.decodeData(&dst.Data, dst.ReportBlob); err != nil {
+		recordFailure[T](FailureUnpackData, fullReport, dst.ReportBlob)
+		return fmt.Errorf("report: failed to unpack data: %s", err)
+	}
+	return nil
+}
+
+// decodeEnvelope decodes the report envelope into dst, reusing its buffers.
+func decodeEnvelope[T Data](dst *Report[T], b []byte) (err error) {
+	if len(b) < envelopeHeadSize {
+		return fmt.Errorf("report too short: %d bytes", len(b))
+	}
+	for x := range dst.ReportContext {
+		copy(dst.ReportContext[x][:], b[x*wordSize:])
+	}
+	copy(dst.RawVs[:], b[6*wordSize:])
+
+	blob, err := readDynamic(b, 3*wordSize, 1)
+	if err != nil {
+		return fmt.Errorf("reportBlob: %w", err)
+	}
+	dst.ReportBlob = append(dst.ReportBlob[:0], blob...)
+
+	if dst.RawRs, err = readWords(dst.RawRs, b, 4*wordSize); err != nil {
+		return fmt.Errorf("rawRs: %w", err)
+	}
+	if dst.RawSs, err = readWords(dst.RawSs, b, 5*wordSize); err != nil {
+		return fmt.Errorf("rawSs: %w", err)
+	}
+	return nil
+}
+
+// readDynamic returns the elements of the dynamic value whose offset is at the head position,
+// elements are elemSize bytes long.
+func readDynamic(b []byte, head int, elemSize int) (elems []byte, err error) {
+	offset, err := readInt(b, head)
+	if err != nil {
+		return nil, fmt.Errorf("offset: %w", err)
+	}
+	n, err := readInt(b, offset)
+	if err != nil {
+		return nil, fmt.Errorf("length: %w", err)
+	}
+	start := offset + wordSize
+	if n > (len(b)-start)/elemSize {
+		return nil, fmt.Errorf("length %d overflows %d bytes", n, len(b)-start)
+	}
+	return b[start : start+n*elemSize], nil
+}
+
+// readWords appends the words of the dynamic array whose offset is at the head position to dst[:0].
+func readWords(dst [][32]byte, b []byte, head int) ([][32]byte, error) {
+	words, err := readDynamic(b, head, wordSize)
+	if err != nil {
+		return dst, err
+	}
+	dst = dst[:0]
+	for x := 0; x < len(words); x += wordSize {
+		dst = append(dst, [32]byte(words[x:x+wordSize]))
+	}
+	return dst, nil
+}
+
+// readInt reads the word at pos as an offset or length.
+func readInt(b []byte, pos int) (n int, err error) {
+	if pos < 0 || pos > len(b)-wordSize {
+		return 0, fmt.Errorf("position %d out of %d bytes", pos, len(b))
+	}
+	w := b[pos : pos+wordSize]
+	for _, c := range w[:wordSize-8] {
+		if c != 0 {
+			return 0, fmt.Errorf("invalid word at %d", pos)
+		}
+	}
+	u := binary.BigEndian.Uint64(w[wordSize-8:])
+	if u > uint64(len(b)) {
+		return 0, fmt.Errorf("%d overflows %d bytes", u, len(b))
+	}
+	return int(u), nil
+}
+
+// decodeData decodes the static data schema fields of the report blob into dst.
+func (d *Decoder[T]) decodeData(dst *T, blob []byte) (err error) {
+	if len(blob) < len(d.fields)*wordSize {
+		return fmt.Errorf("data too short: %d bytes, want %d", len(blob), len(d.fields)*wordSize)
+	}
+
+	v := reflect.ValueOf(dst).Elem()
+	for x, f := range d.fields {
+		word := blob[x*wordSize : (x+1)*wordSize]
+		field := v.Field(f.index)
+
+		switch {
+		case f.kind == abi.FixedBytesTy:
+			copy(field.Bytes(), word)
+		case f.size > 64:
+			i, _ := field.Interface().(*big.Int)
+			if i == nil {
+				i = new(big.Int)
+				field.Set(reflect.ValueOf(i))
+			}
+			i.SetBytes(word)
+			if f.kind == abi.IntTy && word[0]&0x80 != 0 {
+				i.Sub(i, tt256)
+			}
+		default:
+			if err = setInteger(field, f, word); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setInteger sets the integer field of at most 64 bits from the word.
+func setInteger(field reflect.Value, f fieldDecoder, word []byte) error {
+	u := binary.BigEndian.Uint64(word[wordSize-8:])
+	pad := byte(0)
+	if f.kind == abi.IntTy && int64(u) < 0 {
+		pad = 0xff
+	}
+	for _, c := range word[:wordSize-8] {
+		if c != pad {
+			return fmt.Errorf("%s: integer overflows %d bits", f.name, f.size)
+		}
+	}
+
+	if f.kind == abi.IntTy {
+		if field.OverflowInt(int64(u)) {
+			return fmt.Errorf("%s: integer overflows %d bits", f.name, f.size)
+		}
+		field.SetInt(int64(u))
+		return nil
+	}
+	if field.OverflowUint(u) {
+		return fmt.Errorf("%s: integer overflows %d bits", f.name, f.size)
+	}
+	field.SetUint(u)
+	return nil
+}
+
+// fieldDecoders maps the data schema arguments to the fields of the data struct type,
+// matching the fields names or abi tags as abi.Arguments.Copy does.
+func fieldDecoders(t reflect.Type, args abi.Arguments) (fields []fieldDecoder, err error) {
+	tags := map[string]int{}
+	for x := 0; x < t.NumField(); x++ {
+		if tag := t.Field(x).Tag.Get("abi"); tag != "" {
+			tags[tag] = x
+		}
+	}
+
+	for _, arg := range args {
+		index, ok := tags[arg.Name]
+		if !ok {
+			sf, found := t.FieldByName(abi.ToCamelCase(arg.Name))
+			if !found || len(sf.Index) != 1 {
+				return nil, fmt.Errorf("report: %s field %s not found", t, arg.Name)
+			}
+			index = sf.Index[0]
+		}
+
+		f := fieldDecoder{name: arg.Name, index: index, kind: arg.Type.T, size: arg.Type.Size}
+		ft := t.Field(index).Type
+		switch {
+		case f.kind == abi.FixedBytesTy:
+			ok = ft.Kind() == reflect.Array && ft.Elem().Kind() == reflect.Uint8 && ft.Len() == f.size
+		case (f.kind == abi.IntTy || f.kind == abi.UintTy) && f.size > 64:
+			ok = ft == bigType
+		case f.kind == abi.IntTy:
+			ok = ft.Kind() >= reflect.Int && ft.Kind() <= reflect.Int64 && ft.Bits() >= f.size
+		case f.kind == abi.UintTy:
+			ok = ft.Kind() >= reflect.Uint && ft.Kind() <= reflect.Uint64 && ft.Bits() >= f.size
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, fmt.Errorf("report: %s field %s of type %s is not decodable from %s", t, arg.Name, ft, arg.Type.String())
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
@@ -0,0 +1,137 @@
+package report
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
+	v2 "github.com/smartcontractkit/data-streams-sdk/go/report/v2"
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+func TestDecoder(t *testing.T) {
+	negative := *v3Report
+	negative.Data.BenchmarkPrice = big.NewInt(-100)
+	negative.Data.Bid = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 191))
+
+	testDecoder(t, v1Report)
+	testDecoder(t, v2Report)
+	testDecoder(t, v3Report)
+	testDecoder(t, &negative)
+	testDecoder(t, v4Report)
+}
+
+func testDecoder[T Data](t *testing.T, r *Report[T]) {
+	t.Helper()
+	b, err := Encode(r)
+	if err != nil {
+		t.Fatalf("failed to encode report: %s", err)
+	}
+	want, err := Decode[T](b)
+	if err != nil {
+		t.Fatalf("failed to decode report: %s", err)
+	}
+
+	d := NewDecoder[T]()
+	got, err := d.Decode(b)
+	if err != nil {
+		t.Fatalf("Decoder.Decode() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decoder.Decode() = %#v, want %#v", got, want)
+	}
+
+	// decoding into a used report overwrites it
+	if err = d.DecodeInto(got, b); err != nil {
+		t.Fatalf("Decoder.DecodeInto() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decoder.DecodeInto() = %#v, want %#v", got, want)
+	}
+}
+
+func TestDecoder_DecodeIntoAllocs(t *testing.T) {
+	b, err := Encode(v4Report)
+	if err != nil {
+		t.Fatalf("failed to encode report: %s", err)
+	}
+
+	d := NewDecoder[v4.Data]()
+	dst := &Report[v4.Data]{}
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := d.DecodeInto(dst, b); err != nil {
+			t.Fatalf("Decoder.DecodeInto() error = %s", err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Decoder.DecodeInto() allocs = %v, want 0", allocs)
+	}
+}
+
+func TestDecoder_Errors(t *testing.T) {
+	b, err := Encode(v2Report)
+	if err != nil {
+		t.Fatalf("failed to encode report: %s", err)
+	}
+	overflow := append([]byte{}, b...)
+	overflow[envelopeHeadSize+wordSize+wordSize+24] = 1 // the validFromTimestamp word of the report blob
+
+	tests := []struct {
+		name    string
+		b       []byte
+		wantErr string
+	}{
+		{name: "empty", b: nil, wantErr: "report: failed to unpack: report too short"},
+		{name: "truncated", b: b[:len(b)-wordSize], wantErr: "report: failed to unpack: rawSs"},
+		{name: "short data", b: mustEncode(t, &Report[v1.Data]{ReportBlob: []byte{1}, Data: v1Data}),
+			wantErr: "report: failed to unpack data: data too short"},
+		{name: "overflow", b: overflow, wantErr: "report: failed to unpack data: validFromTimestamp: integer overflows 32 bits"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDecoder[v2.Data]().Decode(tt.b)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("Decoder.Decode() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mustEncode[T Data](t *testing.T, r *Report[T]) []byte {
+	t.Helper()
+	b, err := schema.Pack(r.ReportContext, r.ReportBlob, r.RawRs, r.RawSs, r.RawVs)
+	if err != nil {
+		t.Fatalf("failed to encode report: %s", err)
+	}
+	return b
+}
+
+func BenchmarkDecode(b *testing.B) {
+	fullReport, err := Encode(v4Report)
+	if err != nil {
+		b.Fatalf("failed to encode report: %s", err)
+	}
+	b.ReportAllocs()
+	for x := 0; x < b.N; x++ {
+		if _, err = Decode[v4.Data](fullReport); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecoder_DecodeInto(b *testing.B) {
+	fullReport, err := Encode(v4Report)
+	if err != nil {
+		b.Fatalf("failed to encode report: %s", err)
+	}
+	d := NewDecoder[v4.Data]()
+	dst := &Report[v4.Data]{}
+	b.ReportAllocs()
+	for x := 0; x < b.N; x++ {
+		if err = d.DecodeInto(dst, fullReport); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,3 +1,8 @@
 // Package report implements the Streams report schema, type and has sub packages
 // that implements the report data schema and types.
+//
+// Reports are decoded with the go-ethereum ABI implementation by default.
+// Building with the nogeth tag selects a lightweight ABI implementation
+// limited to the types used by the report schemas, removing go-ethereum
+// from the resulting binary.
 package report
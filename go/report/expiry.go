@@ -0,0 +1,16 @@
+package report
+
+import "time"
+
+// Freshness gives access to the expiry and staleness helpers implemented by every vN.Data type,
+// so applications can reject stale data consistently across data versions, e.g. on the AnyData
+// of the reports returned by DecodeAny.
+type Freshness interface {
+	// IsExpired reports whether the data is expired at now, after its ExpiresAt.
+	// Data versions without an expiry never expire.
+	IsExpired(now time.Time) bool
+	// ValidAt reports whether the data is valid at t, not before its ValidFromTimestamp and not expired.
+	ValidAt(t time.Time) bool
+	// Age returns the time elapsed since the data ObservationsTimestamp.
+	Age() time.Duration
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+)
+
+func TestFreshness(t *testing.T) {
+	expiring := v3.Data{ValidFromTimestamp: 100, ObservationsTimestamp: 110, ExpiresAt: 200}
+	noExpiry := v1.Data{ObservationsTimestamp: 110}
+
+	tests := []struct {
+		name        string
+		data        Freshness
+		at          int64
+		wantExpired bool
+		wantValid   bool
+	}{
+		{name: "before valid from", data: expiring, at: 99},
+		{name: "valid from", data: expiring, at: 100, wantValid: true},
+		{name: "expires at", data: expiring, at: 200, wantValid: true},
+		{name: "expired", data: expiring, at: 201, wantExpired: true},
+		{name: "no expiry before observations", data: noExpiry, at: 109},
+		{name: "no expiry", data: noExpiry, at: 1 << 40, wantValid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := time.Unix(tt.at, 0)
+			if got := tt.data.IsExpired(at); got != tt.wantExpired {
+				t.Errorf("IsExpired() = %t, want %t", got, tt.wantExpired)
+			}
+			if got := tt.data.ValidAt(at); got != tt.wantValid {
+				t.Errorf("ValidAt() = %t, want %t", got, tt.wantValid)
+			}
+		})
+	}
+
+	fresh := v3.Data{ObservationsTimestamp: uint32(time.Now().Unix() - 5)}
+	if age := fresh.Age(); age < 5*time.Second || age > 7*time.Second {
+		t.Errorf("Age() = %s, want about 5s", age)
+	}
+}
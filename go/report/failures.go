@@ -0,0 +1,101 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// maxFailureSamples is the number of payload hashes sampled per failure kind
+const maxFailureSamples = 5
+
+// Decode failure error classes
+const (
+	FailureUnpack        = "unpack"         // report envelope ABI unpack failure
+	FailureCopy          = "copy"           // report envelope copy failure
+	FailureUnpackData    = "unpack_data"    // report data ABI unpack failure
+	FailureCopyData      = "copy_data"      // report data copy failure
+	FailureInvalid       = "invalid"        // DecodeStrict validation failure
+	FailureTruncated     = "truncated"      // CheckLength failure of a report shorter than expected
+	FailureTrailingBytes = "trailing_bytes" // CheckLength failure of a report longer than expected
+)
+
+// DecodeFailure aggregates Decode failures by feed version, data type and error class.
+type DecodeFailure struct {
+	Version feed.FeedVersion // Feed version read from the report data, 0 if unknown
+	Type    string           // Data type the report was decoded into
+	Class   string           // Error class
+	Count   uint64           // Number of failures
+	Samples []string         // Hex encoded sha256 hashes of a sample of failed payloads
+}
+
+type failureKey struct {
+	version feed.FeedVersion
+	typ     string
+	class   string
+}
+
+var decodeFailures = struct {
+	mu sync.Mutex
+	m  map[failureKey]*DecodeFailure
+}{m: make(map[failureKey]*DecodeFailure)}
+
+// recordFailure records a Decode failure of the payload into the data type T.
+func recordFailure[T Data](class string, fullReport []byte, reportBlob []byte) {
+	var version feed.FeedVersion
+	// the feed ID is the first word of every report data version
+	if len(reportBlob) >= 2 {
+		version = feed.FeedVersion(binary.BigEndian.Uint16(reportBlob[:2]))
+	}
+
+	var t T
+	k := failureKey{version: version, typ: fmt.Sprintf("%T", t), class: class}
+	h := sha256.Sum256(fullReport)
+
+	decodeFailures.mu.Lock()
+	defer decodeFailures.mu.Unlock()
+	f, ok := decodeFailures.m[k]
+	if !ok {
+		f = &DecodeFailure{Version: k.version, Type: k.typ, Class: k.class}
+		decodeFailures.m[k] = f
+	}
+	f.Count++
+	if len(f.Samples) < maxFailureSamples {
+		f.Samples = append(f.Samples, hex.EncodeToString(h[:]))
+	}
+}
+
+// DecodeFailures returns the Decode failures recorded since the process start
+// or the last ResetDecodeFailures call.
+func DecodeFailures() (r []DecodeFailure) {
+	decodeFailures.mu.Lock()
+	for _, f := range decodeFailures.m {
+		c := *f
+		c.Samples = append([]string(nil), f.Samples...)
+		r = append(r, c)
+	}
+	decodeFailures.mu.Unlock()
+
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Version != r[j].Version {
+			return r[i].Version < r[j].Version
+		}
+		if r[i].Type != r[j].Type {
+			return r[i].Type < r[j].Type
+		}
+		return r[i].Class < r[j].Class
+	})
+	return r
+}
+
+// ResetDecodeFailures clears the recorded Decode failures.
+func ResetDecodeFailures() {
+	decodeFailures.mu.Lock()
+	decodeFailures.m = make(map[failureKey]*DecodeFailure)
+	decodeFailures.mu.Unlock()
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+)
+
+func TestDecodeFailures(t *testing.T) {
+	ResetDecodeFailures()
+	defer ResetDecodeFailures()
+
+	// a v4 report blob is too short to be decoded as v3 data
+	b, err := schema.Pack(v4Report.ReportContext, v4Report.ReportBlob, v4Report.RawRs, v4Report.RawSs, v4Report.RawVs)
+	if err != nil {
+		t.Fatalf("failed to encode report: %s", err)
+	}
+
+	for x := 0; x < maxFailureSamples+1; x++ {
+		if _, err = Decode[v3.Data](b); err == nil {
+			t.Fatalf("expected error decoding v4 report as v3")
+		}
+	}
+
+	if _, err = Decode[v1.Data]([]byte{1, 2, 3}); err == nil {
+		t.Fatalf("expected error decoding invalid payload")
+	}
+
+	failures := DecodeFailures()
+	if len(failures) != 2 {
+		t.Fatalf("expected 2 failure kinds, got %#v", failures)
+	}
+
+	if f := failures[0]; f.Version != 0 || f.Class != FailureUnpack || f.Type != "v1.Data" || f.Count != 1 {
+		t.Errorf("unexpected unpack failure %#v", f)
+	}
+
+	f := failures[1]
+	if f.Version != feed.FeedVersion4 || f.Class != FailureUnpackData || f.Type != "v3.Data" {
+		t.Errorf("unexpected data unpack failure %#v", f)
+	}
+	if f.Count != maxFailureSamples+1 || len(f.Samples) != maxFailureSamples {
+		t.Errorf("expected %d failures and %d samples, got %d and %d",
+			maxFailureSamples+1, maxFailureSamples, f.Count, len(f.Samples))
+	}
+}
@@ -0,0 +1,7 @@
+package report
+
+// The report data version packages, the Data constraint, the feed versions
+// and the reports protobuf definitions are generated from schemas.json, see internal/gen.
+// The test vectors shared with the other SDKs are generated from it too, see internal/vectors.
+//go:generate go run ./internal/gen -schemas schemas.json
+//go:generate go run ./internal/vectors -schemas schemas.json -out testdata/vectors.json
@@ -0,0 +1,31 @@
+package report
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Hash returns the keccak256 hashes of the report blob and of the full report payload, of any data version.
+// The blob hash identifies the report data whatever its signatures, so it deduplicates the same report
+// received with different signers or from different origins, while the full hash identifies the exact
+// payload, e.g. the one submitted onchain for verification.
+func Hash(fullReport []byte) (blobHash, fullHash [32]byte, err error) {
+	values, err := schema.Unpack(fullReport)
+	if err != nil {
+		return blobHash, fullHash, fmt.Errorf("report: failed to unpack: %s", err)
+	}
+	var e envelope
+	if err = schema.Copy(&e, values); err != nil {
+		return blobHash, fullHash, fmt.Errorf("report: failed to copy: %s", err)
+	}
+
+	return keccak256(e.ReportBlob), keccak256(fullReport), nil
+}
+
+func keccak256(b []byte) (hash [32]byte) {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(b)
+	h.Sum(hash[:0])
+	return hash
+}
@@ -0,0 +1,39 @@
+package report
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	if got, want := keccak256(nil), "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"; hex.EncodeToString(got[:]) != want {
+		t.Fatalf("keccak256() = %x, want %s", got, want)
+	}
+
+	b := mustEncode(t, v3Report)
+	blobHash, fullHash, err := Hash(b)
+	if err != nil {
+		t.Fatalf("Hash() error = %s", err)
+	}
+	if want := keccak256(mustPackData(v3Data)); blobHash != want {
+		t.Errorf("Hash() blob hash = %x, want %x", blobHash, want)
+	}
+	if want := keccak256(b); fullHash != want {
+		t.Errorf("Hash() full hash = %x, want %x", fullHash, want)
+	}
+
+	// the same report with other signatures has the same blob hash
+	r := *v3Report
+	r.RawRs, r.RawSs = [][32]byte{{0x01}}, [][32]byte{{0x02}}
+	otherBlobHash, otherFullHash, err := Hash(mustEncode(t, &r))
+	if err != nil {
+		t.Fatalf("Hash() error = %s", err)
+	}
+	if otherBlobHash != blobHash || otherFullHash == fullHash {
+		t.Errorf("Hash() of other signatures = %x, %x, want blob hash %x and another full hash", otherBlobHash, otherFullHash, blobHash)
+	}
+
+	if _, _, err = Hash([]byte{0x01}); err == nil {
+		t.Errorf("Hash() of an invalid report error = nil, want error")
+	}
+}
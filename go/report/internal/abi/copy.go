@@ -0,0 +1,50 @@
+package abi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Copy sets the fields of the struct pointed to by v to the unpacked values of the arguments, as Arguments.Copy
+// does, matching the fields tagged with `abi:"name"` or named after the camel cased argument names. Unlike
+// Arguments.Copy, values are converted to the named field types of the same kind, such as common.MarketStatus.
+func Copy(args Arguments, v any, values []any) error {
+	dst := reflect.ValueOf(v)
+	if dst.Kind() != reflect.Ptr || dst.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("abi: cannot copy values to %T, not a struct pointer", v)
+	}
+	dst = dst.Elem()
+	if len(values) != len(args) {
+		return fmt.Errorf("abi: %d values for %d arguments", len(values), len(args))
+	}
+
+	tagged := map[string]int{}
+	for x := 0; x < dst.NumField(); x++ {
+		if tag := dst.Type().Field(x).Tag.Get("abi"); tag != "" {
+			tagged[tag] = x
+		}
+	}
+
+	for x, arg := range args {
+		var field reflect.Value
+		if f, ok := tagged[arg.Name]; ok {
+			field = dst.Field(f)
+		} else {
+			field = dst.FieldByName(ToCamelCase(arg.Name))
+		}
+		src := reflect.ValueOf(values[x])
+		switch {
+		case !field.IsValid():
+			return fmt.Errorf("abi: field %s not found in %T", arg.Name, v)
+		case !src.IsValid():
+			return fmt.Errorf("abi: nil value for field %s", arg.Name)
+		case src.Type().AssignableTo(field.Type()):
+			field.Set(src)
+		case src.Type().ConvertibleTo(field.Type()) && src.Kind() == field.Kind():
+			field.Set(src.Convert(field.Type()))
+		default:
+			return fmt.Errorf("abi: cannot unmarshal %s in to %s", src.Type(), field.Type())
+		}
+	}
+	return nil
+}
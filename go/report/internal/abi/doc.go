@@ -0,0 +1,5 @@
+// Package abi selects the ABI implementation used to decode reports.
+// By default the go-ethereum accounts/abi package is used, building with
+// the nogeth tag selects the lightweight implementation in the lite package
+// which drops the go-ethereum dependency from the resulting binary.
+package abi
@@ -0,0 +1,31 @@
+//go:build !nogeth
+
+package abi
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+type (
+	Arguments          = abi.Arguments
+	Argument           = abi.Argument
+	Type               = abi.Type
+	ArgumentMarshaling = abi.ArgumentMarshaling
+)
+
+// Type kinds
+const (
+	IntTy        = abi.IntTy
+	UintTy       = abi.UintTy
+	FixedBytesTy = abi.FixedBytesTy
+)
+
+// NewType creates a new ABI type from its string representation.
+func NewType(t string, internalType string, components []ArgumentMarshaling) (typ Type, err error) {
+	return abi.NewType(t, internalType, components)
+}
+
+// ToCamelCase converts an ABI argument name to its Go struct field name.
+func ToCamelCase(input string) string {
+	return abi.ToCamelCase(input)
+}
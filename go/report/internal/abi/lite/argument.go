@@ -0,0 +1,422 @@
+package lite
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+var (
+	errInsufficientLength = errors.New("abi: cannot marshal in to go type: length insufficient")
+
+	tt256   = new(big.Int).Lsh(big.NewInt(1), 256)
+	bigType = reflect.TypeOf(&big.Int{})
+)
+
+// Argument is a named ABI argument.
+type Argument struct {
+	Name    string
+	Type    Type
+	Indexed bool
+}
+
+// Arguments is a list of ABI arguments encoded as a tuple.
+type Arguments []Argument
+
+// Unpack decodes the ABI encoded data into a list of Go values.
+func (arguments Arguments) Unpack(data []byte) (values []interface{}, err error) {
+	if len(data) == 0 && len(arguments) > 0 {
+		return nil, errors.New("abi: attempting to unmarshal an empty string while arguments are expected")
+	}
+
+	values = make([]interface{}, 0, len(arguments))
+	offset := 0
+	for _, arg := range arguments {
+		v, err := readValue(arg.Type, data, offset)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v.Interface())
+		offset += arg.Type.headSize()
+	}
+	return values, nil
+}
+
+// readValue reads a value of type t from the tuple encoded in data with its head at offset.
+func readValue(t Type, data []byte, offset int) (v reflect.Value, err error) {
+	if t.isDynamic() {
+		ptr, err := readLength(data, offset)
+		if err != nil {
+			return v, err
+		}
+
+		switch t.T {
+		case StringTy, BytesTy:
+			n, err := readLength(data, ptr)
+			if err != nil {
+				return v, err
+			}
+			if ptr+32+n > len(data) {
+				return v, errInsufficientLength
+			}
+			b := make([]byte, n)
+			copy(b, data[ptr+32:ptr+32+n])
+			if t.T == StringTy {
+				return reflect.ValueOf(string(b)), nil
+			}
+			return reflect.ValueOf(b), nil
+		case SliceTy:
+			n, err := readLength(data, ptr)
+			if err != nil {
+				return v, err
+			}
+			return readElems(t, reflect.MakeSlice(t.GetType(), n, n), data[ptr+32:])
+		default:
+			return readElems(t, reflect.New(t.GetType()).Elem(), data[ptr:])
+		}
+	}
+
+	if t.T == ArrayTy {
+		return readElems(t, reflect.New(t.GetType()).Elem(), data[min(offset, len(data)):])
+	}
+
+	if offset+32 > len(data) {
+		return v, errInsufficientLength
+	}
+	return readWord(t, data[offset:offset+32])
+}
+
+// readElems reads the array or slice elements encoded as a tuple in data.
+func readElems(t Type, dst reflect.Value, data []byte) (reflect.Value, error) {
+	size := t.Elem.headSize()
+	for x := 0; x < dst.Len(); x++ {
+		v, err := readValue(*t.Elem, data, x*size)
+		if err != nil {
+			return dst, err
+		}
+		dst.Index(x).Set(v)
+	}
+	return dst, nil
+}
+
+func readLength(data []byte, offset int) (n int, err error) {
+	if offset < 0 || offset+32 > len(data) {
+		return 0, errInsufficientLength
+	}
+	v := new(big.Int).SetBytes(data[offset : offset+32])
+	if !v.IsInt64() || v.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("abi: offset or length %s exceeds data length %d", v, len(data))
+	}
+	return int(v.Int64()), nil
+}
+
+// readWord decodes a static 32 bytes word.
+func readWord(t Type, word []byte) (v reflect.Value, err error) {
+	switch t.T {
+	case IntTy, UintTy:
+		return readInteger(t, word)
+	case BoolTy:
+		for _, b := range word[:31] {
+			if b != 0 {
+				return v, errors.New("abi: improperly encoded boolean value")
+			}
+		}
+		switch word[31] {
+		case 0:
+			return reflect.ValueOf(false), nil
+		case 1:
+			return reflect.ValueOf(true), nil
+		default:
+			return v, errors.New("abi: improperly encoded boolean value")
+		}
+	case AddressTy:
+		var a [20]byte
+		copy(a[:], word[12:])
+		return reflect.ValueOf(a), nil
+	case FixedBytesTy:
+		v = reflect.New(t.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(word[:t.Size]))
+		return v, nil
+	default:
+		return v, fmt.Errorf("abi: invalid static type %s", t)
+	}
+}
+
+func readInteger(t Type, word []byte) (v reflect.Value, err error) {
+	i := new(big.Int).SetBytes(word)
+	if t.T == IntTy && word[0]&0x80 != 0 {
+		i.Sub(i, tt256)
+	}
+
+	typ := t.GetType()
+	if typ == bigType {
+		return reflect.ValueOf(i), nil
+	}
+
+	v = reflect.New(typ).Elem()
+	if t.T == IntTy {
+		if !i.IsInt64() || v.OverflowInt(i.Int64()) {
+			return v, fmt.Errorf("abi: integer %s overflows %s", i, t)
+		}
+		v.SetInt(i.Int64())
+		return v, nil
+	}
+
+	if !i.IsUint64() || v.OverflowUint(i.Uint64()) {
+		return v, fmt.Errorf("abi: integer %s overflows %s", i, t)
+	}
+	v.SetUint(i.Uint64())
+	return v, nil
+}
+
+// Copy copies the unpacked values into v, a pointer to a struct whose fields
+// match the argument names or are tagged with `abi:"name"`.
+func (arguments Arguments) Copy(v interface{}, values []interface{}) error {
+	dst := reflect.ValueOf(v)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("abi: Unpack(non-pointer %T)", v)
+	}
+	if len(values) == 0 {
+		if len(arguments) != 0 {
+			return errors.New("abi: attempting to copy no values while arguments are expected")
+		}
+		return nil
+	}
+	if len(values) != len(arguments) {
+		return fmt.Errorf("abi: expected %d values, got %d", len(arguments), len(values))
+	}
+
+	dst = dst.Elem()
+	if len(arguments) == 1 {
+		if dst.Kind() == reflect.Struct {
+			return set(dst.Field(0), reflect.ValueOf(values[0]))
+		}
+		return set(dst, reflect.ValueOf(values[0]))
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		fields := structFields(dst.Type())
+		for x, arg := range arguments {
+			name, ok := fields[arg.Name]
+			if !ok {
+				name = ToCamelCase(arg.Name)
+			}
+			field := dst.FieldByName(name)
+			if !field.IsValid() {
+				return fmt.Errorf("abi: field %s can't be found in the given value", arg.Name)
+			}
+			if err := set(field, reflect.ValueOf(values[x])); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if dst.Len() < len(values) {
+			return fmt.Errorf("abi: insufficient number of arguments for unpack, want %d, got %d", len(values), dst.Len())
+		}
+		for x := range values {
+			if err := set(dst.Index(x), reflect.ValueOf(values[x])); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("abi: cannot unmarshal tuple in to %v", dst.Type())
+	}
+	return nil
+}
+
+// structFields maps the abi struct tags to field names.
+func structFields(t reflect.Type) (fields map[string]string) {
+	fields = make(map[string]string)
+	for x := 0; x < t.NumField(); x++ {
+		if tag := t.Field(x).Tag.Get("abi"); tag != "" {
+			fields[tag] = t.Field(x).Name
+		}
+	}
+	return fields
+}
+
+func set(dst reflect.Value, src reflect.Value) error {
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case src.Type().ConvertibleTo(dst.Type()) && src.Kind() == dst.Kind():
+		dst.Set(src.Convert(dst.Type()))
+	case dst.Kind() == reflect.Slice && src.Kind() == reflect.Slice:
+		s := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for x := 0; x < src.Len(); x++ {
+			if err := set(s.Index(x), src.Index(x)); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+	case dst.Kind() == reflect.Array && src.Kind() == reflect.Array && dst.Len() == src.Len():
+		for x := 0; x < src.Len(); x++ {
+			if err := set(dst.Index(x), src.Index(x)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("abi: cannot unmarshal %v in to %v", src.Type(), dst.Type())
+	}
+	return nil
+}
+
+// ToCamelCase converts an under-score string to a camel-case string.
+func ToCamelCase(input string) string {
+	parts := strings.Split(input, "_")
+	for x, s := range parts {
+		if len(s) > 0 {
+			parts[x] = strings.ToUpper(s[:1]) + s[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// Pack encodes the values according to the arguments.
+func (arguments Arguments) Pack(args ...interface{}) ([]byte, error) {
+	if len(args) != len(arguments) {
+		return nil, fmt.Errorf("argument count mismatch: got %d for %d", len(args), len(arguments))
+	}
+
+	types := make([]Type, len(arguments))
+	values := make([]reflect.Value, len(arguments))
+	for x, arg := range arguments {
+		types[x] = arg.Type
+		values[x] = reflect.ValueOf(args[x])
+	}
+	return packTuple(types, values)
+}
+
+// PackValues encodes the values according to the arguments.
+func (arguments Arguments) PackValues(args []interface{}) ([]byte, error) {
+	return arguments.Pack(args...)
+}
+
+func packTuple(types []Type, values []reflect.Value) (b []byte, err error) {
+	headSize := 0
+	for _, t := range types {
+		headSize += t.headSize()
+	}
+
+	var head, tail []byte
+	for x, t := range types {
+		v, err := pack(t, values[x])
+		if err != nil {
+			return nil, err
+		}
+		if t.isDynamic() {
+			head = append(head, packUint(uint64(headSize+len(tail)))...)
+			tail = append(tail, v...)
+			continue
+		}
+		head = append(head, v...)
+	}
+	return append(head, tail...), nil
+}
+
+func pack(t Type, v reflect.Value) (b []byte, err error) {
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	switch t.T {
+	case IntTy, UintTy:
+		return packInteger(t, v)
+	case BoolTy:
+		if v.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("abi: cannot use %v as type bool", v.Type())
+		}
+		if v.Bool() {
+			return packUint(1), nil
+		}
+		return packUint(0), nil
+	case AddressTy, FixedBytesTy:
+		if v.Kind() != reflect.Array || v.Type().Elem().Kind() != reflect.Uint8 || v.Len() != t.Size {
+			return nil, fmt.Errorf("abi: cannot use %v as type %s", v.Type(), t)
+		}
+		b = make([]byte, 32)
+		if t.T == AddressTy {
+			reflect.Copy(reflect.ValueOf(b[12:]), v)
+		} else {
+			reflect.Copy(reflect.ValueOf(b), v)
+		}
+		return b, nil
+	case StringTy, BytesTy:
+		var data []byte
+		switch {
+		case v.Kind() == reflect.String:
+			data = []byte(v.String())
+		case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+			data = v.Bytes()
+		default:
+			return nil, fmt.Errorf("abi: cannot use %v as type %s", v.Type(), t)
+		}
+		b = packUint(uint64(len(data)))
+		b = append(b, data...)
+		if pad := len(data) % 32; pad != 0 {
+			b = append(b, make([]byte, 32-pad)...)
+		}
+		return b, nil
+	case SliceTy, ArrayTy:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return nil, fmt.Errorf("abi: cannot use %v as type %s", v.Type(), t)
+		}
+		if t.T == ArrayTy && v.Len() != t.Size {
+			return nil, fmt.Errorf("abi: cannot use %v as type %s", v.Type(), t)
+		}
+
+		types := make([]Type, v.Len())
+		values := make([]reflect.Value, v.Len())
+		for x := 0; x < v.Len(); x++ {
+			types[x] = *t.Elem
+			values[x] = v.Index(x)
+		}
+		if b, err = packTuple(types, values); err != nil {
+			return nil, err
+		}
+		if t.T == SliceTy {
+			b = append(packUint(uint64(v.Len())), b...)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("abi: invalid type %s", t)
+	}
+}
+
+func packInteger(t Type, v reflect.Value) ([]byte, error) {
+	var i *big.Int
+	switch {
+	case v.Type() == bigType:
+		if v.IsNil() {
+			return nil, fmt.Errorf("abi: cannot use nil *big.Int as type %s", t)
+		}
+		i = new(big.Int).Set(v.Interface().(*big.Int))
+	case v.CanInt():
+		i = big.NewInt(v.Int())
+	case v.CanUint():
+		i = new(big.Int).SetUint64(v.Uint())
+	default:
+		return nil, fmt.Errorf("abi: cannot use %v as type %s", v.Type(), t)
+	}
+
+	if t.T == UintTy && i.Sign() < 0 {
+		return nil, fmt.Errorf("abi: negative value %s for type %s", i, t)
+	}
+	if i.Sign() < 0 {
+		i.Add(i, tt256)
+	}
+	if i.BitLen() > 256 {
+		return nil, fmt.Errorf("abi: value %s overflows type %s", i, t)
+	}
+	return i.FillBytes(make([]byte, 32)), nil
+}
+
+func packUint(n uint64) (b []byte) {
+	b = make([]byte, 32)
+	binary.BigEndian.PutUint64(b[24:], n)
+	return b
+}
@@ -0,0 +1,128 @@
+package lite
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var parityTypes = []string{
+	"bytes32", "uint32", "uint64", "uint192", "int192", "int8", "bool", "address",
+	"bytes", "string", "bytes32[]", "bytes32[3]", "uint32[2][]",
+}
+
+func parityValues() []interface{} {
+	return []interface{}{
+		[32]byte{0, 3, 1, 2, 3},
+		uint32(1700000000),
+		uint64(123456789),
+		big.NewInt(1234567890123),
+		big.NewInt(-1234567890123),
+		int8(-7),
+		true,
+		[20]byte{1, 2, 3},
+		[]byte("report blob larger than a single thirty two bytes word"),
+		"string",
+		[][32]byte{{1}, {2}},
+		[3][32]byte{{3}, {4}, {5}},
+		[][2]uint32{{1, 2}, {3, 4}},
+	}
+}
+
+func parityArgs(t *testing.T) (lite Arguments, geth gethabi.Arguments) {
+	for _, typ := range parityTypes {
+		lt, err := NewType(typ, "", nil)
+		if err != nil {
+			t.Fatalf("NewType(%s) error = %s", typ, err)
+		}
+		gt, err := gethabi.NewType(typ, "", nil)
+		if err != nil {
+			t.Fatalf("geth NewType(%s) error = %s", typ, err)
+		}
+		lite = append(lite, Argument{Name: "arg", Type: lt})
+		geth = append(geth, gethabi.Argument{Name: "arg", Type: gt})
+	}
+	return lite, geth
+}
+
+func TestArguments_PackParity(t *testing.T) {
+	lite, geth := parityArgs(t)
+
+	lb, err := lite.Pack(parityValues()...)
+	if err != nil {
+		t.Fatalf("Pack() error = %s", err)
+	}
+	gb, err := geth.Pack(parityValues()...)
+	if err != nil {
+		t.Fatalf("geth Pack() error = %s", err)
+	}
+
+	if !bytes.Equal(lb, gb) {
+		t.Errorf("Pack() = %x, want %x", lb, gb)
+	}
+}
+
+func TestArguments_UnpackParity(t *testing.T) {
+	lite, geth := parityArgs(t)
+
+	b, err := geth.Pack(parityValues()...)
+	if err != nil {
+		t.Fatalf("geth Pack() error = %s", err)
+	}
+
+	values, err := lite.Unpack(b)
+	if err != nil {
+		t.Fatalf("Unpack() error = %s", err)
+	}
+
+	want := parityValues()
+	for x := range want {
+		if !reflect.DeepEqual(values[x], want[x]) {
+			t.Errorf("Unpack() %s = %#v, want %#v", parityTypes[x], values[x], want[x])
+		}
+	}
+
+	if _, err = lite.Unpack(b[:len(b)-32]); err == nil {
+		t.Errorf("expected error unpacking truncated data")
+	}
+}
+
+func TestArguments_Copy(t *testing.T) {
+	type data struct {
+		FeedID                [32]byte `abi:"feedId"`
+		ObservationsTimestamp uint32
+		BenchmarkPrice        *big.Int
+	}
+
+	uint32Type, _ := NewType("uint32", "", nil)
+	bytes32Type, _ := NewType("bytes32", "", nil)
+	int192Type, _ := NewType("int192", "", nil)
+	args := Arguments{
+		{Name: "feedId", Type: bytes32Type},
+		{Name: "observationsTimestamp", Type: uint32Type},
+		{Name: "benchmarkPrice", Type: int192Type},
+	}
+
+	expected := data{FeedID: [32]byte{1}, ObservationsTimestamp: 10, BenchmarkPrice: big.NewInt(-100)}
+	b, err := args.Pack(expected.FeedID, expected.ObservationsTimestamp, expected.BenchmarkPrice)
+	if err != nil {
+		t.Fatalf("Pack() error = %s", err)
+	}
+
+	values, err := args.Unpack(b)
+	if err != nil {
+		t.Fatalf("Unpack() error = %s", err)
+	}
+
+	var got data
+	if err = args.Copy(&got, values); err != nil {
+		t.Fatalf("Copy() error = %s", err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Copy() = %#v, want %#v", got, expected)
+	}
+}
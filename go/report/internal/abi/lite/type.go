@@ -0,0 +1,205 @@
+package lite
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// Type kinds
+const (
+	IntTy byte = iota
+	UintTy
+	BoolTy
+	StringTy
+	SliceTy
+	ArrayTy
+	AddressTy
+	FixedBytesTy
+	BytesTy
+)
+
+// ArgumentMarshaling mirrors the go-ethereum abi.ArgumentMarshaling type.
+// Tuple components are not supported.
+type ArgumentMarshaling struct {
+	Name         string
+	Type         string
+	InternalType string
+	Components   []ArgumentMarshaling
+	Indexed      bool
+}
+
+// Type is an ABI type.
+type Type struct {
+	Elem *Type
+	Size int
+	T    byte
+
+	stringKind string
+}
+
+var typeRegex = regexp.MustCompile(`^([a-z]+)([0-9]*)$`)
+
+// NewType creates a Type from its ABI string representation.
+// Only the elementary types, fixed and dynamic arrays are supported.
+func NewType(t string, internalType string, components []ArgumentMarshaling) (typ Type, err error) {
+	if len(components) > 0 {
+		return Type{}, fmt.Errorf("abi: tuple types are not supported: %s", t)
+	}
+
+	// arrays, the last brackets define the outer type
+	if n := len(t); n > 0 && t[n-1] == ']' {
+		open := -1
+		for x := n - 1; x >= 0; x-- {
+			if t[x] == '[' {
+				open = x
+				break
+			}
+		}
+		if open <= 0 {
+			return Type{}, fmt.Errorf("abi: invalid type: %s", t)
+		}
+
+		elem, err := NewType(t[:open], internalType, nil)
+		if err != nil {
+			return Type{}, err
+		}
+		typ.Elem = &elem
+		typ.stringKind = t
+
+		size := t[open+1 : n-1]
+		if size == "" {
+			typ.T = SliceTy
+			return typ, nil
+		}
+
+		typ.T = ArrayTy
+		if typ.Size, err = strconv.Atoi(size); err != nil || typ.Size <= 0 {
+			return Type{}, fmt.Errorf("abi: invalid array size: %s", t)
+		}
+		return typ, nil
+	}
+
+	m := typeRegex.FindStringSubmatch(t)
+	if m == nil {
+		return Type{}, fmt.Errorf("abi: invalid type: %s", t)
+	}
+
+	var size int
+	if m[2] != "" {
+		if size, err = strconv.Atoi(m[2]); err != nil {
+			return Type{}, fmt.Errorf("abi: invalid type: %s", t)
+		}
+	}
+
+	typ.stringKind = t
+	switch m[1] {
+	case "int", "uint":
+		if m[2] == "" {
+			size = 256
+		}
+		if size == 0 || size > 256 || size%8 != 0 {
+			return Type{}, fmt.Errorf("abi: invalid integer size: %s", t)
+		}
+		typ.T, typ.Size = UintTy, size
+		if m[1] == "int" {
+			typ.T = IntTy
+		}
+	case "bool":
+		typ.T = BoolTy
+	case "address":
+		typ.T, typ.Size = AddressTy, 20
+	case "string":
+		typ.T = StringTy
+	case "bytes":
+		if m[2] == "" {
+			typ.T = BytesTy
+			break
+		}
+		if size == 0 || size > 32 {
+			return Type{}, fmt.Errorf("abi: invalid bytes size: %s", t)
+		}
+		typ.T, typ.Size = FixedBytesTy, size
+	default:
+		return Type{}, fmt.Errorf("abi: unsupported type: %s", t)
+	}
+	return typ, nil
+}
+
+// String implements fmt.Stringer.
+func (t Type) String() string {
+	return t.stringKind
+}
+
+// GetType returns the Go type of the decoded values of this type.
+func (t Type) GetType() reflect.Type {
+	switch t.T {
+	case IntTy, UintTy:
+		return intType(t.T == IntTy, t.Size)
+	case BoolTy:
+		return reflect.TypeOf(false)
+	case StringTy:
+		return reflect.TypeOf("")
+	case SliceTy:
+		return reflect.SliceOf(t.Elem.GetType())
+	case ArrayTy:
+		return reflect.ArrayOf(t.Size, t.Elem.GetType())
+	case AddressTy:
+		return reflect.TypeOf([20]byte{})
+	case FixedBytesTy:
+		return reflect.ArrayOf(t.Size, reflect.TypeOf(byte(0)))
+	case BytesTy:
+		return reflect.TypeOf([]byte{})
+	default:
+		panic("abi: invalid type")
+	}
+}
+
+func intType(signed bool, size int) reflect.Type {
+	if signed {
+		switch size {
+		case 8:
+			return reflect.TypeOf(int8(0))
+		case 16:
+			return reflect.TypeOf(int16(0))
+		case 32:
+			return reflect.TypeOf(int32(0))
+		case 64:
+			return reflect.TypeOf(int64(0))
+		}
+	} else {
+		switch size {
+		case 8:
+			return reflect.TypeOf(uint8(0))
+		case 16:
+			return reflect.TypeOf(uint16(0))
+		case 32:
+			return reflect.TypeOf(uint32(0))
+		case 64:
+			return reflect.TypeOf(uint64(0))
+		}
+	}
+	return reflect.TypeOf(&big.Int{})
+}
+
+// isDynamic reports whether the type is encoded in the tail of a tuple.
+func (t Type) isDynamic() bool {
+	switch t.T {
+	case StringTy, BytesTy, SliceTy:
+		return true
+	case ArrayTy:
+		return t.Elem.isDynamic()
+	default:
+		return false
+	}
+}
+
+// headSize returns the size the type occupies in the head of a tuple.
+func (t Type) headSize() int {
+	if t.T == ArrayTy && !t.Elem.isDynamic() {
+		return t.Size * t.Elem.headSize()
+	}
+	return 32
+}
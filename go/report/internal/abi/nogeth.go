@@ -0,0 +1,31 @@
+//go:build nogeth
+
+package abi
+
+import (
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi/lite"
+)
+
+type (
+	Arguments          = lite.Arguments
+	Argument           = lite.Argument
+	Type               = lite.Type
+	ArgumentMarshaling = lite.ArgumentMarshaling
+)
+
+// Type kinds
+const (
+	IntTy        = lite.IntTy
+	UintTy       = lite.UintTy
+	FixedBytesTy = lite.FixedBytesTy
+)
+
+// NewType creates a new ABI type from its string representation.
+func NewType(t string, internalType string, components []ArgumentMarshaling) (typ Type, err error) {
+	return lite.NewType(t, internalType, components)
+}
+
+// ToCamelCase converts an ABI argument name to its Go struct field name.
+func ToCamelCase(input string) string {
+	return lite.ToCamelCase(input)
+}
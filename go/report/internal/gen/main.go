@@ -0,0 +1,1077 @@
+// Command gen generates the report data version packages, the report.Data constraint, the feed versions,
+// the reports protobuf definitions and the codec package from the declarative report schemas file.
+//
+// Run it with go generate in the report package directory:
+//
+//	go generate ./report
+//
+// Adding a report version only requires describing its fields in schemas.json,
+// hand-written helpers of a version live next to the generated files in the version package.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const header = "// Code generated by go run ./internal/gen; DO NOT EDIT.\n\n"
+
+// Schemas is the declarative description of the report data versions.
+type Schemas struct {
+	Versions []Version `json:"versions"`
+}
+
+// Version describes the fields of a report data version in ABI order.
+type Version struct {
+	Version int     `json:"version"`
+	Fields  []Field `json:"fields"`
+}
+
+// Field describes a report data field, the Go name and type are derived from its ABI name and type if not set.
+type Field struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	GoName string `json:"goName,omitempty"`
+	GoType string `json:"goType,omitempty"`
+}
+
+func main() {
+	schemasPath := flag.String("schemas", "schemas.json", "report schemas file")
+	flag.Parse()
+
+	files, err := generateFiles(*schemasPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for path, b := range files {
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err = os.WriteFile(path, b, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generateFiles returns the generated files by path relative to the report package directory.
+func generateFiles(schemasPath string) (files map[string][]byte, err error) {
+	b, err := os.ReadFile(schemasPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas Schemas
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&schemas); err != nil {
+		return nil, fmt.Errorf("invalid schemas file %s: %w", schemasPath, err)
+	}
+	if err = schemas.resolve(); err != nil {
+		return nil, fmt.Errorf("invalid schemas file %s: %w", schemasPath, err)
+	}
+
+	files = map[string][]byte{}
+	for _, v := range schemas.Versions {
+		dir := fmt.Sprintf("v%d", v.Version)
+		if files[filepath.Join(dir, "data_gen.go")], err = execute(dataTemplate, v); err != nil {
+			return nil, err
+		}
+		if files[filepath.Join(dir, "data_gen_test.go")], err = execute(dataTestTemplate, v); err != nil {
+			return nil, err
+		}
+	}
+	if files["versions_gen.go"], err = execute(versionsTemplate, schemas); err != nil {
+		return nil, err
+	}
+	if files[filepath.Join("..", "feed", "versions_gen.go")], err = execute(feedVersionsTemplate, schemas); err != nil {
+		return nil, err
+	}
+	if files[filepath.Join("..", "datastreams.proto")], err = executeProto(protoTemplate, schemas); err != nil {
+		return nil, err
+	}
+	if files[filepath.Join("codec", "codec_gen.go")], err = execute(codecTemplate, schemas); err != nil {
+		return nil, err
+	}
+	if files[filepath.Join("codec", "codec_gen_test.go")], err = execute(codecTestTemplate, schemas); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// resolve validates the schemas and sets the derived Go names and types.
+func (s *Schemas) resolve() (err error) {
+	if len(s.Versions) == 0 {
+		return fmt.Errorf("no versions")
+	}
+
+	for x := range s.Versions {
+		v := &s.Versions[x]
+		if v.Version != x+1 {
+			return fmt.Errorf("versions must be listed in order without gaps, got v%d at position %d", v.Version, x+1)
+		}
+		if len(v.Fields) == 0 || v.Fields[0].Name != "feedId" || v.Fields[0].Type != "bytes32" {
+			return fmt.Errorf("v%d: the first field must be the bytes32 feedId", v.Version)
+		}
+
+		var names []string
+		for y := range v.Fields {
+			f := &v.Fields[y]
+			if f.GoName == "" {
+				f.GoName = toCamelCase(f.Name)
+			}
+			if f.GoType == "" {
+				if f.GoType, err = goType(f.Type); err != nil {
+					return fmt.Errorf("v%d field %s: %w", v.Version, f.Name, err)
+				}
+			}
+			if slices.Contains(names, f.GoName) {
+				return fmt.Errorf("v%d: duplicate field %s", v.Version, f.GoName)
+			}
+			if want, ok := commonFields[f.Name]; ok && f.GoType != want {
+				return fmt.Errorf("v%d field %s: common field of Go type %s, got %s", v.Version, f.Name, want, f.GoType)
+			}
+			names = append(names, f.GoName)
+		}
+	}
+	return nil
+}
+
+// commonFields are the Go types of the fields shared across versions read by the report.PriceReport accessors.
+var commonFields = map[string]string{
+	"validFromTimestamp":    "uint32",
+	"observationsTimestamp": "uint32",
+	"expiresAt":             "uint32",
+	"benchmarkPrice":        "*big.Int",
+	"bid":                   "*big.Int",
+	"ask":                   "*big.Int",
+	"nativeFee":             "*big.Int",
+	"linkFee":               "*big.Int",
+}
+
+var intRegex = regexp.MustCompile(`^(u?int)([0-9]+)$`)
+
+// goType returns the Go type of the values decoded from the ABI type.
+func goType(t string) (string, error) {
+	switch t {
+	case "bool", "string":
+		return t, nil
+	case "bytes":
+		return "[]byte", nil
+	}
+
+	if m := intRegex.FindStringSubmatch(t); m != nil {
+		switch m[2] {
+		case "8", "16", "32", "64":
+			return t, nil
+		default:
+			return "*big.Int", nil
+		}
+	}
+
+	if n, ok := strings.CutPrefix(t, "bytes"); ok {
+		if size, err := strconv.Atoi(n); err == nil && size > 0 && size <= 32 {
+			return fmt.Sprintf("[%d]byte", size), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+func toCamelCase(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// Tag returns the struct tag of the field when its Go name does not match its ABI name.
+func (f Field) Tag() string {
+	if f.GoName == toCamelCase(f.Name) {
+		return ""
+	}
+	return fmt.Sprintf("`abi:%q`", f.Name)
+}
+
+// abiGoType returns the Go type of the values decoded from the field ABI type,
+// the underlying type of a named field Go type such as common.MarketStatus.
+func (f Field) abiGoType() string {
+	t, _ := goType(f.Type)
+	return t
+}
+
+// fixedBytes reports whether the field is a fixed size byte array.
+func (f Field) fixedBytes() bool {
+	return strings.HasPrefix(f.Type, "bytes") && f.Type != "bytes"
+}
+
+// JSONType returns the Go type of the field in the JSON encoding of the data.
+func (f Field) JSONType() string {
+	switch {
+	case f.GoType == "*big.Int":
+		return "*jsonenc.BigInt"
+	case f.Type == "bytes" || f.fixedBytes():
+		return "jsonenc.Bytes"
+	default:
+		return f.GoType
+	}
+}
+
+// JSONValue returns a Go expression of the JSON encoded field value of the data d.
+func (f Field) JSONValue() string {
+	switch {
+	case f.GoType == "*big.Int":
+		return fmt.Sprintf("(*jsonenc.BigInt)(d.%s)", f.GoName)
+	case f.Type == "bytes":
+		return fmt.Sprintf("jsonenc.Bytes(d.%s)", f.GoName)
+	case f.fixedBytes():
+		return fmt.Sprintf("d.%s[:]", f.GoName)
+	default:
+		return "d." + f.GoName
+	}
+}
+
+// JSONDecode returns the Go statements setting the field of the data d from the JSON encoded value j.
+func (f Field) JSONDecode() string {
+	switch {
+	case f.GoType == "*big.Int":
+		return fmt.Sprintf("d.%[1]s = (*big.Int)(j.%[1]s)", f.GoName)
+	case f.Type == "bytes":
+		return fmt.Sprintf("d.%[1]s = []byte(j.%[1]s)", f.GoName)
+	case f.fixedBytes():
+		return fmt.Sprintf("if err := jsonenc.CopyFixed(d.%[1]s[:], j.%[1]s); err != nil {\n"+
+			"return fmt.Errorf(\"invalid %[2]s: %%w\", err)\n}", f.GoName, f.Name)
+	default:
+		return fmt.Sprintf("d.%[1]s = j.%[1]s", f.GoName)
+	}
+}
+
+// Fixture returns a Go expression of a distinct value of the field type for the generated tests.
+func (f Field) Fixture(version, index int) string {
+	n := index + 1
+	if f.GoType == "feed.ID" {
+		return fmt.Sprintf("feed.ID{0x00, 0x%02x, 0x%02x}", version, n)
+	}
+	switch f.abiGoType() {
+	case "*big.Int":
+		if strings.HasPrefix(f.Type, "int") && n%2 == 0 {
+			return fmt.Sprintf("new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(%d), 100))", n)
+		}
+		return fmt.Sprintf("new(big.Int).Lsh(big.NewInt(%d), 100)", n)
+	case "bool":
+		return "true"
+	case "string":
+		return strconv.Quote(f.Name)
+	case "[]byte":
+		return fmt.Sprintf("[]byte(%q)", f.Name)
+	case "uint8", "int8":
+		return fmt.Sprintf("%s(%d)", f.GoType, n)
+	case "uint16", "int16":
+		return fmt.Sprintf("%s(%d)", f.GoType, 1000+n)
+	case "uint32", "int32", "uint64", "int64":
+		return fmt.Sprintf("%s(%d)", f.GoType, 1700000000+n)
+	default:
+		// fixed size byte arrays
+		return fmt.Sprintf("%s{0x%02x}", f.GoType, n)
+	}
+}
+
+// ProtoType returns the protobuf type of the field in the report.proto messages.
+func (f Field) ProtoType() string {
+	switch {
+	case f.GoType == "*big.Int":
+		return "string"
+	case f.Type == "bytes" || f.fixedBytes():
+		return "bytes"
+	case f.abiGoType() == "uint8" || f.abiGoType() == "uint16":
+		return "uint32"
+	case f.abiGoType() == "int8" || f.abiGoType() == "int16":
+		return "int32"
+	default:
+		return f.abiGoType()
+	}
+}
+
+// ProtoName returns the protobuf name of the field, the snake case ABI name.
+func (f Field) ProtoName() string {
+	var b strings.Builder
+	for x, r := range f.Name {
+		if x > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+// ProtoGoName returns the Go name of the field in the protobuf message generated by protoc-gen-go.
+func (f Field) ProtoGoName() string {
+	parts := strings.Split(f.ProtoName(), "_")
+	for x, p := range parts {
+		parts[x] = toCamelCase(p)
+	}
+	return strings.Join(parts, "")
+}
+
+// protoGoType returns the Go type of the field in the protobuf message generated by protoc-gen-go.
+func (f Field) protoGoType() string {
+	if t := f.ProtoType(); t != "bytes" {
+		return t
+	}
+	return "[]byte"
+}
+
+// ProtoValue returns a Go expression of the protobuf message field value of the data d.
+func (f Field) ProtoValue() string {
+	switch {
+	case f.GoType == "*big.Int":
+		return fmt.Sprintf("protoenc.BigInt(d.%s)", f.GoName)
+	case f.fixedBytes():
+		return fmt.Sprintf("protoenc.Fixed(d.%s[:])", f.GoName)
+	case f.GoType == f.protoGoType() || f.Type == "bytes":
+		return "d." + f.GoName
+	default:
+		return fmt.Sprintf("%s(d.%s)", f.protoGoType(), f.GoName)
+	}
+}
+
+// ProtoDecode returns the Go statements setting the field of the data d from the protobuf message m.
+func (f Field) ProtoDecode() string {
+	get := fmt.Sprintf("m.Get%s()", f.ProtoGoName())
+	check := fmt.Sprintf("; err != nil {\nreturn nil, fmt.Errorf(\"invalid %s: %%w\", err)\n}", f.ProtoName())
+	switch {
+	case f.GoType == "*big.Int":
+		return fmt.Sprintf("if d.%s, err = protoenc.ParseBigInt(%s)", f.GoName, get) + check
+	case f.fixedBytes():
+		return fmt.Sprintf("if err = protoenc.CopyFixed(d.%s[:], %s)", f.GoName, get) + check
+	case f.GoType == f.protoGoType() || f.Type == "bytes":
+		return fmt.Sprintf("d.%s = %s", f.GoName, get)
+	case f.abiGoType() == f.protoGoType():
+		return fmt.Sprintf("d.%s = %s(%s)", f.GoName, f.GoType, get)
+	default:
+		// narrower integers are encoded as 32 bits integers
+		return fmt.Sprintf("if d.%s, err = protoenc.Narrow[%s](%s)", f.GoName, f.GoType, get) + check
+	}
+}
+
+// CBORAppend returns the Go statement appending the CBOR encoded field key of the data d to b.
+func (f Field) CBORAppend(key int) string {
+	switch {
+	case f.GoType == "*big.Int":
+		return fmt.Sprintf("b = cborenc.AppendBigInt(b, %d, d.%s)", key, f.GoName)
+	case f.Type == "bytes":
+		return fmt.Sprintf("b = cborenc.AppendBytes(b, %d, d.%s)", key, f.GoName)
+	case f.fixedBytes():
+		return fmt.Sprintf("b = cborenc.AppendBytes(b, %d, d.%s[:])", key, f.GoName)
+	case f.GoType == "string":
+		return fmt.Sprintf("b = cborenc.AppendString(b, %d, d.%s)", key, f.GoName)
+	case f.GoType == "bool":
+		return fmt.Sprintf("b = cborenc.AppendBool(b, %d, d.%s)", key, f.GoName)
+	case strings.HasPrefix(f.abiGoType(), "uint"):
+		return fmt.Sprintf("b = cborenc.AppendUint(b, %d, uint64(d.%s))", key, f.GoName)
+	default:
+		return fmt.Sprintf("b = cborenc.AppendInt(b, %d, int64(d.%s))", key, f.GoName)
+	}
+}
+
+// CBORDecode returns the Go statements setting the field of the data d from the CBOR field f.
+func (f Field) CBORDecode() string {
+	switch {
+	case f.GoType == "*big.Int":
+		return fmt.Sprintf("d.%s, err = f.BigInt()", f.GoName)
+	case f.Type == "bytes":
+		return fmt.Sprintf("d.%s, err = f.Bytes()", f.GoName)
+	case f.fixedBytes():
+		return fmt.Sprintf("err = f.CopyFixed(d.%s[:])", f.GoName)
+	case f.GoType == "string":
+		return fmt.Sprintf("d.%s, err = f.String()", f.GoName)
+	case f.GoType == "bool":
+		return fmt.Sprintf("d.%s, err = f.Bool()", f.GoName)
+	case strings.HasPrefix(f.abiGoType(), "uint"):
+		return fmt.Sprintf("var v uint64\nv, err = f.Uint(%s)\nd.%s = %s(v)", strings.TrimPrefix(f.Type, "uint"), f.GoName, f.GoType)
+	default:
+		return fmt.Sprintf("var v int64\nv, err = f.Int(%s)\nd.%s = %s(v)", strings.TrimPrefix(f.Type, "int"), f.GoName, f.GoType)
+	}
+}
+
+// Has reports whether the version has the field with the ABI name.
+func (v Version) Has(name string) bool {
+	return slices.ContainsFunc(v.Fields, func(f Field) bool { return f.Name == name })
+}
+
+// Value returns a Go expression of the field with the ABI name of the data d, zero if the version does not have it.
+func (v Version) Value(name, zero string) string {
+	for _, f := range v.Fields {
+		if f.Name == name {
+			return "d." + f.GoName
+		}
+	}
+	return zero
+}
+
+// StaticSize returns the size of the ABI encoded data, -1 if the version has dynamic fields.
+func (v Version) StaticSize() int {
+	for _, f := range v.Fields {
+		if f.Type == "bytes" || f.Type == "string" {
+			return -1
+		}
+	}
+	// every static field is encoded in a 32 bytes word
+	return 32 * len(v.Fields)
+}
+
+// Imports returns the imports of the generated data package, only the ABI layer besides the field types.
+func (v Version) Imports() (imports []string) {
+	// math/big is used by the report.PriceReport accessors
+	imports = []string{`"fmt"`, `"math/big"`, `"time"`, ""}
+	imports = append(imports, v.typeImports()...)
+	imports = append(imports, `"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi"`)
+	slices.Sort(imports[4:])
+	return imports
+}
+
+// typeImports returns the imports of the named field Go types, feed.ID and the report/common types.
+func (v Version) typeImports() (imports []string) {
+	for pkg, path := range map[string]string{
+		"feed.":   `"github.com/smartcontractkit/data-streams-sdk/go/feed"`,
+		"common.": `"github.com/smartcontractkit/data-streams-sdk/go/report/common"`,
+	} {
+		if slices.ContainsFunc(v.Fields, func(f Field) bool { return strings.HasPrefix(f.GoType, pkg) }) {
+			imports = append(imports, path)
+		}
+	}
+	slices.Sort(imports)
+	return imports
+}
+
+// TestImports returns the imports of the generated data package tests.
+func (v Version) TestImports() (imports []string) {
+	for _, f := range v.Fields {
+		if strings.Contains(f.GoType, "big.Int") {
+			imports = append(imports, `"math/big"`)
+			break
+		}
+	}
+	imports = append(imports, `"reflect"`, `"testing"`)
+	if types := v.typeImports(); len(types) > 0 {
+		imports = append(append(imports, ""), types...)
+	}
+	return imports
+}
+
+// typeImports returns the imports of the named field Go types of all the versions.
+func (s Schemas) typeImports() (imports []string) {
+	for _, v := range s.Versions {
+		for _, i := range v.typeImports() {
+			if !slices.Contains(imports, i) {
+				imports = append(imports, i)
+			}
+		}
+	}
+	slices.Sort(imports)
+	return imports
+}
+
+// versionImports returns the imports of the data version packages.
+func (s Schemas) versionImports() (imports []string) {
+	for _, v := range s.Versions {
+		imports = append(imports, fmt.Sprintf(`v%d "github.com/smartcontractkit/data-streams-sdk/go/report/v%d"`, v.Version, v.Version))
+	}
+	return imports
+}
+
+// hasBigInt reports whether a version has big integer fields.
+func (s Schemas) hasBigInt() bool {
+	return slices.ContainsFunc(s.Versions, func(v Version) bool {
+		return slices.ContainsFunc(v.Fields, func(f Field) bool { return f.GoType == "*big.Int" })
+	})
+}
+
+// CodecImports returns the imports of the generated codec package.
+func (s Schemas) CodecImports() (imports []string) {
+	imports = []string{`"encoding/json"`, `"fmt"`}
+	if s.hasBigInt() {
+		imports = append(imports, `"math/big"`)
+	}
+	imports = append(imports, "")
+	n := len(imports)
+	imports = append(imports,
+		`"github.com/smartcontractkit/data-streams-sdk/go/datastreamspb"`,
+		`"github.com/smartcontractkit/data-streams-sdk/go/internal/cborenc"`,
+		`"github.com/smartcontractkit/data-streams-sdk/go/report"`,
+		`"github.com/smartcontractkit/data-streams-sdk/go/report/internal/jsonenc"`,
+		`"github.com/smartcontractkit/data-streams-sdk/go/report/internal/protoenc"`,
+	)
+	for _, i := range s.typeImports() {
+		if strings.Contains(i, "/report/common") {
+			imports = append(imports, i)
+		}
+	}
+	slices.Sort(imports[n:])
+	return append(imports, s.versionImports()...)
+}
+
+// CodecTestImports returns the imports of the generated codec package tests.
+func (s Schemas) CodecTestImports() (imports []string) {
+	imports = []string{`"encoding/json"`}
+	if s.hasBigInt() {
+		imports = append(imports, `"math/big"`)
+	}
+	imports = append(imports, `"reflect"`, `"testing"`, "")
+	n := len(imports)
+	imports = append(imports, `"github.com/smartcontractkit/data-streams-sdk/go/datastreamspb"`,
+		`"google.golang.org/protobuf/proto"`)
+	imports = append(imports, s.typeImports()...)
+	slices.Sort(imports[n:])
+	return append(imports, s.versionImports()...)
+}
+
+// Latest returns the latest version.
+func (s Schemas) Latest() int {
+	return s.Versions[len(s.Versions)-1].Version
+}
+
+func execute(t *template.Template, data any) (b []byte, err error) {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	if err = t.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("executing template %s: %w", t.Name(), err)
+	}
+	if b, err = format.Source(buf.Bytes()); err != nil {
+		return nil, fmt.Errorf("formatting template %s output: %w\n%s", t.Name(), err, buf.String())
+	}
+	return b, nil
+}
+
+// funcs are the template functions.
+var funcs = template.FuncMap{"inc": func(x int) int { return x + 1 }}
+
+// executeProto executes the protobuf definitions template.
+func executeProto(t *template.Template, data any) (b []byte, err error) {
+	var buf bytes.Buffer
+	buf.WriteString(strings.ReplaceAll(header, "\n\n", "\n"))
+	if err = t.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("executing template %s: %w", t.Name(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+var dataTemplate = template.Must(template.New("data").Funcs(funcs).Parse(`package v{{.Version}}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+var schema = Schema()
+
+// Schema returns this data version schema
+func Schema() abi.Arguments {
+	mustNewType := func(t string) abi.Type {
+		result, err := abi.NewType(t, "", []abi.ArgumentMarshaling{})
+		if err != nil {
+			panic(fmt.Sprintf("Unexpected error during abi.NewType: %s", err))
+		}
+		return result
+	}
+	return abi.Arguments([]abi.Argument{
+{{- range .Fields}}
+		{Name: "{{.Name}}", Type: mustNewType("{{.Type}}")},
+{{- end}}
+	})
+}
+
+// Data is the container for this schema attributes
+type Data struct {
+{{- range .Fields}}
+	{{.GoName}} {{.GoType}} {{.Tag}}
+{{- end}}
+}
+
+// Schema returns this data version schema
+func (Data) Schema() abi.Arguments {
+	return Schema()
+}
+
+// Decode decodes the serialized data bytes
+func Decode(data []byte) (*Data, error) {
+	values, err := schema.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode report: %w", err)
+	}
+	decoded := new(Data)
+	if err = abi.Copy(schema, decoded, values); err != nil {
+		return nil, fmt.Errorf("failed to copy report values to struct: %w", err)
+	}
+	return decoded, nil
+}
+
+// Encode serializes the data, the reverse of Decode
+func Encode(d *Data) ([]byte, error) {
+	b, err := schema.Pack(
+{{- range .Fields}}
+		d.{{.GoName}},
+{{- end}}
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+	return b, nil
+}
+
+// BenchmarkOrMid returns the benchmark price, or the mid price of the bid and ask
+// when the benchmark price is not set, nil if neither is available
+func (d Data) BenchmarkOrMid() *big.Int {
+{{- if and (.Has "bid") (.Has "ask")}}
+	if {{.Value "benchmarkPrice" "nil"}} == nil && d.Bid != nil && d.Ask != nil {
+		mid := new(big.Int).Add(d.Bid, d.Ask)
+		return mid.Quo(mid, big.NewInt(2))
+	}
+{{- end}}
+	return {{.Value "benchmarkPrice" "nil"}}
+}
+
+// Timestamps returns the data timestamps, 0 for the timestamps not part of this version
+func (d Data) Timestamps() (validFrom, observations, expiresAt uint32) {
+	return {{.Value "validFromTimestamp" "0"}}, {{.Value "observationsTimestamp" "0"}}, {{.Value "expiresAt" "0"}}
+}
+
+// Fees returns the verification fees in native and LINK tokens, nil for the fees not part of this version
+func (d Data) Fees() (native, link *big.Int) {
+	return {{.Value "nativeFee" "nil"}}, {{.Value "linkFee" "nil"}}
+}
+
+// IsExpired reports whether the data is expired at now, after its ExpiresAt, never for versions without an expiry
+func (d Data) IsExpired(now time.Time) bool {
+{{- if .Has "expiresAt"}}
+	return now.Unix() > int64(d.ExpiresAt)
+{{- else}}
+	return false
+{{- end}}
+}
+
+// ValidAt reports whether the data is valid at t, not before its ValidFromTimestamp, or ObservationsTimestamp
+// for versions without a validity start, and not expired
+func (d Data) ValidAt(t time.Time) bool {
+	return t.Unix() >= int64({{.Value "validFromTimestamp" (.Value "observationsTimestamp" "0")}}) && !d.IsExpired(t)
+}
+
+// Age returns the time elapsed since the data ObservationsTimestamp, 0 for versions without observations timestamp
+func (d Data) Age() time.Duration {
+{{- if .Has "observationsTimestamp"}}
+	return time.Since(time.Unix(int64(d.ObservationsTimestamp), 0))
+{{- else}}
+	return 0
+{{- end}}
+}
+`))
+
+var dataTestTemplate = template.Must(template.New("dataTest").Parse(`package v{{.Version}}
+
+import (
+{{- range .TestImports}}
+	{{.}}
+{{- end}}
+)
+
+func TestData_EncodeDecode(t *testing.T) {
+	want := &Data{
+{{- $version := .Version}}
+{{- range $x, $f := .Fields}}
+		{{$f.GoName}}: {{$f.Fixture $version $x}},
+{{- end}}
+	}
+
+	b, err := Encode(want)
+	if err != nil {
+		t.Fatalf("Encode() error = %s", err)
+	}
+
+	got, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %#v, want %#v", got, want)
+	}
+
+	if _, err = Decode(b[:len(b)-1]); err == nil {
+		t.Errorf("Decode() of truncated data error = nil, want error")
+	}
+}
+`))
+
+var versionsTemplate = template.Must(template.New("versions").Parse(`package report
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi"
+{{- range .Versions}}
+	v{{.Version}} "github.com/smartcontractkit/data-streams-sdk/go/report/v{{.Version}}"
+{{- end}}
+)
+
+// Data represents the actual report data and attributes
+type Data interface {
+	{{range $x, $v := .Versions}}{{if $x}} | {{end}}v{{$v.Version}}.Data{{end}}
+	Schema() abi.Arguments
+}
+
+// LatestVersion is the latest feed version decodable by this package.
+// Applications can assert at compile time that a feed version is supported,
+// the following declaration fails to compile if feed.FeedVersion{{.Latest}} is not supported:
+//
+//	const _ = report.LatestVersion - feed.FeedVersion{{.Latest}}
+const LatestVersion = feed.FeedVersion{{.Latest}}
+
+// VersionOf returns the feed version of the data type.
+func VersionOf[T Data]() feed.FeedVersion {
+	var t T
+	switch any(t).(type) {
+{{- range .Versions}}
+	case v{{.Version}}.Data:
+		return feed.FeedVersion{{.Version}}
+{{- end}}
+	default:
+		return 0
+	}
+}
+
+// encodeData serializes the report data with the encoder of its version.
+func encodeData[T Data](d *T) ([]byte, error) {
+	switch d := any(d).(type) {
+{{- range .Versions}}
+	case *v{{.Version}}.Data:
+		return v{{.Version}}.Encode(d)
+{{- end}}
+	default:
+		return nil, fmt.Errorf("report: unsupported data type %T", d)
+	}
+}
+
+var (
+{{- range .Versions}}
+	_ PriceReport = v{{.Version}}.Data{}
+	_ Freshness   = v{{.Version}}.Data{}
+{{- end}}
+)
+
+// dataSize returns the ABI encoded size of the report data of the feed version, false if unknown or variable.
+func dataSize(v feed.FeedVersion) (int, bool) {
+	switch v {
+{{- range .Versions}}
+{{- if ge .StaticSize 0}}
+	case feed.FeedVersion{{.Version}}:
+		return {{.StaticSize}}, true
+{{- end}}
+{{- end}}
+	default:
+		return 0, false
+	}
+}
+
+// dataSchema returns the report data schema of the feed version, false if unsupported.
+func dataSchema(v feed.FeedVersion) (abi.Arguments, bool) {
+	switch v {
+{{- range .Versions}}
+	case feed.FeedVersion{{.Version}}:
+		return v{{.Version}}.Schema(), true
+{{- end}}
+	default:
+		return nil, false
+	}
+}
+
+// decodeVersion decodes the full report with the data type of the feed version.
+func decodeVersion(v feed.FeedVersion, fullReport []byte) (AnyReport, error) {
+	switch v {
+{{- range .Versions}}
+	case feed.FeedVersion{{.Version}}:
+		return decodeAny[v{{.Version}}.Data](fullReport)
+{{- end}}
+	default:
+		return nil, fmt.Errorf("report: unsupported feed version %d", v)
+	}
+}
+`))
+
+var feedVersionsTemplate = template.Must(template.New("feedVersions").Parse(`package feed
+
+const (
+	_ FeedVersion = iota
+{{- range .Versions}}
+	FeedVersion{{.Version}}
+{{- end}}
+	_
+)
+`))
+
+var protoTemplate = template.Must(template.New("proto").Funcs(funcs).Parse(`
+// Protobuf definitions of the Data Streams reports, for forwarding reports over gRPC pipelines.
+// Their Go types are generated by protoc-gen-go in the datastreamspb package, see datastreamspb/generate.go.
+// The ReportResponse and VnData messages are converted by the report/codec package.
+// Big integers are decimal strings.
+syntax = "proto3";
+
+package datastreams;
+
+option go_package = "github.com/smartcontractkit/data-streams-sdk/go/datastreamspb";
+
+message ReportResponse {
+  bytes feed_id = 1;
+  bytes full_report = 2;
+  uint64 valid_from_timestamp = 3;
+  uint64 observations_timestamp = 4;
+}
+{{- range .Versions}}
+
+message V{{.Version}}Data {
+{{- range $x, $f := .Fields}}
+  {{$f.ProtoType}} {{$f.ProtoName}} = {{inc $x}};
+{{- end}}
+}
+{{- end}}
+`))
+
+var codecTemplate = template.Must(template.New("codec").Funcs(funcs).Parse(`package codec
+
+import (
+{{- range .CodecImports}}
+	{{.}}
+{{- end}}
+)
+{{range .Versions}}
+// v{{.Version}}JSON is the JSON encoding of v{{.Version}}.Data
+type v{{.Version}}JSON struct {
+{{- range .Fields}}
+	{{.GoName}} {{.JSONType}} ` + "`json:\"{{.Name}}\"`" + `
+{{- end}}
+}
+
+// marshalV{{.Version}}JSON encodes the data as a JSON object keyed by the schema field names
+func marshalV{{.Version}}JSON(d *v{{.Version}}.Data) ([]byte, error) {
+	return json.Marshal(v{{.Version}}JSON{
+{{- range .Fields}}
+		{{.GoName}}: {{.JSONValue}},
+{{- end}}
+	})
+}
+
+// unmarshalV{{.Version}}JSON decodes the data encoded by marshalV{{.Version}}JSON
+func unmarshalV{{.Version}}JSON(b []byte, d *v{{.Version}}.Data) error {
+	var j v{{.Version}}JSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+{{- range .Fields}}
+	{{.JSONDecode}}
+{{- end}}
+	return nil
+}
+
+// V{{.Version}}ToProto converts the data to its V{{.Version}}Data protobuf message of datastreams.proto
+func V{{.Version}}ToProto(d v{{.Version}}.Data) *datastreamspb.V{{.Version}}Data {
+	return &datastreamspb.V{{.Version}}Data{
+{{- range .Fields}}
+		{{.ProtoGoName}}: {{.ProtoValue}},
+{{- end}}
+	}
+}
+
+// V{{.Version}}FromProto converts the V{{.Version}}Data protobuf message of datastreams.proto to the data,
+// the reverse of V{{.Version}}ToProto
+func V{{.Version}}FromProto(m *datastreamspb.V{{.Version}}Data) (d *v{{.Version}}.Data, err error) {
+	d = new(v{{.Version}}.Data)
+{{- range .Fields}}
+	{{.ProtoDecode}}
+{{- end}}
+	return d, nil
+}
+
+// marshalV{{.Version}}CBOR encodes the data as a CBOR map keyed by the field numbers of its V{{.Version}}Data protobuf message
+func marshalV{{.Version}}CBOR(d *v{{.Version}}.Data) []byte {
+	b := cborenc.AppendMap(nil, {{len .Fields}})
+{{- range $x, $f := .Fields}}
+	{{$f.CBORAppend (inc $x)}}
+{{- end}}
+	return b
+}
+
+// unmarshalV{{.Version}}CBOR decodes the data encoded by marshalV{{.Version}}CBOR, unknown fields are ignored
+func unmarshalV{{.Version}}CBOR(b []byte, d *v{{.Version}}.Data) error {
+	*d = v{{.Version}}.Data{}
+	return cborenc.Decode(b, func(f cborenc.Field) (err error) {
+		switch f.Key {
+{{- range $x, $f := .Fields}}
+		case {{inc $x}}:
+			{{$f.CBORDecode}}
+{{- end}}
+		}
+		return err
+	})
+}
+{{end}}
+// marshalJSON encodes the data with the JSON encoding of its version
+func marshalJSON(d any) ([]byte, error) {
+	switch d := d.(type) {
+{{- range .Versions}}
+	case *v{{.Version}}.Data:
+		return marshalV{{.Version}}JSON(d)
+{{- end}}
+	default:
+		return nil, fmt.Errorf("codec: unsupported data type %T", d)
+	}
+}
+
+// unmarshalJSON decodes the data with the JSON encoding of its version
+func unmarshalJSON(b []byte, d any) error {
+	switch d := d.(type) {
+{{- range .Versions}}
+	case *v{{.Version}}.Data:
+		return unmarshalV{{.Version}}JSON(b, d)
+{{- end}}
+	default:
+		return fmt.Errorf("codec: unsupported data type %T", d)
+	}
+}
+
+// marshalCBOR encodes the data with the CBOR encoding of its version
+func marshalCBOR(d any) ([]byte, error) {
+	switch d := d.(type) {
+{{- range .Versions}}
+	case *v{{.Version}}.Data:
+		return marshalV{{.Version}}CBOR(d), nil
+{{- end}}
+	default:
+		return nil, fmt.Errorf("codec: unsupported data type %T", d)
+	}
+}
+
+// unmarshalCBOR decodes the data with the CBOR encoding of its version
+func unmarshalCBOR(b []byte, d any) error {
+	switch d := d.(type) {
+{{- range .Versions}}
+	case *v{{.Version}}.Data:
+		return unmarshalV{{.Version}}CBOR(b, d)
+{{- end}}
+	default:
+		return fmt.Errorf("codec: unsupported data type %T", d)
+	}
+}
+
+// MarshalAnyReportJSON encodes the report returned by report.DecodeAny as MarshalReportJSON does.
+func MarshalAnyReportJSON(r report.AnyReport) ([]byte, error) {
+	switch r := r.(type) {
+{{- range .Versions}}
+	case *report.Report[v{{.Version}}.Data]:
+		return MarshalReportJSON(r)
+{{- end}}
+	default:
+		return nil, fmt.Errorf("codec: unsupported report type %T", r)
+	}
+}
+`))
+
+var codecTestTemplate = template.Must(template.New("codecTest").Parse(`package codec
+
+import (
+{{- range .CodecTestImports}}
+	{{.}}
+{{- end}}
+)
+{{range .Versions}}
+{{- $version := .Version}}
+func TestV{{.Version}}_Proto(t *testing.T) {
+	want := &v{{.Version}}.Data{
+{{- range $x, $f := .Fields}}
+		{{$f.GoName}}: {{$f.Fixture $version $x}},
+{{- end}}
+	}
+
+	b, err := proto.Marshal(V{{.Version}}ToProto(*want))
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %s", err)
+	}
+	m := &datastreamspb.V{{.Version}}Data{}
+	if err = proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %s", err)
+	}
+	got, err := V{{.Version}}FromProto(m)
+	if err != nil {
+		t.Fatalf("V{{.Version}}FromProto() error = %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("V{{.Version}}FromProto() = %#v, want %#v", got, want)
+	}
+
+	if got, err = V{{.Version}}FromProto(nil); err != nil || !reflect.DeepEqual(got, &v{{.Version}}.Data{}) {
+		t.Errorf("V{{.Version}}FromProto(nil) = %#v, %v, want zero data", got, err)
+	}
+}
+
+func TestV{{.Version}}_CBOR(t *testing.T) {
+	want := v{{.Version}}.Data{
+{{- range $x, $f := .Fields}}
+		{{$f.GoName}}: {{$f.Fixture $version $x}},
+{{- end}}
+	}
+
+	b, err := MarshalCBOR(want)
+	if err != nil {
+		t.Fatalf("MarshalCBOR() error = %s", err)
+	}
+	got, err := UnmarshalCBOR[v{{.Version}}.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalCBOR() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalCBOR() = %#v, want %#v", *got, want)
+	}
+
+	if _, err = UnmarshalCBOR[v{{.Version}}.Data](b[:len(b)-1]); err == nil {
+		t.Errorf("UnmarshalCBOR() of truncated data error = nil, want error")
+	}
+}
+
+func TestV{{.Version}}_JSON(t *testing.T) {
+	want := v{{.Version}}.Data{
+{{- range $x, $f := .Fields}}
+		{{$f.GoName}}: {{$f.Fixture $version $x}},
+{{- end}}
+	}
+
+	b, err := MarshalJSON(want)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %s", err)
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("invalid JSON %s: %s", b, err)
+	}
+	if got, wantID := fields["feedId"], want.FeedID.String(); got != wantID {
+		t.Errorf("MarshalJSON() feedId = %v, want %s", got, wantID)
+	}
+
+	got, err := UnmarshalJSON[v{{.Version}}.Data](b)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() error = %s", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("UnmarshalJSON() = %#v, want %#v", *got, want)
+	}
+}
+{{end}}`))
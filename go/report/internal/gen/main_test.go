@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	reportDir := filepath.Join("..", "..")
+	files, err := generateFiles(filepath.Join(reportDir, "schemas.json"))
+	if err != nil {
+		t.Fatalf("generateFiles() error = %s", err)
+	}
+
+	for path, want := range files {
+		got, err := os.ReadFile(filepath.Join(reportDir, path))
+		if err != nil {
+			t.Errorf("error reading generated file: %s", err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run go generate ./report", path)
+		}
+	}
+}
+
+func TestSchemas_resolve(t *testing.T) {
+	feedID := Field{Name: "feedId", Type: "bytes32", GoName: "FeedID", GoType: "feed.ID"}
+	tests := []struct {
+		name    string
+		schemas Schemas
+		wantErr bool
+	}{
+		{name: "valid", schemas: Schemas{Versions: []Version{{Version: 1, Fields: []Field{feedID, {Name: "price", Type: "int192"}}}}}},
+		{name: "no versions", wantErr: true},
+		{name: "gap", schemas: Schemas{Versions: []Version{{Version: 2, Fields: []Field{feedID}}}}, wantErr: true},
+		{name: "no feed id", schemas: Schemas{Versions: []Version{{Version: 1, Fields: []Field{{Name: "price", Type: "int192"}}}}}, wantErr: true},
+		{name: "unsupported type", schemas: Schemas{Versions: []Version{{Version: 1, Fields: []Field{feedID, {Name: "t", Type: "tuple"}}}}}, wantErr: true},
+		{name: "common field type", schemas: Schemas{Versions: []Version{{Version: 1, Fields: []Field{feedID, {Name: "expiresAt", Type: "uint64"}}}}}, wantErr: true},
+		{name: "duplicate", schemas: Schemas{Versions: []Version{{Version: 1, Fields: []Field{feedID, {Name: "bid", Type: "int192"}, {Name: "Bid", Type: "int192"}}}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.schemas.resolve(); (err != nil) != tt.wantErr {
+				t.Errorf("resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Package jsonenc implements the JSON encoding of the report values:
+// bytes as 0x prefixed hex strings and big integers as decimal strings.
+package jsonenc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Bytes is encoded as a 0x prefixed hex string.
+type Bytes []byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Bytes) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, the 0x prefix is optional.
+func (b *Bytes) UnmarshalText(text []byte) (err error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(string(text), "0x"), "0X")
+	*b, err = hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("invalid hex string %q: %w", text, err)
+	}
+	return nil
+}
+
+// CopyFixed copies the decoded bytes to the fixed size array slice dst, failing if their lengths differ.
+// Missing values zero dst.
+func CopyFixed(dst []byte, b Bytes) error {
+	if b == nil {
+		clear(dst)
+		return nil
+	}
+	if len(b) != len(dst) {
+		return fmt.Errorf("invalid length %d, expected %d bytes", len(b), len(dst))
+	}
+	copy(dst, b)
+	return nil
+}
+
+// BigInt is encoded as a decimal string.
+type BigInt big.Int
+
+// MarshalText implements encoding.TextMarshaler.
+func (i *BigInt) MarshalText() ([]byte, error) {
+	return (*big.Int)(i).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *BigInt) UnmarshalText(text []byte) error {
+	if _, ok := (*big.Int)(i).SetString(string(text), 10); !ok {
+		return fmt.Errorf("invalid decimal integer %q", text)
+	}
+	return nil
+}
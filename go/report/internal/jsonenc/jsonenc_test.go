@@ -0,0 +1,54 @@
+package jsonenc
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestEncoding(t *testing.T) {
+	type values struct {
+		B   Bytes   `json:"b"`
+		I   *BigInt `json:"i"`
+		Nil *BigInt `json:"nil"`
+	}
+	i, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	v := values{B: Bytes{0x00, 0x0a, 0xff}, I: (*BigInt)(i)}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %s", err)
+	}
+	want := `{"b":"0x000aff","i":"-123456789012345678901234567890","nil":null}`
+	if string(b) != want {
+		t.Errorf("Marshal() = %s, want %s", b, want)
+	}
+
+	var decoded values
+	if err = json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %s", err)
+	}
+	if !reflect.DeepEqual(decoded, v) {
+		t.Errorf("Unmarshal() = %+v, want %+v", decoded, v)
+	}
+
+	for _, invalid := range []string{`{"b":"0xzz"}`, `{"i":"1.5"}`, `{"i":12}`} {
+		if err = json.Unmarshal([]byte(invalid), &decoded); err == nil {
+			t.Errorf("Unmarshal(%s) error = nil, want error", invalid)
+		}
+	}
+}
+
+func TestCopyFixed(t *testing.T) {
+	var dst [4]byte
+	if err := CopyFixed(dst[:], Bytes{1, 2, 3, 4}); err != nil || dst != [4]byte{1, 2, 3, 4} {
+		t.Errorf("CopyFixed() = %v, %v", dst, err)
+	}
+	if err := CopyFixed(dst[:], Bytes{1, 2}); err == nil {
+		t.Errorf("CopyFixed() error = nil, want error")
+	}
+	if err := CopyFixed(dst[:], nil); err != nil || dst != [4]byte{} {
+		t.Errorf("CopyFixed() of missing value = %v, %v", dst, err)
+	}
+}
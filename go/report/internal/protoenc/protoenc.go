@@ -0,0 +1,65 @@
+// Package protoenc implements the conversions of the report values to and from the fields of the
+// datastreamspb messages generated by protoc-gen-go: fixed size bytes as bytes, big integers as decimal
+// strings and integers narrower than 32 bits as 32 bits integers. All zero fixed size bytes and nil big
+// integers are converted to the empty field values, omitted as proto3 does, and back.
+package protoenc
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Fixed returns the fixed size bytes field value, nil if all zero.
+func Fixed(v []byte) []byte {
+	for _, c := range v {
+		if c != 0 {
+			return append([]byte{}, v...)
+		}
+	}
+	return nil
+}
+
+// CopyFixed copies the bytes field value v to the fixed size array slice dst, left zero if v is empty,
+// failing if their lengths differ.
+func CopyFixed(dst, v []byte) error {
+	if len(v) == 0 {
+		return nil
+	}
+	if len(v) != len(dst) {
+		return fmt.Errorf("invalid length %d, expected %d bytes", len(v), len(dst))
+	}
+	copy(dst, v)
+	return nil
+}
+
+// BigInt returns the decimal string field value of the big integer, empty if nil.
+func BigInt(v *big.Int) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// ParseBigInt returns the big integer of the decimal string field value, nil if empty.
+func ParseBigInt(v string) (*big.Int, error) {
+	if v == "" {
+		return nil, nil
+	}
+	i, ok := new(big.Int).SetString(v, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal integer %q", v)
+	}
+	return i, nil
+}
+
+type integer interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Narrow converts the integer field value v to the narrower integer type T, failing if it overflows T.
+func Narrow[T integer, P integer](v P) (T, error) {
+	if t := T(v); P(t) == v && (t < 0) == (v < 0) {
+		return t, nil
+	}
+	return 0, fmt.Errorf("integer %d overflows %T", v, T(0))
+}
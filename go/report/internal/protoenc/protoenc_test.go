@@ -0,0 +1,56 @@
+package protoenc
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFixed(t *testing.T) {
+	if v := Fixed([]byte{0, 0}); v != nil {
+		t.Errorf("Fixed() of zero bytes = %x, want nil", v)
+	}
+
+	v := Fixed([]byte{0, 1, 2})
+	dst := make([]byte, 3)
+	if err := CopyFixed(dst, v); err != nil || string(dst) != "\x00\x01\x02" {
+		t.Errorf("CopyFixed() = %x, %v, want 000102", dst, err)
+	}
+	if err := CopyFixed(dst, []byte{1}); err == nil {
+		t.Errorf("CopyFixed() of a shorter value error = nil, want error")
+	}
+
+	zero := make([]byte, 3)
+	if err := CopyFixed(zero, nil); err != nil || string(zero) != "\x00\x00\x00" {
+		t.Errorf("CopyFixed() of an empty value = %x, %v, want zero", zero, err)
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	i, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	got, err := ParseBigInt(BigInt(i))
+	if err != nil || got.Cmp(i) != 0 {
+		t.Errorf("ParseBigInt(BigInt()) = %s, %v, want %s", got, err, i)
+	}
+
+	if got, err = ParseBigInt(BigInt(nil)); err != nil || got != nil {
+		t.Errorf("ParseBigInt(BigInt(nil)) = %s, %v, want nil", got, err)
+	}
+	if _, err = ParseBigInt("0x01"); err == nil {
+		t.Errorf("ParseBigInt() of an invalid integer error = nil, want error")
+	}
+}
+
+func TestNarrow(t *testing.T) {
+	if v, err := Narrow[uint8](uint32(255)); err != nil || v != 255 {
+		t.Errorf("Narrow[uint8](255) = %d, %v, want 255", v, err)
+	}
+	if v, err := Narrow[int16](int32(-300)); err != nil || v != -300 {
+		t.Errorf("Narrow[int16](-300) = %d, %v, want -300", v, err)
+	}
+	if _, err := Narrow[uint8](uint32(256)); err == nil {
+		t.Errorf("Narrow[uint8](256) error = nil, want error")
+	}
+	if _, err := Narrow[int8](int32(-129)); err == nil {
+		t.Errorf("Narrow[int8](-129) error = nil, want error")
+	}
+}
@@ -0,0 +1,294 @@
+// Command vectors generates the canonical report test vectors shared with the other Data Streams SDKs
+// from the declarative report schemas file: for each report data version, full reports ABI encoded
+// with typical, zero and extreme field values along with their expected decoded values.
+//
+// The vectors file is a JSON object with a vectors list, each vector having:
+//   - name: the version and the kind of values, e.g. v3/extremes,
+//   - version: the report data version,
+//   - fullReport: the 0x prefixed hex encoded full report,
+//   - report: the expected decoded reportContext, reportBlob, rawRs, rawSs and rawVs as 0x prefixed hex strings,
+//     and data, the report data fields keyed by their schema name in schema order, with integers as decimal
+//     strings, whatever their size, bytes as 0x prefixed hex strings, strings and bools as JSON strings and bools.
+//
+// Run it with go generate in the report package directory:
+//
+//	go generate ./report
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"reflect"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi"
+)
+
+// Schemas is the declarative description of the report data versions, see internal/gen.
+type Schemas struct {
+	Versions []struct {
+		Version int `json:"version"`
+		Fields  []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"fields"`
+	} `json:"versions"`
+}
+
+// Vectors is the test vectors file.
+type Vectors struct {
+	Vectors []Vector `json:"vectors"`
+}
+
+// Vector is a full report and its expected decoded values.
+type Vector struct {
+	Name       string `json:"name"`
+	Version    int    `json:"version"`
+	FullReport string `json:"fullReport"`
+	Report     Report `json:"report"`
+}
+
+// Report are the expected decoded values of a full report.
+type Report struct {
+	Data          json.RawMessage `json:"data"`
+	ReportContext [3]string       `json:"reportContext"`
+	ReportBlob    string          `json:"reportBlob"`
+	RawRs         []string        `json:"rawRs"`
+	RawSs         []string        `json:"rawSs"`
+	RawVs         string          `json:"rawVs"`
+}
+
+// valueKind selects the field values of a vector.
+type valueKind string
+
+const (
+	typical  valueKind = "typical"  // Realistic values
+	zero     valueKind = "zero"     // Zero values but the feed ID
+	extremes valueKind = "extremes" // Minimum or maximum values of the field types
+)
+
+// envelope is the ABI schema of the full report.
+var envelope = abi.Arguments{
+	{Name: "reportContext", Type: mustNewType("bytes32[3]")},
+	{Name: "reportBlob", Type: mustNewType("bytes")},
+	{Name: "rawRs", Type: mustNewType("bytes32[]")},
+	{Name: "rawSs", Type: mustNewType("bytes32[]")},
+	{Name: "rawVs", Type: mustNewType("bytes32")},
+}
+
+func main() {
+	schemasPath := flag.String("schemas", "schemas.json", "report schemas file")
+	out := flag.String("out", "testdata/vectors.json", "test vectors file")
+	flag.Parse()
+
+	b, err := generate(*schemasPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(*out, b, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the test vectors file of the schemas file.
+func generate(schemasPath string) ([]byte, error) {
+	b, err := os.ReadFile(schemasPath)
+	if err != nil {
+		return nil, err
+	}
+	var schemas Schemas
+	if err = json.Unmarshal(b, &schemas); err != nil {
+		return nil, fmt.Errorf("invalid schemas file %s: %w", schemasPath, err)
+	}
+
+	var vectors Vectors
+	for _, v := range schemas.Versions {
+		for _, kind := range []valueKind{typical, zero, extremes} {
+			var args abi.Arguments
+			var values []any
+			data := &bytes.Buffer{}
+			data.WriteByte('{')
+			for x, f := range v.Fields {
+				t, err := abi.NewType(f.Type, "", nil)
+				if err != nil {
+					return nil, fmt.Errorf("v%d field %s: %w", v.Version, f.Name, err)
+				}
+				value, text, err := fieldValue(t, v.Version, x, kind)
+				if err != nil {
+					return nil, fmt.Errorf("v%d field %s: %w", v.Version, f.Name, err)
+				}
+				args = append(args, abi.Argument{Name: f.Name, Type: t})
+				values = append(values, value)
+
+				if x > 0 {
+					data.WriteByte(',')
+				}
+				name, _ := json.Marshal(f.Name)
+				fmt.Fprintf(data, "%s:%s", name, text)
+			}
+			data.WriteByte('}')
+
+			blob, err := args.Pack(values...)
+			if err != nil {
+				return nil, fmt.Errorf("v%d: failed to pack data: %w", v.Version, err)
+			}
+			vector, err := newVector(v.Version, kind, blob, data.Bytes())
+			if err != nil {
+				return nil, err
+			}
+			vectors.Vectors = append(vectors.Vectors, vector)
+		}
+	}
+
+	b, err = json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// newVector packs the report blob in a full report with a report context and signatures depending on the kind.
+func newVector(version int, kind valueKind, blob []byte, data []byte) (Vector, error) {
+	var reportContext [3][32]byte
+	signatures := 0
+	switch kind {
+	case typical:
+		// config digest, epoch 0x01020304 and round 5, no extra hash
+		reportContext[0] = word(0x00, 0x06, byte(version))
+		reportContext[1][27], reportContext[1][28], reportContext[1][29], reportContext[1][30], reportContext[1][31] = 1, 2, 3, 4, 5
+		signatures = 4
+	case extremes:
+		for x := range reportContext {
+			reportContext[x] = word(0xff)
+		}
+		signatures = 32
+	}
+	rs, ss := make([][32]byte, signatures), make([][32]byte, signatures)
+	for x := range rs {
+		rs[x], ss[x] = word(0x0a, byte(x)), word(0x0b, byte(x))
+	}
+	vs := [32]byte{}
+	if signatures > 0 {
+		vs = word(0x1b)
+	}
+
+	fullReport, err := envelope.Pack(reportContext, blob, rs, ss, vs)
+	if err != nil {
+		return Vector{}, fmt.Errorf("v%d: failed to pack report: %w", version, err)
+	}
+
+	r := Report{
+		Data:       data,
+		ReportBlob: hexString(blob),
+		RawRs:      make([]string, len(rs)),
+		RawSs:      make([]string, len(ss)),
+		RawVs:      hexString(vs[:]),
+	}
+	for x := range reportContext {
+		r.ReportContext[x] = hexString(reportContext[x][:])
+	}
+	for x := range rs {
+		r.RawRs[x], r.RawSs[x] = hexString(rs[x][:]), hexString(ss[x][:])
+	}
+	return Vector{
+		Name:       fmt.Sprintf("v%d/%s", version, kind),
+		Version:    version,
+		FullReport: hexString(fullReport),
+		Report:     r,
+	}, nil
+}
+
+// fieldValue returns the Go value packed for the field x of the version and its expected JSON value.
+func fieldValue(t abi.Type, version, x int, kind valueKind) (v any, text string, err error) {
+	rv := reflect.New(t.GetType()).Elem()
+	switch {
+	case x == 0:
+		// the feed ID is always set, its first 2 bytes are the version
+		id := word(0x00, byte(version), byte(kind[0]), 0x01)
+		reflect.Copy(rv, reflect.ValueOf(id[:]))
+	case t.T == abi.IntTy || t.T == abi.UintTy:
+		i := intValue(t.T == abi.IntTy, t.Size, x, kind)
+		text = `"` + i.String() + `"`
+		switch rv.Kind() {
+		case reflect.Ptr:
+			rv.Set(reflect.ValueOf(i))
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			rv.SetInt(i.Int64())
+		default:
+			rv.SetUint(i.Uint64())
+		}
+	case t.T == abi.FixedBytesTy:
+		var b [32]byte
+		switch kind {
+		case typical:
+			b = word(0x0c, byte(x))
+		case extremes:
+			b = word(0xff)
+		}
+		reflect.Copy(rv, reflect.ValueOf(b[:t.Size]))
+	default:
+		return nil, "", fmt.Errorf("unsupported type %s", t.String())
+	}
+
+	if text == "" {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		text = `"` + hexString(b) + `"`
+	}
+	return rv.Interface(), text, nil
+}
+
+// intValue returns the value of the integer field x of the kind.
+func intValue(signed bool, bits, x int, kind valueKind) *big.Int {
+	switch kind {
+	case zero:
+		return new(big.Int)
+	case extremes:
+		// the maximum unsigned values, alternating the minimum and maximum signed values
+		if !signed {
+			return new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bits)), big.NewInt(1))
+		}
+		if x%2 == 0 {
+			return new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), uint(bits-1)))
+		}
+		return new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bits-1)), big.NewInt(1))
+	}
+	switch {
+	case bits <= 16:
+		return big.NewInt(int64(x))
+	case bits <= 64:
+		// timestamps and block numbers
+		return big.NewInt(1_700_000_000 + int64(x))
+	case signed && x%2 == 0:
+		// negative prices with 18 decimals
+		return new(big.Int).Mul(big.NewInt(-int64(x)*1_000), big.NewInt(1e18))
+	default:
+		return new(big.Int).Mul(big.NewInt(int64(x)*1_000), big.NewInt(1e18))
+	}
+}
+
+// word returns a 32 bytes word starting with the prefix bytes, the remaining bytes set to the last prefix byte.
+func word(prefix ...byte) (w [32]byte) {
+	copy(w[:], prefix)
+	for x := len(prefix); x < len(w); x++ {
+		w[x] = prefix[len(prefix)-1]
+	}
+	return w
+}
+
+func hexString(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func mustNewType(t string) abi.Type {
+	result, err := abi.NewType(t, "", []abi.ArgumentMarshaling{})
+	if err != nil {
+		panic(fmt.Sprintf("Unexpected error during abi.NewType: %s", err))
+	}
+	return result
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVectorsUpToDate(t *testing.T) {
+	reportDir := filepath.Join("..", "..")
+	want, err := generate(filepath.Join(reportDir, "schemas.json"))
+	if err != nil {
+		t.Fatalf("generate() error = %s", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(reportDir, "testdata", "vectors.json"))
+	if err != nil {
+		t.Fatalf("error reading test vectors: %s", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("testdata/vectors.json is out of date, run go generate ./report")
+	}
+}
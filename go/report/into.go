@@ -0,0 +1,133 @@
+package report
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// DecodeInto decodes the report data of the full report into the fields of the struct pointed to by dst
+// tagged with `abi:"name"`, name being a schema field name of the report feed version, e.g. benchmarkPrice.
+// Untagged fields are left unchanged, so dst can hold only the fields of interest along with application fields.
+// A field tagged with the optional option, e.g. `abi:"bid,optional"`, is left unchanged if the report feed version
+// has no such field, other tagged fields missing from the schema fail.
+//
+// Field values are converted to the destination field types: to named types of the same kind,
+// integers and big integers to any integer type they fit in, *big.Int to big.Int and fixed size bytes to []byte.
+func DecodeInto(fullReport []byte, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("report: cannot decode into %T, not a struct pointer", dst)
+	}
+	v = v.Elem()
+
+	values, err := schema.Unpack(fullReport)
+	if err != nil {
+		return fmt.Errorf("report: failed to unpack: %s", err)
+	}
+	blob, _ := values[1].([]byte)
+	var id feed.ID
+	if len(blob) < len(id) {
+		return fmt.Errorf("report: data too short for a feed ID: %d bytes", len(blob))
+	}
+	copy(id[:], blob)
+
+	dataSchema, ok := schemaOf(id.Version())
+	if !ok {
+		return fmt.Errorf("report: unsupported feed version %d of feed %s, latest supported v%d",
+			id.Version(), id.String(), LatestVersion)
+	}
+	dataValues, err := dataSchema.Unpack(blob)
+	if err != nil {
+		return fmt.Errorf("report: failed to unpack data: %s", err)
+	}
+	byName := make(map[string]any, len(dataSchema))
+	for x, arg := range dataSchema {
+		byName[arg.Name] = dataValues[x]
+	}
+
+	for x := 0; x < v.NumField(); x++ {
+		tag, ok := v.Type().Field(x).Tag.Lookup("abi")
+		if !ok || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		value, ok := byName[name]
+		switch {
+		case !ok && opts == "optional":
+			continue
+		case !ok:
+			return fmt.Errorf("report: no field %s in feed version %d", name, id.Version())
+		}
+		if err = setValue(v.Field(x), reflect.ValueOf(value)); err != nil {
+			return fmt.Errorf("report: field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setValue sets the dst field to the decoded src value, converting it to the dst type.
+func setValue(dst, src reflect.Value) error {
+	if !dst.CanSet() {
+		return fmt.Errorf("cannot set unexported field")
+	}
+
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+		return nil
+	case src.Type().ConvertibleTo(dst.Type()) && src.Kind() == dst.Kind():
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	case src.Type() == bigType && dst.Type() == bigType.Elem():
+		if !src.IsNil() {
+			dst.Set(src.Elem())
+		}
+		return nil
+	case src.Kind() == reflect.Array && src.Type().Elem().Kind() == reflect.Uint8 && dst.Type() == reflect.TypeOf([]byte{}):
+		b := make([]byte, src.Len())
+		reflect.Copy(reflect.ValueOf(b), src)
+		dst.SetBytes(b)
+		return nil
+	}
+
+	i, ok := bigValue(src)
+	if !ok {
+		return fmt.Errorf("cannot decode %s into %s", src.Type(), dst.Type())
+	}
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !i.IsInt64() || dst.OverflowInt(i.Int64()) {
+			return fmt.Errorf("integer %s overflows %s", i, dst.Type())
+		}
+		dst.SetInt(i.Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !i.IsUint64() || dst.OverflowUint(i.Uint64()) {
+			return fmt.Errorf("integer %s overflows %s", i, dst.Type())
+		}
+		dst.SetUint(i.Uint64())
+	default:
+		if dst.Type() != bigType {
+			return fmt.Errorf("cannot decode %s into %s", src.Type(), dst.Type())
+		}
+		dst.Set(reflect.ValueOf(i))
+	}
+	return nil
+}
+
+// bigValue returns the integer value of src, false if src is not an integer.
+func bigValue(src reflect.Value) (*big.Int, bool) {
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(src.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(src.Uint()), true
+	}
+	if src.Type() == bigType && !src.IsNil() {
+		return src.Interface().(*big.Int), true
+	}
+	return nil, false
+}
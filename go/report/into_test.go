@@ -0,0 +1,91 @@
+package report
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+)
+
+// price is an application type of a decoded price.
+type price int64
+
+func TestDecodeInto(t *testing.T) {
+	type quote struct {
+		Feed     feed.ID `abi:"feedId"`
+		FeedRaw  []byte  `abi:"feedId"`
+		Observed uint64  `abi:"observationsTimestamp"`
+		Price    price   `abi:"benchmarkPrice"`
+		Bid      big.Int `abi:"bid,optional"`
+		Status   uint8   `abi:"marketStatus,optional"`
+		Source   string
+	}
+
+	tests := []struct {
+		name string
+		b    []byte
+		want quote
+	}{
+		{name: "v3", b: mustEncode(t, v3Report), want: quote{
+			Feed: v3Data.FeedID, FeedRaw: v3Data.FeedID[:], Observed: uint64(v3Data.ObservationsTimestamp),
+			Price: price(v3Data.BenchmarkPrice.Int64()), Bid: *v3Data.Bid, Source: "app",
+		}},
+		{name: "v4", b: mustEncode(t, v4Report), want: quote{
+			Feed: v4Data.FeedID, FeedRaw: v4Data.FeedID[:], Observed: uint64(v4Data.ObservationsTimestamp),
+			Price: price(v4Data.BenchmarkPrice.Int64()), Status: uint8(v4Data.MarketStatus), Source: "app",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := quote{Source: "app"}
+			if err := DecodeInto(tt.b, &got); err != nil {
+				t.Fatalf("DecodeInto() error = %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeInto() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeInto_Errors(t *testing.T) {
+	b := mustEncode(t, v3Report)
+	overflow := *v3Report
+	overflow.Data.BenchmarkPrice = new(big.Int).Lsh(big.NewInt(1), 100)
+	overflow.Data.Bid = big.NewInt(-1)
+	overflow.ReportBlob = mustPackData(overflow.Data)
+	unsupported := &Report[v3.Data]{ReportBlob: append([]byte{0x00, 0xff}, make([]byte, 30)...)}
+
+	type priceOf[T any] struct {
+		Price T `abi:"benchmarkPrice"`
+	}
+	tests := []struct {
+		name    string
+		b       []byte
+		dst     any
+		wantErr string
+	}{
+		{name: "not a pointer", b: b, dst: priceOf[int64]{}, wantErr: "not a struct pointer"},
+		{name: "truncated", b: b[:10], dst: &priceOf[int64]{}, wantErr: "failed to unpack"},
+		{name: "unsupported version", b: mustEncode(t, unsupported), dst: &priceOf[int64]{}, wantErr: "unsupported feed version 255"},
+		{name: "missing field", b: b, dst: &struct {
+			Status uint32 `abi:"marketStatus"`
+		}{}, wantErr: "no field marketStatus in feed version 3"},
+		{name: "overflow", b: mustEncode(t, &overflow), dst: &priceOf[int64]{}, wantErr: "overflows int64"},
+		{name: "negative into unsigned", b: mustEncode(t, &overflow), dst: &struct {
+			Bid uint8 `abi:"bid"`
+		}{}, wantErr: "integer -1 overflows uint8"},
+		{name: "type mismatch", b: b, dst: &priceOf[string]{}, wantErr: "cannot decode *big.Int into string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DecodeInto(tt.b, tt.dst)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("DecodeInto() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
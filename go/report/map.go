@@ -0,0 +1,97 @@
+package report
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi"
+)
+
+// ToMap converts decoded report data, a vN.Data value, pointer or a *Report[T],
+// into a map keyed by the schema field names, e.g. feedId, observationsTimestamp.
+// Values keep their decoded Go types.
+func ToMap(decoded any) (m map[string]any, err error) {
+	v := reflect.ValueOf(decoded)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("report: cannot convert nil %T to map", decoded)
+		}
+		v = v.Elem()
+	}
+
+	// unwrap the data of a Report[T]
+	if v.Kind() == reflect.Struct {
+		if d := v.FieldByName("Data"); d.IsValid() {
+			v = d
+		}
+	}
+
+	s, ok := v.Interface().(interface{ Schema() abi.Arguments })
+	if !ok || v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("report: cannot convert %T to map, not a report data type", decoded)
+	}
+
+	fields := schemaFields(v.Type())
+	m = make(map[string]any, len(s.Schema()))
+	for _, arg := range s.Schema() {
+		f, ok := fields[arg.Name]
+		if !ok {
+			return nil, fmt.Errorf("report: field %s not found in %T", arg.Name, decoded)
+		}
+		m[arg.Name] = v.FieldByIndex(f).Interface()
+	}
+	return m, nil
+}
+
+// FromMap converts a map keyed by the schema field names, as returned by ToMap,
+// into the report data type. Every schema field must be present.
+func FromMap[T Data](m map[string]any) (d T, err error) {
+	v := reflect.ValueOf(&d).Elem()
+	fields := schemaFields(v.Type())
+	for _, arg := range d.Schema() {
+		value, ok := m[arg.Name]
+		if !ok {
+			return d, fmt.Errorf("report: missing field %s", arg.Name)
+		}
+
+		f := v.FieldByIndex(fields[arg.Name])
+		src := reflect.ValueOf(value)
+		switch {
+		case !src.IsValid():
+			return d, fmt.Errorf("report: nil value for field %s", arg.Name)
+		case src.Type().AssignableTo(f.Type()):
+			f.Set(src)
+		case src.Type().ConvertibleTo(f.Type()) && src.Kind() == f.Kind():
+			f.Set(src.Convert(f.Type()))
+		default:
+			return d, fmt.Errorf("report: invalid type %T for field %s, expected %s", value, arg.Name, f.Type())
+		}
+	}
+	return d, nil
+}
+
+// schemaFields maps the schema field names to the struct field index
+// following the abi struct tags and naming conventions.
+func schemaFields(t reflect.Type) (fields map[string][]int) {
+	fields = make(map[string][]int, t.NumField())
+	for x := 0; x < t.NumField(); x++ {
+		f := t.Field(x)
+		if tag := f.Tag.Get("abi"); tag != "" {
+			fields[tag] = f.Index
+		}
+	}
+
+	s, ok := reflect.New(t).Elem().Interface().(interface{ Schema() abi.Arguments })
+	if !ok {
+		return fields
+	}
+	for _, arg := range s.Schema() {
+		if _, ok := fields[arg.Name]; ok {
+			continue
+		}
+		if f, ok := t.FieldByName(abi.ToCamelCase(arg.Name)); ok {
+			fields[arg.Name] = f.Index
+		}
+	}
+	return fields
+}
@@ -0,0 +1,39 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+func TestToMap(t *testing.T) {
+	m, err := ToMap(v4Report)
+	if err != nil {
+		t.Fatalf("ToMap() error = %s", err)
+	}
+
+	if len(m) != len(v4.Schema()) {
+		t.Errorf("expected %d fields, got %d", len(v4.Schema()), len(m))
+	}
+	if m["feedId"] != v4Data.FeedID || m["marketStatus"] != v4Data.MarketStatus {
+		t.Errorf("unexpected map values %#v", m)
+	}
+
+	d, err := FromMap[v4.Data](m)
+	if err != nil {
+		t.Fatalf("FromMap() error = %s", err)
+	}
+	if !reflect.DeepEqual(d, v4Data) {
+		t.Errorf("FromMap() = %#v, want %#v", d, v4Data)
+	}
+
+	if _, err = FromMap[v3.Data](m); err == nil {
+		t.Errorf("expected error converting map with missing fields")
+	}
+
+	if _, err = ToMap(struct{}{}); err == nil {
+		t.Errorf("expected error converting a non report data type")
+	}
+}
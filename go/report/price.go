@@ -0,0 +1,17 @@
+package report
+
+import "math/big"
+
+// PriceReport gives access to the fields common to the report data versions, implemented by
+// every vN.Data type, so applications can handle the data of feeds of mixed versions without
+// a type switch, e.g. on the AnyData of the reports returned by DecodeAny.
+// The accessors return zero values for the fields not part of a data version.
+type PriceReport interface {
+	// BenchmarkOrMid returns the benchmark price, or the mid price of the bid and ask
+	// when the benchmark price is not set, nil if neither is available.
+	BenchmarkOrMid() *big.Int
+	// Timestamps returns the data validity start, observation and expiry timestamps in unix seconds.
+	Timestamps() (validFrom, observations, expiresAt uint32)
+	// Fees returns the verification fees in native and LINK tokens.
+	Fees() (native, link *big.Int)
+}
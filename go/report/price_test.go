@@ -0,0 +1,43 @@
+package report
+
+import (
+	"math/big"
+	"testing"
+
+	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+)
+
+func TestPriceReport(t *testing.T) {
+	tests := []struct {
+		name                               string
+		data                               PriceReport
+		wantPrice                          *big.Int
+		wantValidFrom, wantObs, wantExpiry uint32
+		wantNative, wantLink               *big.Int
+	}{
+		{name: "v1", data: v1Data, wantPrice: v1Data.BenchmarkPrice, wantObs: v1Data.ObservationsTimestamp},
+		{name: "v1 mid", data: v1.Data{Bid: big.NewInt(99), Ask: big.NewInt(102)}, wantPrice: big.NewInt(100)},
+		{name: "v2", data: v2Data, wantPrice: v2Data.BenchmarkPrice, wantValidFrom: v2Data.ValidFromTimestamp,
+			wantObs: v2Data.ObservationsTimestamp, wantExpiry: v2Data.ExpiresAt, wantNative: v2Data.NativeFee, wantLink: v2Data.LinkFee},
+		{name: "v3", data: v3Data, wantPrice: v3Data.BenchmarkPrice, wantValidFrom: v3Data.ValidFromTimestamp,
+			wantObs: v3Data.ObservationsTimestamp, wantExpiry: v3Data.ExpiresAt, wantNative: v3Data.NativeFee, wantLink: v3Data.LinkFee},
+		{name: "v3 no price", data: v3.Data{}},
+		{name: "v4", data: v4Data, wantPrice: v4Data.BenchmarkPrice, wantValidFrom: v4Data.ValidFromTimestamp,
+			wantObs: v4Data.ObservationsTimestamp, wantExpiry: v4Data.ExpiresAt, wantNative: v4Data.NativeFee, wantLink: v4Data.LinkFee},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.BenchmarkOrMid(); (got == nil) != (tt.wantPrice == nil) || got != nil && got.Cmp(tt.wantPrice) != 0 {
+				t.Errorf("BenchmarkOrMid() = %v, want %v", got, tt.wantPrice)
+			}
+			validFrom, obs, expiry := tt.data.Timestamps()
+			if validFrom != tt.wantValidFrom || obs != tt.wantObs || expiry != tt.wantExpiry {
+				t.Errorf("Timestamps() = %d, %d, %d, want %d, %d, %d", validFrom, obs, expiry, tt.wantValidFrom, tt.wantObs, tt.wantExpiry)
+			}
+			if native, link := tt.data.Fees(); native != tt.wantNative || link != tt.wantLink {
+				t.Errorf("Fees() = %v, %v, want %v, %v", native, link, tt.wantNative, tt.wantLink)
+			}
+		})
+	}
+}
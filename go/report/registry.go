@@ -0,0 +1,139 @@
+package report
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi"
+)
+
+// registeredSchema is the report data schema of a feed version registered with RegisterSchema.
+type registeredSchema struct {
+	args    abi.Arguments
+	factory func() any
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[feed.FeedVersion]registeredSchema{}
+)
+
+// RegisterSchema registers the report data schema of a feed version not built in this package, such as a
+// private or preview version, so that its reports are decoded by DecodeAny and DecodeInto and the version
+// is supported by IsSupported and SchemaJSON. The schema can be declared with the ABI implementation types
+// or parsed from the standard ABI JSON format with ParseSchemaJSON, its first field must be the bytes32 feed ID.
+//
+// The factory returns a pointer to a new data struct whose fields match the schema field names as vN.Data do,
+// tagged with `abi:"name"` or named after the camel cased field names. DecodeAny returns the reports of the
+// version as a *CustomReport holding the data struct value. Built-in versions cannot be registered nor
+// a version registered twice. Registration is typically done in an init function.
+func RegisterSchema(v feed.FeedVersion, args abi.Arguments, factory func() any) error {
+	if v == 0 {
+		return fmt.Errorf("report: invalid feed version 0")
+	}
+	if v <= LatestVersion {
+		return fmt.Errorf("report: feed version %d is built in", v)
+	}
+	if len(args) == 0 || args[0].Type.String() != "bytes32" {
+		return fmt.Errorf("report: feed version %d schema must start with the bytes32 feed ID", v)
+	}
+	if factory == nil {
+		return fmt.Errorf("report: nil factory for feed version %d", v)
+	}
+
+	// copy zero values to check that the data struct matches the schema
+	values := make([]any, len(args))
+	for x, arg := range args {
+		values[x] = reflect.New(arg.Type.GetType()).Elem().Interface()
+	}
+	if err := abi.Copy(args, factory(), values); err != nil {
+		return fmt.Errorf("report: feed version %d data type does not match its schema: %w", v, err)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[v]; ok {
+		return fmt.Errorf("report: feed version %d already registered", v)
+	}
+	registry[v] = registeredSchema{args: args, factory: factory}
+	return nil
+}
+
+// registered returns the registered schema of the feed version, false if none.
+func registered(v feed.FeedVersion) (registeredSchema, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	s, ok := registry[v]
+	return s, ok
+}
+
+// registeredVersions returns the registered feed versions, oldest first.
+func registeredVersions() []feed.FeedVersion {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return slices.Sorted(maps.Keys(registry))
+}
+
+// schemaOf returns the report data schema of the built-in or registered feed version, false if unsupported.
+func schemaOf(v feed.FeedVersion) (abi.Arguments, bool) {
+	if args, ok := dataSchema(v); ok {
+		return args, true
+	}
+	s, ok := registered(v)
+	return s.args, ok
+}
+
+// CustomReport is a report of a feed version registered with RegisterSchema as returned by DecodeAny.
+type CustomReport struct {
+	Data          any // Data struct value of the registered factory type
+	ReportContext [3][32]byte
+	ReportBlob    []byte
+	RawRs         [][32]byte
+	RawSs         [][32]byte
+	RawVs         [32]byte
+
+	version feed.FeedVersion
+}
+
+// Version returns the feed version of the report data.
+func (r *CustomReport) Version() feed.FeedVersion {
+	return r.version
+}
+
+// FeedID returns the feed ID of the report data.
+func (r *CustomReport) FeedID() (id feed.ID) {
+	copy(id[:], r.ReportBlob)
+	return id
+}
+
+// AnyData returns the report data, a value of the registered factory type.
+func (r *CustomReport) AnyData() any {
+	return r.Data
+}
+
+// decode decodes the full report with the registered schema.
+func (s registeredSchema) decode(v feed.FeedVersion, fullReport []byte) (*CustomReport, error) {
+	r := &CustomReport{version: v}
+	values, err := schema.Unpack(fullReport)
+	if err != nil {
+		return nil, fmt.Errorf("report: failed to unpack: %s", err)
+	}
+	if err = schema.Copy(r, values); err != nil {
+		return nil, fmt.Errorf("report: failed to copy: %s", err)
+	}
+
+	dataValues, err := s.args.Unpack(r.ReportBlob)
+	if err != nil {
+		return nil, fmt.Errorf("report: failed to unpack data: %s", err)
+	}
+	data := s.factory()
+	if err = abi.Copy(s.args, data, dataValues); err != nil {
+		return nil, fmt.Errorf("report: failed to copy data: %s", err)
+	}
+	r.Data = reflect.ValueOf(data).Elem().Interface()
+	return r, nil
+}
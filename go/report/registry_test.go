@@ -0,0 +1,155 @@
+package report
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+const previewVersion feed.FeedVersion = 200
+
+const previewSchemaJSON = `[{"name":"feedId","type":"bytes32"},{"name":"observationsTimestamp","type":"uint32"},` +
+	`{"name":"price","type":"int192"},{"name":"flags","type":"uint8"}]`
+
+type previewData struct {
+	FeedID                [32]byte `abi:"feedId"`
+	ObservationsTimestamp uint32
+	Price                 *big.Int
+	Flags                 uint8
+}
+
+// registerPreview registers the preview version schema for the duration of the test.
+func registerPreview(t *testing.T) {
+	args, err := ParseSchemaJSON([]byte(previewSchemaJSON))
+	if err != nil {
+		t.Fatalf("ParseSchemaJSON() error = %s", err)
+	}
+	if err = RegisterSchema(previewVersion, args, func() any { return &previewData{} }); err != nil {
+		t.Fatalf("RegisterSchema() error = %s", err)
+	}
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, previewVersion)
+	})
+}
+
+func TestRegisterSchema(t *testing.T) {
+	registerPreview(t)
+
+	data := previewData{
+		FeedID:                [32]byte{0x00, byte(previewVersion), 0x01},
+		ObservationsTimestamp: 1_700_000_000,
+		Price:                 big.NewInt(-42),
+		Flags:                 3,
+	}
+	args, _ := schemaOf(previewVersion)
+	blob, err := args.Pack(data.FeedID, data.ObservationsTimestamp, data.Price, data.Flags)
+	if err != nil {
+		t.Fatalf("failed to pack data: %s", err)
+	}
+	want := &CustomReport{
+		Data:          data,
+		ReportContext: v3Report.ReportContext,
+		ReportBlob:    blob,
+		RawRs:         v3Report.RawRs,
+		RawSs:         v3Report.RawSs,
+		RawVs:         v3Report.RawVs,
+		version:       previewVersion,
+	}
+	b, err := schema.Pack(want.ReportContext, want.ReportBlob, want.RawRs, want.RawSs, want.RawVs)
+	if err != nil {
+		t.Fatalf("failed to pack report: %s", err)
+	}
+
+	r, err := DecodeAny(b)
+	if err != nil {
+		t.Fatalf("DecodeAny() error = %s", err)
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("DecodeAny() = %#v, want %#v", r, want)
+	}
+	if r.Version() != previewVersion || r.FeedID() != feed.ID(data.FeedID) {
+		t.Errorf("Version(), FeedID() = %d, %x, want %d, %x", r.Version(), r.FeedID(), previewVersion, data.FeedID)
+	}
+
+	var into struct {
+		Price int64 `abi:"price"`
+	}
+	if err = DecodeInto(b, &into); err != nil || into.Price != -42 {
+		t.Errorf("DecodeInto() = %d, %v, want -42", into.Price, err)
+	}
+
+	if !IsSupported(previewVersion) {
+		t.Errorf("IsSupported(%d) = false, want true", previewVersion)
+	}
+	if versions := SupportedVersions(); versions[len(versions)-1] != previewVersion {
+		t.Errorf("SupportedVersions() = %v, want %d last", versions, previewVersion)
+	}
+	if s, err := SchemaJSON(previewVersion); err != nil || string(s) != previewSchemaJSON {
+		t.Errorf("SchemaJSON() = %s, %v, want %s", s, err, previewSchemaJSON)
+	}
+}
+
+func TestRegisterSchema_Errors(t *testing.T) {
+	registerPreview(t)
+
+	args, err := ParseSchemaJSON([]byte(previewSchemaJSON))
+	if err != nil {
+		t.Fatalf("ParseSchemaJSON() error = %s", err)
+	}
+	newPreview := func() any { return &previewData{} }
+
+	tests := []struct {
+		name    string
+		version feed.FeedVersion
+		args    []byte
+		factory func() any
+	}{
+		{name: "zero version", version: 0, factory: newPreview},
+		{name: "built in version", version: feed.FeedVersion3, factory: newPreview},
+		{name: "already registered", version: previewVersion, factory: newPreview},
+		{name: "no feed ID", version: previewVersion + 1, args: []byte(`[{"name":"price","type":"int192"}]`), factory: newPreview},
+		{name: "nil factory", version: previewVersion + 1},
+		{name: "not a pointer", version: previewVersion + 1, factory: func() any { return previewData{} }},
+		{name: "missing field", version: previewVersion + 1, factory: func() any {
+			return &struct {
+				FeedID [32]byte `abi:"feedId"`
+			}{}
+		}},
+		{name: "mismatched field type", version: previewVersion + 1, factory: func() any {
+			return &struct {
+				FeedID                [32]byte `abi:"feedId"`
+				ObservationsTimestamp string
+				Price                 *big.Int
+				Flags                 uint8
+			}{}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := args
+			if tt.args != nil {
+				if a, err = ParseSchemaJSON(tt.args); err != nil {
+					t.Fatalf("ParseSchemaJSON() error = %s", err)
+				}
+			}
+			if err := RegisterSchema(tt.version, a, tt.factory); err == nil {
+				t.Errorf("RegisterSchema() error = nil, want error")
+			}
+			if tt.version != previewVersion && IsSupported(tt.version) != (tt.version == feed.FeedVersion3) {
+				t.Errorf("IsSupported(%d) changed by a failed registration", tt.version)
+			}
+		})
+	}
+}
+
+func TestParseSchemaJSON_Errors(t *testing.T) {
+	for _, s := range []string{`{}`, `[{"name":"feedId","type":"bytes33"}]`} {
+		if _, err := ParseSchemaJSON([]byte(s)); err == nil {
+			t.Errorf("ParseSchemaJSON(%s) error = nil, want error", s)
+		}
+	}
+}
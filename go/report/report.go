@@ -3,19 +3,9 @@ package report
 import (
 	"fmt"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
-	v2 "github.com/smartcontractkit/data-streams-sdk/go/report/v2"
-	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
-	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi"
 )
 
-// Data represents the actual report data and attributes
-type Data interface {
-	v1.Data | v2.Data | v3.Data | v4.Data
-	Schema() abi.Arguments
-}
-
 // Report is the full report content
 type Report[T Data] struct {
 	Data          T
@@ -31,27 +21,46 @@ func Decode[T Data](fullReport []byte) (r *Report[T], err error) {
 	r = &Report[T]{}
 	values, err := schema.Unpack(fullReport)
 	if err != nil {
+		recordFailure[T](FailureUnpack, fullReport, nil)
 		return nil, fmt.Errorf("report: failed to unpack: %s", err)
 	}
 	err = schema.Copy(r, values)
 	if err != nil {
+		recordFailure[T](FailureCopy, fullReport, nil)
 		return nil, fmt.Errorf("report: failed to copy: %s", err)
 	}
 
 	dataSchema := r.Data.Schema()
 	dataValues, err := dataSchema.Unpack(r.ReportBlob)
 	if err != nil {
+		recordFailure[T](FailureUnpackData, fullReport, r.ReportBlob)
 		return nil, fmt.Errorf("report: failed to unpack data: %s", err)
 	}
 
-	err = dataSchema.Copy(&r.Data, dataValues)
+	err = abi.Copy(dataSchema, &r.Data, dataValues)
 	if err != nil {
+		recordFailure[T](FailureCopyData, fullReport, r.ReportBlob)
 		return nil, fmt.Errorf("report: failed to copy data: %s", err)
 	}
 
 	return r, nil
 }
 
+// Encode serializes the report, the reverse of Decode. The report blob is encoded from the report Data,
+// ReportBlob is ignored, and packed with the report context and signatures.
+func Encode[T Data](r *Report[T]) (b []byte, err error) {
+	blob, err := encodeData(&r.Data)
+	if err != nil {
+		return nil, fmt.Errorf("report: failed to pack data: %w", err)
+	}
+
+	b, err = schema.Pack(r.ReportContext, blob, r.RawRs, r.RawSs, r.RawVs)
+	if err != nil {
+		return nil, fmt.Errorf("report: failed to pack: %w", err)
+	}
+	return b, nil
+}
+
 var schema = abi.Arguments{
 	{Name: "reportContext", Type: mustNewType("bytes32[3]")},
 	{Name: "reportBlob", Type: mustNewType("bytes")},
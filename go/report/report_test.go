@@ -1,13 +1,14 @@
 package report
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/smartcontractkit/data-streams-sdk/go/report/internal/abi"
 	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
 	v2 "github.com/smartcontractkit/data-streams-sdk/go/report/v2"
 	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
@@ -72,6 +73,38 @@ func TestReport(t *testing.T) {
 	}
 }
 
+func TestEncode(t *testing.T) {
+	testEncode(t, v1Report)
+	testEncode(t, v2Report)
+	testEncode(t, v3Report)
+	testEncode(t, v4Report)
+}
+
+func testEncode[T Data](t *testing.T, r *Report[T]) {
+	t.Helper()
+
+	b, err := Encode(r)
+	if err != nil {
+		t.Fatalf("Encode() error = %s", err)
+	}
+
+	want, err := schema.Pack(r.ReportContext, r.ReportBlob, r.RawRs, r.RawSs, r.RawVs)
+	if err != nil {
+		t.Fatalf("failed to encode report: %s", err)
+	}
+	if !bytes.Equal(b, want) {
+		t.Errorf("Encode() = %x, want %x", b, want)
+	}
+
+	decoded, err := Decode[T](b)
+	if err != nil {
+		t.Fatalf("failed to decode report: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, r) {
+		t.Errorf("Decode(Encode()) = %#v, want %#v", decoded, r)
+	}
+}
+
 var v1Report = &Report[v1.Data]{
 	Data:          v1Data,
 	ReportContext: [3][32]uint8{},
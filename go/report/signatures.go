@@ -0,0 +1,109 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrNoQuorum is returned by VerifySignatures when the report is signed by at most f of the signers.
+var ErrNoQuorum = errors.New("report: signatures quorum not reached")
+
+// envelope is the report envelope of any data version.
+type envelope struct {
+	ReportContext [3][32]byte
+	ReportBlob    []byte
+	RawRs         [][32]byte
+	RawSs         [][32]byte
+	RawVs         [32]byte
+}
+
+// VerifySignatures verifies offchain the report signatures of the full report, of any data version,
+// against the Ethereum addresses of the DON signers, as the onchain verifier does: the signers are
+// recovered from the RawRs, RawSs and RawVs signatures of the report blob and context, each signature
+// must be from a distinct listed signer and more than f signers must have signed the report.
+// A report signed by at most f signers fails with ErrNoQuorum.
+//
+// The signer addresses of a DON are published with its configuration on the verifier contract,
+// a go-ethereum common.Address converts to [20]byte.
+func VerifySignatures(fullReport []byte, signers [][20]byte, f int) (err error) {
+	if f < 0 {
+		return fmt.Errorf("report: invalid fault tolerance %d", f)
+	}
+
+	values, err := schema.Unpack(fullReport)
+	if err != nil {
+		return fmt.Errorf("report: failed to unpack: %s", err)
+	}
+	var e envelope
+	if err = schema.Copy(&e, values); err != nil {
+		return fmt.Errorf("report: failed to copy: %s", err)
+	}
+
+	if len(e.RawRs) != len(e.RawSs) {
+		return fmt.Errorf("report: mismatched signatures, %d rs and %d ss", len(e.RawRs), len(e.RawSs))
+	}
+	if len(e.RawRs) > len(e.RawVs) {
+		return fmt.Errorf("report: too many signatures: %d", len(e.RawRs))
+	}
+
+	hash := signingHash(e.ReportBlob, e.ReportContext)
+	seen := make([][20]byte, 0, len(e.RawRs))
+	for x := range e.RawRs {
+		signer, err := recoverSigner(hash, e.RawRs[x], e.RawSs[x], e.RawVs[x])
+		if err != nil {
+			return fmt.Errorf("report: signature %d: %w", x, err)
+		}
+		if !slices.Contains(signers, signer) {
+			return fmt.Errorf("report: signature %d: unexpected signer 0x%x", x, signer)
+		}
+		if slices.Contains(seen, signer) {
+			return fmt.Errorf("report: signature %d: duplicate signer 0x%x", x, signer)
+		}
+		seen = append(seen, signer)
+	}
+
+	if len(seen) <= f {
+		return fmt.Errorf("%w: %d signers, need %d", ErrNoQuorum, len(seen), f+1)
+	}
+	return nil
+}
+
+// signingHash returns the hash signed by the DON: keccak256(keccak256(reportBlob) || reportContext).
+func signingHash(reportBlob []byte, reportContext [3][32]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(reportBlob)
+	blobHash := h.Sum(nil)
+
+	h.Reset()
+	h.Write(blobHash)
+	for _, word := range reportContext {
+		h.Write(word[:])
+	}
+	return h.Sum(nil)
+}
+
+// recoverSigner returns the Ethereum address of the signer of the hash.
+func recoverSigner(hash []byte, r, s [32]byte, v byte) (signer [20]byte, err error) {
+	if v > 1 {
+		return signer, fmt.Errorf("invalid recovery id %d", v)
+	}
+
+	// compact signatures are prefixed with 27 + the recovery id for uncompressed public keys
+	sig := make([]byte, 0, 65)
+	sig = append(sig, 27+v)
+	sig = append(sig, r[:]...)
+	sig = append(sig, s[:]...)
+	pub, _, err := ecdsa.RecoverCompact(sig, hash)
+	if err != nil {
+		return signer, err
+	}
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write(pub.SerializeUncompressed()[1:])
+	copy(signer[:], h.Sum(nil)[12:])
+	return signer, nil
+}
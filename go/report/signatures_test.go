@@ -0,0 +1,98 @@
+package report
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+)
+
+// signedReport returns the full report signed by the keys.
+func signedReport(t *testing.T, keys ...*secp256k1.PrivateKey) []byte {
+	t.Helper()
+	r := &Report[v3.Data]{Data: v3Data, ReportContext: [3][32]byte{{1}, {2}, {3}}}
+	blob, err := encodeData(&r.Data)
+	if err != nil {
+		t.Fatalf("error encoding data: %s", err)
+	}
+
+	hash := signingHash(blob, r.ReportContext)
+	for x, key := range keys {
+		sig := ecdsa.SignCompact(key, hash, false)
+		var rs, ss [32]byte
+		copy(rs[:], sig[1:33])
+		copy(ss[:], sig[33:])
+		r.RawRs, r.RawSs = append(r.RawRs, rs), append(r.RawSs, ss)
+		r.RawVs[x] = sig[0] - 27
+	}
+
+	b, err := Encode(r)
+	if err != nil {
+		t.Fatalf("Encode() error = %s", err)
+	}
+	return b
+}
+
+func address(key *secp256k1.PrivateKey) (a [20]byte) {
+	var r, s [32]byte
+	hash := make([]byte, 32)
+	sig := ecdsa.SignCompact(key, hash, false)
+	copy(r[:], sig[1:33])
+	copy(s[:], sig[33:])
+	a, err := recoverSigner(hash, r, s, sig[0]-27)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func TestVerifySignatures(t *testing.T) {
+	var keys []*secp256k1.PrivateKey
+	var signers [][20]byte
+	for x := 1; x <= 5; x++ {
+		var b [32]byte
+		b[31] = byte(x)
+		key := secp256k1.PrivKeyFromBytes(b[:])
+		keys, signers = append(keys, key), append(signers, address(key))
+	}
+
+	// the address of the private key 1 is a well known value
+	if got := hex.EncodeToString(signers[0][:]); got != "7e5f4552091a69125d5dfcb7b8c2659029395bdf" {
+		t.Fatalf("address of key 1 = %s", got)
+	}
+
+	tampered := signedReport(t, keys[0], keys[1])
+	tampered[len(tampered)-1] ^= 1
+
+	tests := []struct {
+		name       string
+		fullReport []byte
+		signers    [][20]byte
+		f          int
+		wantErr    bool
+		wantQuorum bool
+	}{
+		{name: "quorum", fullReport: signedReport(t, keys[0], keys[1]), signers: signers[:4], f: 1},
+		{name: "more than quorum", fullReport: signedReport(t, keys[3], keys[0], keys[2]), signers: signers[:4], f: 1},
+		{name: "no quorum", fullReport: signedReport(t, keys[0]), signers: signers[:4], f: 1, wantErr: true, wantQuorum: true},
+		{name: "unexpected signer", fullReport: signedReport(t, keys[0], keys[4]), signers: signers[:4], f: 1, wantErr: true},
+		{name: "duplicate signer", fullReport: signedReport(t, keys[0], keys[0]), signers: signers[:4], f: 1, wantErr: true},
+		{name: "tampered", fullReport: tampered, signers: signers[:4], f: 1, wantErr: true},
+		{name: "invalid f", fullReport: signedReport(t, keys[0]), signers: signers[:4], f: -1, wantErr: true},
+		{name: "invalid report", fullReport: []byte("invalid"), signers: signers[:4], f: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignatures(tt.fullReport, tt.signers, tt.f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifySignatures() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrNoQuorum) != tt.wantQuorum {
+				t.Errorf("VerifySignatures() error = %v, want ErrNoQuorum %v", err, tt.wantQuorum)
+			}
+		})
+	}
+}
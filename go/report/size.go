@@ -0,0 +1,123 @@
+package report
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// Length validation errors of CheckLength.
+var (
+	// ErrTruncatedReport is returned for full reports shorter than their expected size, typically cut in transit.
+	ErrTruncatedReport = errors.New("report: truncated report")
+	// ErrTrailingBytes is returned for full reports longer than their expected size,
+	// typically a report of another feed version.
+	ErrTrailingBytes = errors.New("report: trailing bytes after report")
+)
+
+// FullReportSize returns the size in bytes of the ABI encoded full report of the feed version
+// with the given number of signatures, typically the fault tolerance of the DON plus one.
+func FullReportSize(v feed.FeedVersion, signatures int) (size int, err error) {
+	if signatures < 0 || signatures > 32 {
+		return 0, fmt.Errorf("report: invalid number of signatures %d", signatures)
+	}
+	data, ok := dataSize(v)
+	if !ok {
+		return 0, fmt.Errorf("report: unknown report size of feed version %d", v)
+	}
+
+	// the report context, the reportBlob, rawRs and rawSs offsets and rawVs words
+	size = 7 * 32
+	// the reportBlob, rawRs and rawSs lengths and contents
+	size += 32 + (data+31)/32*32
+	size += 2 * (32 + 32*signatures)
+	return size, nil
+}
+
+// CheckLength validates the full report length before it is unpacked by Decode, distinguishing reports cut short,
+// typically in transit, from reports of another feed version than the data type T:
+//   - ErrTruncatedReport is returned if the full report is shorter than the size of its encoded report data
+//     and signatures, or if its report data is shorter than the data size of the version of T,
+//   - ErrTrailingBytes is returned if the full report is longer than the size of its encoded report data
+//     and signatures, or if its report data is longer than the data size of the version of T.
+//
+// Failures are recorded in the DecodeFailures.
+func CheckLength[T Data](fullReport []byte) error {
+	err := checkLength(VersionOf[T](), fullReport)
+	if errors.Is(err, ErrTruncatedReport) {
+		recordFailure[T](FailureTruncated, fullReport, nil)
+	} else if errors.Is(err, ErrTrailingBytes) {
+		recordFailure[T](FailureTrailingBytes, fullReport, nil)
+	}
+	return err
+}
+
+func checkLength(v feed.FeedVersion, fullReport []byte) error {
+	if len(fullReport) < envelopeHeadSize {
+		return fmt.Errorf("%w: %d bytes, want at least %d", ErrTruncatedReport, len(fullReport), envelopeHeadSize)
+	}
+	// readLength reads the length word at the offset read from the envelope head word x
+	readLength := func(x int) (uint64, error) {
+		offset, err := readUint(fullReport[x*wordSize:])
+		if err != nil {
+			return 0, fmt.Errorf("report: invalid offset: %w", err)
+		}
+		if offset > uint64(len(fullReport)-wordSize) {
+			return 0, fmt.Errorf("%w: %d bytes, want at least %d", ErrTruncatedReport, len(fullReport), offset+wordSize)
+		}
+		n, err := readUint(fullReport[offset:])
+		if err != nil {
+			return 0, fmt.Errorf("report: invalid length: %w", err)
+		}
+		return n, nil
+	}
+
+	data, err := readLength(3)
+	if err != nil {
+		return err
+	}
+	rs, err := readLength(4)
+	if err != nil {
+		return err
+	}
+	ss, err := readLength(5)
+	if err != nil {
+		return err
+	}
+	if data > uint64(len(fullReport)) || rs > 32 || ss > 32 {
+		return fmt.Errorf("%w: report data of %d bytes with %d rs and %d ss signatures, %d bytes",
+			ErrTruncatedReport, data, rs, ss, len(fullReport))
+	}
+
+	// the envelope head, the reportBlob, rawRs and rawSs lengths and contents
+	want := envelopeHeadSize + wordSize + (int(data)+wordSize-1)/wordSize*wordSize +
+		wordSize + wordSize*int(rs) + wordSize + wordSize*int(ss)
+	switch {
+	case len(fullReport) < want:
+		return fmt.Errorf("%w: %d bytes, want %d", ErrTruncatedReport, len(fullReport), want)
+	case len(fullReport) > want:
+		return fmt.Errorf("%w: %d bytes, want %d", ErrTrailingBytes, len(fullReport), want)
+	}
+
+	size, ok := dataSize(v)
+	switch {
+	case !ok:
+	case int(data) < size:
+		return fmt.Errorf("%w: %d bytes of report data, want %d", ErrTruncatedReport, data, size)
+	case int(data) > size:
+		return fmt.Errorf("%w: %d bytes of report data, want %d", ErrTrailingBytes, data, size)
+	}
+	return nil
+}
+
+// readUint reads the 64 bits unsigned integer of the first word of b.
+func readUint(b []byte) (uint64, error) {
+	for _, c := range b[:wordSize-8] {
+		if c != 0 {
+			return 0, errors.New("word overflows 64 bits")
+		}
+	}
+	return binary.BigEndian.Uint64(b[wordSize-8 : wordSize]), nil
+}
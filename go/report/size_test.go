@@ -0,0 +1,69 @@
+package report
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+func TestFullReportSize(t *testing.T) {
+	for _, signatures := range []int{0, 4} {
+		r := *v3Report
+		r.RawRs, r.RawSs = make([][32]byte, signatures), make([][32]byte, signatures)
+		b, err := Encode(&r)
+		if err != nil {
+			t.Fatalf("Encode() error = %s", err)
+		}
+
+		size, err := FullReportSize(feed.FeedVersion3, signatures)
+		if err != nil {
+			t.Fatalf("FullReportSize() error = %s", err)
+		}
+		if size != len(b) {
+			t.Errorf("FullReportSize(%d signatures) = %d, want %d", signatures, size, len(b))
+		}
+	}
+
+	if _, err := FullReportSize(LatestVersion+1, 4); err == nil {
+		t.Errorf("FullReportSize() of an unsupported version error = nil, want error")
+	}
+	if _, err := FullReportSize(feed.FeedVersion3, -1); err == nil {
+		t.Errorf("FullReportSize() of negative signatures error = nil, want error")
+	}
+}
+
+func TestCheckLength(t *testing.T) {
+	r := *v3Report
+	r.RawRs, r.RawSs = make([][32]byte, 4), make([][32]byte, 4)
+	b := mustEncode(t, &r)
+	b4 := mustEncode(t, v4Report)
+	r.RawSs = r.RawSs[:2]
+	fewerSs := mustEncode(t, &r)
+
+	tests := []struct {
+		name    string
+		b       []byte
+		check   func([]byte) error
+		wantErr error
+	}{
+		{name: "valid", b: b, check: CheckLength[v3.Data]},
+		{name: "fewer ss than rs", b: fewerSs, check: CheckLength[v3.Data]},
+		{name: "truncated ss", b: fewerSs[:len(fewerSs)-wordSize], check: CheckLength[v3.Data], wantErr: ErrTruncatedReport},
+		{name: "truncated signatures", b: b[:len(b)-1], check: CheckLength[v3.Data], wantErr: ErrTruncatedReport},
+		{name: "truncated data", b: b[:envelopeHeadSize+2*wordSize], check: CheckLength[v3.Data], wantErr: ErrTruncatedReport},
+		{name: "truncated head", b: b[:envelopeHeadSize-1], check: CheckLength[v3.Data], wantErr: ErrTruncatedReport},
+		{name: "trailing bytes", b: append(b[:len(b):len(b)], 0), check: CheckLength[v3.Data], wantErr: ErrTrailingBytes},
+		{name: "shorter version", b: b4, check: CheckLength[v3.Data], wantErr: ErrTruncatedReport},
+		{name: "longer version", b: b, check: CheckLength[v4.Data], wantErr: ErrTrailingBytes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.check(tt.b); !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("CheckLength() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidReport is returned by DecodeStrict for decoded reports failing validation.
+var ErrInvalidReport = errors.New("report: invalid report")
+
+// DecodeStrict decodes the report serialized bytes and its data as Decode does and validates the result,
+// instead of returning whatever the ABI unpacker produced. Reports are rejected with ErrInvalidReport for:
+//   - a full report or report data cut short, also matching ErrTruncatedReport, checked before the report
+//     is unpacked, see CheckLength,
+//   - bytes trailing the ABI encoded report or report data, also matching ErrTrailingBytes,
+//   - a zero feed ID,
+//   - a feed ID of another feed version than the data type T,
+//   - a zero observations timestamp, a validity start after the observations timestamp
+//     or an expiry before it.
+func DecodeStrict[T Data](fullReport []byte) (r *Report[T], err error) {
+	if err = CheckLength[T](fullReport); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidReport, err)
+	}
+	r, err = Decode[T](fullReport)
+	if err != nil {
+		return nil, err
+	}
+	if err = validate(r); err != nil {
+		recordFailure[T](FailureInvalid, fullReport, r.ReportBlob)
+		return nil, fmt.Errorf("%w: %w", ErrInvalidReport, err)
+	}
+	return r, nil
+}
+
+// validate validates the report decoded from the full report.
+func validate[T Data](r *Report[T]) error {
+	id := r.FeedID()
+	if id == ([32]byte{}) {
+		return errors.New("zero feed ID")
+	}
+	if v := id.Version(); v != VersionOf[T]() {
+		return fmt.Errorf("feed %s of version %d decoded as version %d", id.String(), v, VersionOf[T]())
+	}
+
+	d := any(r.Data).(PriceReport)
+	validFrom, observations, _ := d.Timestamps()
+	switch {
+	case observations == 0:
+		return errors.New("zero observations timestamp")
+	case validFrom > observations:
+		return fmt.Errorf("valid from timestamp %d after observations timestamp %d", validFrom, observations)
+	case any(r.Data).(Freshness).IsExpired(time.Unix(int64(observations), 0)):
+		return fmt.Errorf("expired at observations timestamp %d", observations)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	valid := mustEncode(t, v4Report)
+
+	withData := func(f func(d *v4.Data)) []byte {
+		r := *v4Report
+		f(&r.Data)
+		b, err := Encode(&r)
+		if err != nil {
+			t.Fatalf("failed to encode report: %s", err)
+		}
+		return b
+	}
+	trailingData := *v4Report
+	trailingData.ReportBlob = append(mustPackData(v4Data), make([]byte, 32)...)
+
+	tests := []struct {
+		name    string
+		b       []byte
+		wantErr string
+		wantIs  error
+	}{
+		{name: "valid", b: valid},
+		{name: "trailing bytes", b: append(valid[:len(valid):len(valid)], 0), wantErr: "bytes, want", wantIs: ErrTrailingBytes},
+		{name: "trailing data bytes", b: mustEncode(t, &trailingData), wantErr: "bytes of report data", wantIs: ErrTrailingBytes},
+		{name: "zero feed ID", b: withData(func(d *v4.Data) { d.FeedID = [32]byte{} }), wantErr: "zero feed ID"},
+		{name: "version mismatch", b: withData(func(d *v4.Data) { d.FeedID[1] = 3 }), wantErr: "decoded as version 4"},
+		{name: "zero observations", b: withData(func(d *v4.Data) { d.ObservationsTimestamp = 0 }),
+			wantErr: "zero observations timestamp"},
+		{name: "valid after observations", b: withData(func(d *v4.Data) { d.ValidFromTimestamp = d.ObservationsTimestamp + 1 }),
+			wantErr: "after observations timestamp"},
+		{name: "expired", b: withData(func(d *v4.Data) { d.ExpiresAt = d.ObservationsTimestamp - 1 }),
+			wantErr: "expired at observations timestamp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := DecodeStrict[v4.Data](tt.b)
+			if tt.wantErr == "" {
+				if err != nil || r == nil {
+					t.Fatalf("DecodeStrict() = %v, %v, want report", r, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidReport) || !strings.Contains(err.Error(), tt.wantErr) ||
+				(tt.wantIs != nil && !errors.Is(err, tt.wantIs)) {
+				t.Errorf("DecodeStrict() error = %v, want %s", err, tt.wantErr)
+			}
+			if _, err = Decode[v4.Data](tt.b); err != nil {
+				t.Errorf("Decode() error = %s, want lenient decoding", err)
+			}
+		})
+	}
+
+	if _, err := DecodeStrict[v4.Data](valid[:len(valid)-1]); !errors.Is(err, ErrInvalidReport) || !errors.Is(err, ErrTruncatedReport) {
+		t.Errorf("DecodeStrict() of truncated report error = %v, want %v", err, ErrTruncatedReport)
+	}
+}
@@ -1,64 +1,25 @@
 package v1
 
-import (
-	"fmt"
-	"math/big"
-
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/smartcontractkit/data-streams-sdk/go/feed"
-)
-
-var schema = Schema()
-
-// Schema returns this data version schema
-func Schema() abi.Arguments {
-	mustNewType := func(t string) abi.Type {
-		result, err := abi.NewType(t, "", []abi.ArgumentMarshaling{})
-		if err != nil {
-			panic(fmt.Sprintf("Unexpected error during abi.NewType: %s", err))
-		}
-		return result
-	}
-	return abi.Arguments([]abi.Argument{
-		{Name: "feedId", Type: mustNewType("bytes32")},
-		{Name: "observationsTimestamp", Type: mustNewType("uint32")},
-		{Name: "benchmarkPrice", Type: mustNewType("int192")},
-		{Name: "bid", Type: mustNewType("int192")},
-		{Name: "ask", Type: mustNewType("int192")},
-		{Name: "currentBlockNum", Type: mustNewType("uint64")},
-		{Name: "currentBlockHash", Type: mustNewType("bytes32")},
-		{Name: "validFromBlockNum", Type: mustNewType("uint64")},
-		{Name: "currentBlockTimestamp", Type: mustNewType("uint64")},
-	})
+// BlockRange returns the range of blocks the report is valid for, from ValidFromBlockNum to CurrentBlockNum inclusive.
+func (d *Data) BlockRange() (from, to uint64) {
+	return d.ValidFromBlockNum, d.CurrentBlockNum
 }
 
-// Data is the container for this schema attributes
-type Data struct {
-	FeedID                feed.ID `abi:"feedId"`
-	ObservationsTimestamp uint32
-	BenchmarkPrice        *big.Int
-	Bid                   *big.Int
-	Ask                   *big.Int
-	CurrentBlockNum       uint64
-	CurrentBlockHash      [32]byte
-	ValidFromBlockNum     uint64
-	CurrentBlockTimestamp uint64
+// ValidAtBlock reports whether the report is valid at the given block number.
+func (d *Data) ValidAtBlock(blockNum uint64) bool {
+	return d.ValidFromBlockNum <= blockNum && blockNum <= d.CurrentBlockNum
 }
 
-// Schema returns this data version schema
-func (Data) Schema() abi.Arguments {
-	return Schema()
+// BlocksCovered returns the number of blocks the report is valid for, 0 if the range is empty.
+func (d *Data) BlocksCovered() uint64 {
+	if d.ValidFromBlockNum > d.CurrentBlockNum {
+		return 0
+	}
+	return d.CurrentBlockNum - d.ValidFromBlockNum + 1
 }
 
-// Decode decodes the serialized data bytes
-func Decode(report []byte) (*Data, error) {
-	values, err := schema.Unpack(report)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode report: %w", err)
-	}
-	decoded := new(Data)
-	if err = schema.Copy(decoded, values); err != nil {
-		return nil, fmt.Errorf("failed to copy report values to struct: %w", err)
-	}
-	return decoded, nil
+// Follows reports whether the report validity range starts right after the prev report one,
+// meaning there is no gap nor overlap between consecutive reports.
+func (d *Data) Follows(prev *Data) bool {
+	return d.ValidFromBlockNum == prev.CurrentBlockNum+1
 }
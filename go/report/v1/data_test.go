@@ -45,3 +45,40 @@ func TestData(t *testing.T) {
 		t.Errorf("expected: %#v, got %#v", r, d)
 	}
 }
+
+func TestData_BlockRange(t *testing.T) {
+	d := &Data{ValidFromBlockNum: 100, CurrentBlockNum: 104}
+
+	if from, to := d.BlockRange(); from != 100 || to != 104 {
+		t.Errorf("BlockRange() = %d, %d, want 100, 104", from, to)
+	}
+	if got := d.BlocksCovered(); got != 5 {
+		t.Errorf("BlocksCovered() = %d, want 5", got)
+	}
+	if got := (&Data{ValidFromBlockNum: 5, CurrentBlockNum: 4}).BlocksCovered(); got != 0 {
+		t.Errorf("BlocksCovered() = %d, want 0", got)
+	}
+
+	tests := []struct {
+		block uint64
+		want  bool
+	}{
+		{block: 99, want: false},
+		{block: 100, want: true},
+		{block: 102, want: true},
+		{block: 104, want: true},
+		{block: 105, want: false},
+	}
+	for _, tt := range tests {
+		if got := d.ValidAtBlock(tt.block); got != tt.want {
+			t.Errorf("ValidAtBlock(%d) = %v, want %v", tt.block, got, tt.want)
+		}
+	}
+
+	if !(&Data{ValidFromBlockNum: 105, CurrentBlockNum: 110}).Follows(d) {
+		t.Errorf("Follows() = false, want true")
+	}
+	if (&Data{ValidFromBlockNum: 104, CurrentBlockNum: 110}).Follows(d) {
+		t.Errorf("Follows() = true, want false")
+	}
+}
@@ -1,68 +1,10 @@
 package v4
 
-import (
-	"fmt"
-	"math/big"
-
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/smartcontractkit/data-streams-sdk/go/feed"
-)
-
-var schema = Schema()
-
-// Schema returns this data version schema
-func Schema() abi.Arguments {
-	mustNewType := func(t string) abi.Type {
-		result, err := abi.NewType(t, "", []abi.ArgumentMarshaling{})
-		if err != nil {
-			panic(fmt.Sprintf("Unexpected error during abi.NewType: %s", err))
-		}
-		return result
-	}
-	return abi.Arguments([]abi.Argument{
-		{Name: "feedId", Type: mustNewType("bytes32")},
-		{Name: "validFromTimestamp", Type: mustNewType("uint32")},
-		{Name: "observationsTimestamp", Type: mustNewType("uint32")},
-		{Name: "nativeFee", Type: mustNewType("uint192")},
-		{Name: "linkFee", Type: mustNewType("uint192")},
-		{Name: "expiresAt", Type: mustNewType("uint32")},
-		{Name: "benchmarkPrice", Type: mustNewType("int192")},
-		{Name: "marketStatus", Type: mustNewType("uint32")},
-	})
-}
+import "github.com/smartcontractkit/data-streams-sdk/go/report/common"
 
+// Market statuses of the Data MarketStatus, see common.MarketStatus.
 const (
-	MarketStatusUnknown uint32 = iota
-	MarketStatusClosed
-	MarketStatusOpen
+	MarketStatusUnknown = common.MarketStatusUnknown
+	MarketStatusClosed  = common.MarketStatusClosed
+	MarketStatusOpen    = common.MarketStatusOpen
 )
-
-// Data is the container for this schema attributes
-type Data struct {
-	FeedID                feed.ID `abi:"feedId"`
-	ObservationsTimestamp uint32
-	BenchmarkPrice        *big.Int
-	MarketStatus          uint32
-	ValidFromTimestamp    uint32
-	ExpiresAt             uint32
-	LinkFee               *big.Int
-	NativeFee             *big.Int
-}
-
-// Schema returns this data version schema
-func (Data) Schema() abi.Arguments {
-	return Schema()
-}
-
-// Decode decodes the serialized data bytes
-func Decode(data []byte) (*Data, error) {
-	values, err := schema.Unpack(data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode report: %w", err)
-	}
-	decoded := new(Data)
-	if err = schema.Copy(decoded, values); err != nil {
-		return nil, fmt.Errorf("failed to copy report values to struct: %w", err)
-	}
-	return decoded, nil
-}
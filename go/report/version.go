@@ -0,0 +1,49 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// LatestSupportedVersion returns the latest feed version decodable by this package.
+func LatestSupportedVersion() feed.FeedVersion {
+	return LatestVersion
+}
+
+// SupportedVersions returns the feed versions decodable by this package, oldest first,
+// including the versions registered with RegisterSchema.
+func SupportedVersions() (versions []feed.FeedVersion) {
+	for v := feed.FeedVersion1; v <= LatestVersion; v++ {
+		versions = append(versions, v)
+	}
+	return append(versions, registeredVersions()...)
+}
+
+// IsSupported reports whether reports of the feed version are decodable by this package,
+// built in or registered with RegisterSchema.
+func IsSupported(v feed.FeedVersion) bool {
+	if v >= feed.FeedVersion1 && v <= LatestVersion {
+		return true
+	}
+	_, ok := registered(v)
+	return ok
+}
+
+// CheckSupported returns an error listing the feeds with versions not decodable by this package.
+// Applications can call it at startup to fail fast on feeds requiring a newer SDK.
+func CheckSupported(ids ...feed.ID) (err error) {
+	var unsupported []string
+	for _, id := range ids {
+		if v := id.Version(); !IsSupported(v) {
+			unsupported = append(unsupported, fmt.Sprintf("%s (v%d)", id.String(), v))
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("report: unsupported feed versions, latest supported v%d: %s",
+			LatestVersion, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	v1 "github.com/smartcontractkit/data-streams-sdk/go/report/v1"
+	v4 "github.com/smartcontractkit/data-streams-sdk/go/report/v4"
+)
+
+// compile-time assertion of the supported versions
+const _ = LatestVersion - feed.FeedVersion4
+
+func TestCheckSupported(t *testing.T) {
+	supported := feed.ID{0, 3}
+	unsupported := feed.ID{0, 5}
+
+	if err := CheckSupported(supported); err != nil {
+		t.Errorf("CheckSupported() error = %v", err)
+	}
+
+	if err := CheckSupported(supported, unsupported, feed.ID{}); err == nil {
+		t.Errorf("expected error checking unsupported feed versions")
+	}
+
+	if v := LatestSupportedVersion(); !IsSupported(v) || IsSupported(v+1) {
+		t.Errorf("LatestSupportedVersion() = %d is not the latest supported version", v)
+	}
+}
+
+func TestSupportedVersions(t *testing.T) {
+	versions := SupportedVersions()
+	if len(versions) == 0 || versions[0] != feed.FeedVersion1 || versions[len(versions)-1] != LatestVersion {
+		t.Fatalf("SupportedVersions() = %v, want v1 to v%d", versions, LatestVersion)
+	}
+	for x, v := range versions {
+		if v != feed.FeedVersion(x+1) || !IsSupported(v) {
+			t.Errorf("SupportedVersions()[%d] = %d, want supported version %d", x, v, x+1)
+		}
+	}
+}
+
+func TestVersionOf(t *testing.T) {
+	if v := VersionOf[v1.Data](); v != feed.FeedVersion1 {
+		t.Errorf("VersionOf[v1.Data]() = %d, want %d", v, feed.FeedVersion1)
+	}
+	if v := VersionOf[v4.Data](); v != LatestVersion {
+		t.Errorf("VersionOf[v4.Data]() = %d, want %d", v, LatestVersion)
+	}
+}
@@ -0,0 +1,37 @@
+package streams
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// RequestError wraps errors of requests to the Data Streams service
+// with the request ID sent to the server to correlate client and server logs.
+type RequestError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s, request id: %s", e.Err, e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// requestID returns the request ID set in the context with RequestIDCtxKey
+// or generates a new one.
+func requestID(ctx context.Context) (id string) {
+	if value := ctx.Value(RequestIDCtxKey); value != nil {
+		if id, ok := value.(string); ok && id != "" {
+			return id
+		}
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,133 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+// routedQueueSize is the number of reports queued for each handler of a RoutedStream.
+const routedQueueSize = 64
+
+// RoutedStream is a Stream delivering the reports of each feed to its own handler, see ExtendedClient.StreamRouted.
+type RoutedStream interface {
+	ExtendedStream
+
+	// RouteStats returns the stats of the handler of each feed.
+	RouteStats() map[feed.ID]RouteStats
+}
+
+// RouteStats are the stats of the handler of a feed of a RoutedStream.
+type RouteStats struct {
+	Handled uint64 // Reports handled, including the ones the handler panicked on
+	Panics  uint64 // Handler panics, recovered and logged
+	Dropped uint64 // Reports dropped because the handler queue was full
+	Queued  uint64 // Reports currently waiting for the handler
+}
+
+func (s RouteStats) String() string {
+	return fmt.Sprintf("handled: %d, panics: %d, dropped: %d, queued: %d", s.Handled, s.Panics, s.Dropped, s.Queued)
+}
+
+// route is the handler of a feed of a routedStream and its queue.
+type route struct {
+	handler func(*ReportResponse)
+	reports chan *ReportResponse
+
+	handled atomic.Uint64
+	panics  atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// routedStream is a Stream delivering its reports to the handlers of their feed.
+type routedStream struct {
+	handlerStream
+	routes map[feed.ID]*route
+}
+
+func (r *routedStream) RouteStats() map[feed.ID]RouteStats {
+	stats := make(map[feed.ID]RouteStats, len(r.routes))
+	for id, rt := range r.routes {
+		stats[id] = RouteStats{
+			Handled: rt.handled.Load(),
+			Panics:  rt.panics.Load(),
+			Dropped: rt.dropped.Load(),
+			Queued:  uint64(len(rt.reports)),
+		}
+	}
+	return stats
+}
+
+func (c *client) StreamRouted(ctx context.Context, routes map[feed.ID]func(*ReportResponse)) (s RoutedStream, err error) {
+	ids := make([]feed.ID, 0, len(routes))
+	for id, handler := range routes {
+		if handler == nil {
+			return nil, fmt.Errorf("client: nil handler for feed %s", id.String())
+		}
+		ids = append(ids, id)
+	}
+
+	stream, err := c.StreamWithConnStatusCallback(ctx, ids, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &routedStream{routes: make(map[feed.ID]*route, len(routes))}
+	rs.ExtendedStream = stream
+	for id, handler := range routes {
+		rt := &route{handler: handler, reports: make(chan *ReportResponse, routedQueueSize)}
+		rs.routes[id] = rt
+		rs.workers.Add(1)
+		go func() {
+			defer rs.workers.Done()
+			rt.handle(c.config)
+		}()
+	}
+
+	go func() {
+		defer func() {
+			for _, rt := range rs.routes {
+				close(rt.reports)
+			}
+		}()
+		for {
+			// read from the wrapped stream, Read is disabled on the routed stream
+			r, err := stream.Read(context.Background())
+			if err != nil {
+				return
+			}
+			rt, ok := rs.routes[r.FeedID]
+			if !ok {
+				c.config.logInfo("client: routed stream received report for unrouted feed %s", r.FeedID.String())
+				continue
+			}
+			// a slow handler drops its own reports instead of delaying the other feeds
+			select {
+			case rt.reports <- r:
+			default:
+				rt.dropped.Add(1)
+				c.config.logInfo("client: routed stream handler queue full, dropped report %s", r.FeedID.String())
+			}
+		}
+	}()
+
+	return rs, nil
+}
+
+// handle calls the route handler with the reports of its feed in order.
+func (rt *route) handle(cfg Config) {
+	for r := range rt.reports {
+		func() {
+			defer rt.handled.Add(1)
+			defer func() {
+				if err := recover(); err != nil {
+					rt.panics.Add(1)
+					cfg.logInfo("client: stream handler panic on report %s: %v", r.FeedID.String(), err)
+				}
+			}()
+			rt.handler(r)
+		}()
+	}
+}
@@ -0,0 +1,113 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestClient_StreamRouted(t *testing.T) {
+	const n = 20
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for ts := uint64(1); ts <= n; ts++ {
+			for _, id := range []feed.ID{feed1, feed2} {
+				b, err := json.Marshal(&message{&ReportResponse{FeedID: id, ObservationsTimestamp: ts}})
+				if err != nil {
+					t.Errorf("failed to serialize message: %s", err)
+				}
+				if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+					return
+				}
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2 * n)
+	var handled []uint64
+	sub, err := streamsClient.StreamRouted(context.Background(), map[feed.ID]func(*ReportResponse){
+		feed1: func(r *ReportResponse) {
+			defer wg.Done()
+			if r.FeedID != feed1 {
+				t.Errorf("feed1 handler called with report %s", r.FeedID.String())
+			}
+			handled = append(handled, r.ObservationsTimestamp)
+		},
+		feed2: func(r *ReportResponse) {
+			defer wg.Done()
+			panic("handler panic")
+		},
+	})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	if _, err = sub.Read(context.Background()); !errors.Is(err, ErrHandlerStream) {
+		t.Errorf("Read() error = %v, want %v", err, ErrHandlerStream)
+	}
+	if err = sub.ReadInto(context.Background(), &ReportResponse{}); !errors.Is(err, ErrHandlerStream) {
+		t.Errorf("ReadInto() error = %v, want %v", err, ErrHandlerStream)
+	}
+
+	wg.Wait()
+
+	var want []uint64
+	for ts := uint64(1); ts <= n; ts++ {
+		want = append(want, ts)
+	}
+	if !reflect.DeepEqual(handled, want) {
+		t.Errorf("feed1 handled %v, want %v", handled, want)
+	}
+
+	// the handled counter is updated once the handler returns
+	deadline := time.Now().Add(time.Second)
+	wantStats := map[feed.ID]RouteStats{feed1: {Handled: n}, feed2: {Handled: n, Panics: n}}
+	for !reflect.DeepEqual(sub.RouteStats(), wantStats) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := sub.RouteStats(); !reflect.DeepEqual(got, wantStats) {
+		t.Errorf("RouteStats() = %v, want %v", got, wantStats)
+	}
+}
+
+func TestClient_StreamRoutedNilHandler(t *testing.T) {
+	ms := newMockServer(func(http.ResponseWriter, *http.Request) {})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	if _, err = streamsClient.StreamRouted(context.Background(), map[feed.ID]func(*ReportResponse){feed1: nil}); err == nil {
+		t.Errorf("StreamRouted() error = nil, want nil handler error")
+	}
+}
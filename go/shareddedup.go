@@ -0,0 +1,37 @@
+package streams
+
+import (
+	"context"
+	"time"
+)
+
+const defaultSharedDedupTimeout = time.Second
+
+// SharedDedupStore deduplicates Stream reports across consumers subscribed to the same feeds
+// so only one consumer delivers a given feed report. The redisdedup package implements it with Redis.
+type SharedDedupStore interface {
+	// Claim atomically claims the delivery of the feed report observed at the given timestamp.
+	// Claim returns true only for the first consumer claiming the report.
+	Claim(ctx context.Context, feedID string, observationsTimestamp uint64) (claimed bool, err error)
+}
+
+// claim claims the report delivery in the shared dedup store.
+// If the store is unavailable the report is delivered.
+func (s *stream) claim(r *ReportResponse) (claimed bool) {
+	ctx, cancel := context.WithTimeout(s.streamCtx, defaultSharedDedupTimeout)
+	defer cancel()
+
+	claimed, err := s.config.SharedDedup.Claim(ctx, r.FeedID.String(), r.ObservationsTimestamp)
+	if err != nil {
+		s.config.logInfo(
+			"client: stream shared dedup error for report %s %d, delivering: %s",
+			r.FeedID.String(), r.ObservationsTimestamp, err,
+		)
+		return true
+	}
+
+	if !claimed {
+		s.stats.sharedDeduplicated.Add(1)
+	}
+	return claimed
+}
@@ -0,0 +1,125 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+// memoryDedupStore is a SharedDedupStore shared by the consumers of a test.
+type memoryDedupStore struct {
+	mu      sync.Mutex
+	claimed map[string]bool
+}
+
+func (m *memoryDedupStore) Claim(_ context.Context, feedID string, observationsTimestamp uint64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := feedID + ":" + strconv.FormatUint(observationsTimestamp, 10)
+	if m.claimed[key] {
+		return false, nil
+	}
+	m.claimed[key] = true
+	return true, nil
+}
+
+func TestClient_StreamSharedDedup(t *testing.T) {
+	const numReports = 50
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(
+			w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover},
+		)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for x := 1; x <= numReports; x++ {
+			b, err := json.Marshal(&message{&ReportResponse{FeedID: feed1, ObservationsTimestamp: uint64(x)}})
+			if err != nil {
+				t.Errorf("failed to serialize message: %s", err)
+			}
+
+			if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+				return
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	store := &memoryDedupStore{claimed: map[string]bool{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	delivered := map[uint64]int{}
+	var subs []ExtendedStream
+	for x := 0; x < 2; x++ {
+		streamsClient, err := ms.Client()
+		if err != nil {
+			t.Fatalf("error creating client %s", err)
+		}
+		streamsClient.(*client).config.SharedDedup = store
+
+		sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1}))
+		if err != nil {
+			t.Fatalf("error subscribing %s", err)
+		}
+		defer sub.Close()
+		subs = append(subs, sub)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				r, err := sub.Read(context.Background())
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				delivered[r.ObservationsTimestamp]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for subs[0].Stats().Accepted+subs[1].Stats().Accepted != 2*numReports {
+		time.Sleep(time.Millisecond)
+	}
+	for subs[0].StatsV2().Counter(StatSharedDeduplicated)+subs[1].StatsV2().Counter(StatSharedDeduplicated) != numReports {
+		time.Sleep(time.Millisecond)
+	}
+	for {
+		mu.Lock()
+		n := len(delivered)
+		mu.Unlock()
+		if n == numReports {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for _, sub := range subs {
+		_ = sub.Close()
+	}
+	wg.Wait()
+
+	for ts, n := range delivered {
+		if n != 1 {
+			t.Errorf("report %d delivered %d times, want 1", ts, n)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package streams
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+const (
+	defaultSLOWindow         = time.Hour
+	defaultSLOSampleInterval = time.Second
+)
+
+// SLO objective names
+const (
+	SLOObjectiveStaleness = "staleness"
+	SLOObjectiveUptime    = "uptime"
+)
+
+// SLOConfig specifies the service level objectives tracked by a Stream.
+// A zero MaxStaleness or UptimeTarget disables the respective objective.
+type SLOConfig struct {
+	MaxStaleness   time.Duration // Maximum time without an accepted report for any subscribed feed
+	UptimeTarget   float64       // Minimum ratio (0-1] of time with at least one active connection
+	Window         time.Duration // Rolling compliance window, defaults to 1h
+	SampleInterval time.Duration // Interval between compliance samples, defaults to 1s
+
+	// OnBreach is called when an objective transitions from compliant to breached.
+	OnBreach func(SLOBreach)
+}
+
+// SLOBreach describes an objective breach event.
+type SLOBreach struct {
+	Objective string        // SLOObjectiveStaleness or SLOObjectiveUptime
+	FeedID    feed.ID       // Stalest feed, only set for staleness breaches
+	Staleness time.Duration // Observed staleness, only set for staleness breaches
+	Uptime    float64       // Observed uptime ratio, only set for uptime breaches
+	At        time.Time     // Time the breach was detected
+	Labels    Labels        // Stream labels
+}
+
+// SLOStatus is a point in time view of the Stream objectives compliance
+// over the configured rolling window.
+type SLOStatus struct {
+	Window              time.Duration // Rolling window covered by the samples
+	Samples             int           // Number of samples in the window
+	Uptime              float64       // Ratio of samples with at least one active connection
+	StalenessCompliance float64       // Ratio of samples where every feed was within MaxStaleness
+	MaxStaleness        time.Duration // Current staleness of the stalest feed
+	StalestFeed         feed.ID       // Current stalest feed
+	UptimeBreached      bool          // Uptime is below UptimeTarget
+	StalenessBreached   bool          // MaxStaleness is above the configured MaxStaleness
+}
+
+type sloSample struct {
+	connected bool
+	fresh     bool
+}
+
+type sloTracker struct {
+	cfg    SLOConfig
+	now    func() time.Time
+	labels Labels
+
+	mu                sync.Mutex
+	lastAccepted      map[feed.ID]time.Time
+	samples           []sloSample
+	next              int
+	full              bool
+	uptimeBreached    bool
+	stalenessBreached bool
+}
+
+func newSLOTracker(cfg SLOConfig, feedIDs []feed.ID, now func() time.Time) (t *sloTracker) {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultSLOWindow
+	}
+	if cfg.SampleInterval <= 0 {
+		cfg.SampleInterval = defaultSLOSampleInterval
+	}
+
+	size := int(cfg.Window / cfg.SampleInterval)
+	if size < 1 {
+		size = 1
+	}
+
+	t = &sloTracker{
+		cfg:          cfg,
+		now:          now,
+		lastAccepted: make(map[feed.ID]time.Time, len(feedIDs)),
+		samples:      make([]sloSample, size),
+	}
+
+	// feeds that never receive a report are stale since the tracker start
+	start := now()
+	for _, id := range feedIDs {
+		t.lastAccepted[id] = start
+	}
+	return t
+}
+
+func (t *sloTracker) accepted(id feed.ID) {
+	t.mu.Lock()
+	t.lastAccepted[id] = t.now()
+	t.mu.Unlock()
+}
+
+// remove stops tracking the staleness of the feed.
+func (t *sloTracker) remove(id feed.ID) {
+	t.mu.Lock()
+	delete(t.lastAccepted, id)
+	t.mu.Unlock()
+}
+
+// stalest returns the feed with the oldest accepted report and its staleness.
+// Must be called with the mutex held.
+func (t *sloTracker) stalest(now time.Time) (id feed.ID, staleness time.Duration) {
+	for k, v := range t.lastAccepted {
+		if d := now.Sub(v); d > staleness {
+			id, staleness = k, d
+		}
+	}
+	return id, staleness
+}
+
+func (t *sloTracker) sample(connected bool) {
+	now := t.now()
+
+	t.mu.Lock()
+	id, staleness := t.stalest(now)
+	fresh := t.cfg.MaxStaleness <= 0 || staleness <= t.cfg.MaxStaleness
+
+	t.samples[t.next] = sloSample{connected: connected, fresh: fresh}
+	t.next = (t.next + 1) % len(t.samples)
+	if t.next == 0 {
+		t.full = true
+	}
+
+	uptime, _ := t.ratios()
+	uptimeBreached := t.cfg.UptimeTarget > 0 && uptime < t.cfg.UptimeTarget
+
+	var breaches []SLOBreach
+	if !fresh && !t.stalenessBreached {
+		breaches = append(breaches, SLOBreach{
+			Objective: SLOObjectiveStaleness, FeedID: id, Staleness: staleness, At: now, Labels: t.labels,
+		})
+	}
+	if uptimeBreached && !t.uptimeBreached {
+		breaches = append(breaches, SLOBreach{
+			Objective: SLOObjectiveUptime, Uptime: uptime, At: now, Labels: t.labels,
+		})
+	}
+	t.stalenessBreached = !fresh
+	t.uptimeBreached = uptimeBreached
+	t.mu.Unlock()
+
+	if t.cfg.OnBreach != nil {
+		for _, b := range breaches {
+			t.cfg.OnBreach(b)
+		}
+	}
+}
+
+// ratios computes the uptime and staleness compliance ratios.
+// Must be called with the mutex held.
+func (t *sloTracker) ratios() (uptime float64, staleness float64) {
+	n := t.next
+	if t.full {
+		n = len(t.samples)
+	}
+	if n == 0 {
+		return 1, 1
+	}
+
+	var connected, fresh int
+	for x := 0; x < n; x++ {
+		if t.samples[x].connected {
+			connected++
+		}
+		if t.samples[x].fresh {
+			fresh++
+		}
+	}
+	return float64(connected) / float64(n), float64(fresh) / float64(n)
+}
+
+func (t *sloTracker) status() (st SLOStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	st.Window = t.cfg.Window
+	st.Samples = t.next
+	if t.full {
+		st.Samples = len(t.samples)
+	}
+	st.Uptime, st.StalenessCompliance = t.ratios()
+	st.StalestFeed, st.MaxStaleness = t.stalest(t.now())
+	st.UptimeBreached = t.uptimeBreached
+	st.StalenessBreached = t.cfg.MaxStaleness > 0 && st.MaxStaleness > t.cfg.MaxStaleness
+	return st
+}
+
+func (s *stream) monitorSLO() {
+	ticker := time.NewTicker(s.slo.cfg.SampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.streamCtx.Done():
+			return
+		case <-ticker.C:
+			s.slo.sample(s.stats.activeConnections.Load() > 0)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package streams
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+func TestSLOTracker(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+
+	var breaches []SLOBreach
+	tracker := newSLOTracker(SLOConfig{
+		MaxStaleness:   3 * time.Second,
+		UptimeTarget:   0.75,
+		Window:         4 * time.Second,
+		SampleInterval: time.Second,
+		OnBreach:       func(b SLOBreach) { breaches = append(breaches, b) },
+	}, []feed.ID{feed1, feed2}, clock)
+
+	// both feeds fresh and connected
+	for x := 0; x < 2; x++ {
+		now = now.Add(time.Second)
+		tracker.accepted(feed1)
+		tracker.accepted(feed2)
+		tracker.sample(true)
+	}
+
+	st := tracker.status()
+	if st.Uptime != 1 || st.StalenessCompliance != 1 || st.UptimeBreached || st.StalenessBreached {
+		t.Fatalf("expected compliant status, got %#v", st)
+	}
+
+	// feed2 goes stale and connections drop
+	for x := 0; x < 4; x++ {
+		now = now.Add(time.Second)
+		tracker.accepted(feed1)
+		tracker.sample(false)
+	}
+
+	st = tracker.status()
+	if st.Samples != 4 {
+		t.Errorf("expected 4 samples, got %d", st.Samples)
+	}
+	if st.Uptime != 0 {
+		t.Errorf("expected uptime 0, got %f", st.Uptime)
+	}
+	if !st.UptimeBreached || !st.StalenessBreached {
+		t.Errorf("expected breached status, got %#v", st)
+	}
+	if st.StalestFeed != feed2 || st.MaxStaleness != 4*time.Second {
+		t.Errorf("expected stalest feed %s for 4s, got %s for %s",
+			feed2.String(), st.StalestFeed.String(), st.MaxStaleness)
+	}
+
+	// breaches fire once per transition
+	if len(breaches) != 2 {
+		t.Fatalf("expected 2 breaches, got %d: %#v", len(breaches), breaches)
+	}
+	if breaches[0].Objective != SLOObjectiveUptime {
+		t.Errorf("expected %s breach, got %s", SLOObjectiveUptime, breaches[0].Objective)
+	}
+	if breaches[1].Objective != SLOObjectiveStaleness || breaches[1].FeedID != feed2 {
+		t.Errorf("expected %s breach for %s, got %#v", SLOObjectiveStaleness, feed2.String(), breaches[1])
+	}
+}
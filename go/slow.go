@@ -0,0 +1,33 @@
+package streams
+
+import (
+	"time"
+)
+
+// SlowRequest describes a rest request slower than Config.RestSlowThreshold.
+type SlowRequest struct {
+	RequestID string        // Request ID
+	Method    string        // Request method
+	Path      string        // Request path
+	Duration  time.Duration // Request duration, including reading the response body unless streamed
+}
+
+// checkSlow reports the request if it took longer than Config.RestSlowThreshold since start.
+func (c *client) checkSlow(id string, d *request, start time.Time) {
+	threshold := c.config.RestSlowThreshold
+	if threshold <= 0 {
+		return
+	}
+
+	elapsed := time.Since(start)
+	if elapsed < threshold {
+		return
+	}
+
+	c.transfer.slowRequests.Add(1)
+	c.config.logInfo("client: slow rest request id: %s, %s %s took %s",
+		id, d.method, d.path, elapsed.Truncate(time.Millisecond))
+	if c.config.OnSlowRequest != nil {
+		c.config.OnSlowRequest(SlowRequest{RequestID: id, Method: d.method, Path: d.path, Duration: elapsed})
+	}
+}
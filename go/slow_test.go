@@ -0,0 +1,43 @@
+package streams
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_RestSlowThreshold(t *testing.T) {
+	delay := 50 * time.Millisecond
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("feedID") == feed1str {
+			time.Sleep(delay)
+		}
+		_, _ = w.Write([]byte(`{"report":{}}`))
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	slow := make(chan SlowRequest, 2)
+	streamsClient.(*client).config.RestSlowThreshold = delay
+	streamsClient.(*client).config.OnSlowRequest = func(r SlowRequest) { slow <- r }
+
+	ctx := context.WithValue(context.Background(), RequestIDCtxKey, "slow-request")
+	if _, err = streamsClient.GetLatestReport(ctx, feed1); err != nil {
+		t.Fatalf("GetLatestReport() error = %s", err)
+	}
+	if _, err = streamsClient.GetLatestReport(context.Background(), feed2); err != nil {
+		t.Fatalf("GetLatestReport() error = %s", err)
+	}
+
+	if got := streamsClient.TransferStats().SlowRequests; got != 1 {
+		t.Errorf("TransferStats().SlowRequests = %d, want 1", got)
+	}
+	r := <-slow
+	if r.RequestID != "slow-request" || r.Method != http.MethodGet || r.Path != apiV1ReportsLatest || r.Duration < delay {
+		t.Errorf("OnSlowRequest() = %+v", r)
+	}
+}
@@ -0,0 +1,115 @@
+package streams
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// StatCounter names a Stream counter of StatsV2.
+type StatCounter string
+
+// Stream counters
+const (
+	StatAccepted               StatCounter = "accepted"                // Accepted reports
+	StatDeduplicated           StatCounter = "deduplicated"            // Reports deduplicated when in HA
+	StatTotalReceived          StatCounter = "total_received"          // Received reports
+	StatPartialReconnects      StatCounter = "partial_reconnects"      // Partial reconnects when in HA
+	StatFullReconnects         StatCounter = "full_reconnects"         // Full reconnects
+	StatClockAnomalies         StatCounter = "clock_anomalies"         // Reports with implausible observation timestamps
+	StatClockRejected          StatCounter = "clock_rejected"          // Reports rejected due to implausible observation timestamps
+	StatSharedDeduplicated     StatCounter = "shared_deduplicated"     // Accepted reports claimed by another consumer of the shared dedup store
+	StatFiltered               StatCounter = "filtered"                // Accepted reports dropped by Config.OnAccept
+	StatSuppressed             StatCounter = "suppressed"              // Accepted reports dropped while their market was closed
+	StatMonotonicityViolations StatCounter = "monotonicity_violations" // Reports delivered out of order, see MonotonicityConfig
+	StatValidationFailed       StatCounter = "validation_failed"       // Reports failing Config.Verification, delivered Unverified
+	StatStandbyDropped         StatCounter = "standby_dropped"         // Reports dropped from the full standby buffer, see StandbyStream
+)
+
+// StatsV2 are the Stream stats, extensible with new counters without breaking consumers: Counters only holds
+// the non-zero counters, so StatsV2 values compared with reflect.DeepEqual are unaffected by counters added
+// later that do not apply to the consumer. The fixed Stats struct keeps its original fields, the counters
+// added since and the latency are only reported by StatsV2. Stats returns the Stats struct for compatibility.
+// The counters only cover what the Stream does to its reports: the Stream does not fill gaps, drop
+// expired reports or conflate reports under back-pressure, their counters come with these features.
+type StatsV2 struct {
+	Counters              map[StatCounter]uint64 // Non-zero Stream counters
+	ConfiguredConnections uint64                 // Number of configured connections if in HA
+	ActiveConnections     uint64                 // Current number of active connections
+
+	// Latency is the smoothed end-to-end latency of each feed, nil unless Config.Latency is set.
+	Latency *LatencyStats
+}
+
+// Counter returns the value of the counter, 0 if not set.
+func (s StatsV2) Counter(c StatCounter) uint64 {
+	return s.Counters[c]
+}
+
+// Delta returns the counters accumulated since prev, a previous StatsV2 of the same Stream.
+// Connection counts and latencies are current values.
+func (s StatsV2) Delta(prev StatsV2) (d StatsV2) {
+	d = s
+	d.Counters = map[StatCounter]uint64{}
+	for c, v := range s.Counters {
+		if v -= prev.Counters[c]; v != 0 {
+			d.Counters[c] = v
+		}
+	}
+	return d
+}
+
+// Stats returns the StatsV2 as the fixed Stats struct, without the counters and latency not part of it.
+func (s StatsV2) Stats() Stats {
+	return Stats{
+		Accepted:              s.Counter(StatAccepted),
+		Deduplicated:          s.Counter(StatDeduplicated),
+		TotalReceived:         s.Counter(StatTotalReceived),
+		PartialReconnects:     s.Counter(StatPartialReconnects),
+		FullReconnects:        s.Counter(StatFullReconnects),
+		ConfiguredConnections: s.ConfiguredConnections,
+		ActiveConnections:     s.ActiveConnections,
+	}
+}
+
+func (s StatsV2) String() string {
+	var b strings.Builder
+	for _, c := range slices.Sorted(maps.Keys(s.Counters)) {
+		fmt.Fprintf(&b, "%s: %d, ", c, s.Counters[c])
+	}
+	fmt.Fprintf(&b, "configured_connections: %d, active_connections: %d", s.ConfiguredConnections, s.ActiveConnections)
+	return b.String()
+}
+
+func (s *stream) StatsV2() (st StatsV2) {
+	v1 := s.Stats()
+	st = StatsV2{
+		Counters:              map[StatCounter]uint64{},
+		ConfiguredConnections: v1.ConfiguredConnections,
+		ActiveConnections:     v1.ActiveConnections,
+	}
+	if s.latency != nil {
+		st.Latency = s.latency.stats()
+	}
+	for c, v := range map[StatCounter]uint64{
+		StatAccepted:               v1.Accepted,
+		StatDeduplicated:           v1.Deduplicated,
+		StatTotalReceived:          v1.TotalReceived,
+		StatPartialReconnects:      v1.PartialReconnects,
+		StatFullReconnects:         v1.FullReconnects,
+		StatClockAnomalies:         s.stats.clockAnomalies.Load(),
+		StatClockRejected:          s.stats.clockRejected.Load(),
+		StatSharedDeduplicated:     s.stats.sharedDeduplicated.Load(),
+		StatFiltered:               s.stats.filtered.Load(),
+		StatSuppressed:             s.stats.suppressed.Load(),
+		StatMonotonicityViolations: s.stats.monotonicityViolations.Load(),
+		StatValidationFailed:       s.stats.validationFailed.Load(),
+		StatStandbyDropped:         s.stats.standbyDropped.Load(),
+	} {
+		if v != 0 {
+			st.Counters[c] = v
+		}
+	}
+	return st
+}
@@ -0,0 +1,59 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatsV2(t *testing.T) {
+	latency := &LatencyStats{}
+	s := StatsV2{
+		Counters: map[StatCounter]uint64{
+			StatAccepted: 10, StatDeduplicated: 5, StatTotalReceived: 15, StatFiltered: 2, StatValidationFailed: 1,
+		},
+		ConfiguredConnections: 2,
+		ActiveConnections:     1,
+		Latency:               latency,
+	}
+
+	want := Stats{Accepted: 10, Deduplicated: 5, TotalReceived: 15, ConfiguredConnections: 2, ActiveConnections: 1}
+	if got := s.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	prev := StatsV2{Counters: map[StatCounter]uint64{StatAccepted: 4, StatDeduplicated: 5, StatTotalReceived: 9}}
+	wantDelta := StatsV2{
+		Counters:              map[StatCounter]uint64{StatAccepted: 6, StatTotalReceived: 6, StatFiltered: 2, StatValidationFailed: 1},
+		ConfiguredConnections: 2,
+		ActiveConnections:     1,
+		Latency:               latency,
+	}
+	if got := s.Delta(prev); !reflect.DeepEqual(got, wantDelta) {
+		t.Errorf("Delta() = %+v, want %+v", got, wantDelta)
+	}
+
+	wantString := "accepted: 10, deduplicated: 5, filtered: 2, total_received: 15, validation_failed: 1, " +
+		"configured_connections: 2, active_connections: 1"
+	if got := s.String(); got != wantString {
+		t.Errorf("String() = %s, want %s", got, wantString)
+	}
+}
+
+func TestStream_StatsV2(t *testing.T) {
+	s := &stream{}
+	s.stats.accepted.Add(3)
+	s.stats.skipped.Add(2)
+	s.stats.validationFailed.Add(1)
+	s.stats.activeConnections.Add(2)
+
+	want := StatsV2{
+		Counters:          map[StatCounter]uint64{StatAccepted: 3, StatDeduplicated: 2, StatTotalReceived: 5, StatValidationFailed: 1},
+		ActiveConnections: 2,
+	}
+	if got := s.StatsV2(); !reflect.DeepEqual(got, want) {
+		t.Errorf("StatsV2() = %s, want %s", got, want)
+	}
+	if got := s.StatsV2().Stats(); got != s.Stats() {
+		t.Errorf("StatsV2().Stats() = %s, want %s", got, s.Stats())
+	}
+}
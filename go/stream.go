@@ -1,10 +1,11 @@
 package streams
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"math/rand"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -13,7 +14,6 @@ import (
 	"time"
 
 	"github.com/smartcontractkit/data-streams-sdk/go/feed"
-	"nhooyr.io/websocket"
 )
 
 const (
@@ -21,18 +21,37 @@ const (
 	minWSReconnectIntervalMillis = 1000
 	maxWSReconnectIntervalMIllis = 10000
 	maxWSReconnectAttempts       = 5
+	asyncQueueSize               = 16
 )
 
 var (
 	ErrStreamClosed = fmt.Errorf("client: use of closed Stream")
+
+	// ErrSessionReplaced is returned when the server dropped the Stream connections because
+	// another connection using the same credentials replaced the session.
+	ErrSessionReplaced = fmt.Errorf("client: stream session replaced by another connection using the same credentials")
+
+	// ErrReadTimeout is the error of a Stream connection reconnected after receiving no message
+	// within Config.WsReadTimeout.
+	ErrReadTimeout = fmt.Errorf("client: stream websocket read timeout")
 )
 
 type message struct {
 	Report *ReportResponse `json:"report"`
 }
 
+// received is a report message with the connection it was received from.
+type received struct {
+	*message
+	conn *wsConn
+}
+
 // Stream represents a realtime report stream.
-// Safe for concurrent usage.
+//
+// Stream is safe for concurrent usage: Read, Stats, Close and the ExtendedStream methods
+// may be called from multiple goroutines, each report is delivered to a single Read call
+// and Close may be called multiple times. Reports of a feed are delivered in ObservationsTimestamp
+// order to a single reader, see MonotonicityConfig.
 //
 // The Stream will maintain at least 2 concurrent connections to different instances
 // to ensure high availability, fault tolerance and minimize the risk of report gaps.
@@ -50,6 +69,70 @@ type Stream interface {
 	Close() error
 }
 
+// ExtendedStream is the Stream with the capabilities added since the Stream interface was introduced,
+// implemented by the Streams created by the Client. Type assert a Stream to use them, so that existing
+// implementations and mocks of Stream remain valid.
+type ExtendedStream interface {
+	Stream
+
+	// ReadInto reads the next available report on the Stream like Read and copies it into dst,
+	// the full report being copied into the dst FullReport buffer, reused when large enough.
+	// The report is still decoded and allocated by the Stream as for Read, ReadInto only lets
+	// readers keep a single caller-owned report and full report buffer. With Config.LazyFullReport
+	// the hex encoded full report is decoded directly into the dst buffer.
+	ReadInto(ctx context.Context, dst *ReportResponse) error
+
+	// StatsDelta returns the Stream counters accumulated since the previous StatsDelta call,
+	// or since the Stream creation on the first call. Connection counts are current values.
+	StatsDelta() Stats
+
+	// StatsV2 returns the Stream stats with all the Stream counters, see StatsV2.
+	StatsV2() StatsV2
+
+	// SLO returns the service level objectives status of the Stream.
+	// Returns a zero SLOStatus if Config.SLO is not set.
+	SLO() SLOStatus
+
+	// Handover stops delivering reports, saves the watermarks of the delivered reports
+	// to the HandoverStore for a StandbyStream to take over and closes the Stream.
+	Handover(ctx context.Context, store HandoverStore) error
+
+	// Labels returns the labels the Stream was created with, nil if none.
+	Labels() Labels
+
+	// State returns the aggregate state of the Stream connections, see ConnState.
+	State() ConnState
+
+	// Provenance returns the connections that delivered the accepted report of the feed with the
+	// given ObservationsTimestamp, false if not recorded. Requires Config.Provenance, see ProvenanceConfig.
+	Provenance(id feed.ID, observationsTimestamp uint64) (Provenance, bool)
+
+	// Done returns a channel closed when the Stream is closed, by Close, a failure or
+	// the cancellation of the context it was created with, like context.Context Done.
+	Done() <-chan struct{}
+
+	// Err returns nil until Done is closed, then the error Read returns once the Stream is closed,
+	// ErrStreamClosed if the Stream was closed by Close or its context.
+	Err() error
+
+	// Context returns a context canceled when the Stream is closed,
+	// to scope work to the Stream lifetime.
+	Context() context.Context
+
+	// Events returns the channel of control events received from the server.
+	// Events are dropped if the channel is not being read.
+	Events() <-chan Event
+
+	// WriteRecords writes the reports read from the Stream to w in the codec record format until the context
+	// is done, the Stream fails or is closed or a write fails. Each record is written with a single Write call.
+	// Returns the number of bytes written and a nil error once the Stream is closed.
+	WriteRecords(ctx context.Context, w io.Writer, codec RecordCodec) (n int64, err error)
+
+	// WriteTo implements io.WriterTo, writing the reports as newline delimited JSON
+	// until the Stream is closed, see WriteRecords.
+	WriteTo(w io.Writer) (n int64, err error)
+}
+
 // Stats for the Stream
 type Stats struct {
 	Accepted              uint64 // Total number of accepted reports
@@ -70,46 +153,133 @@ func (s Stats) String() (st string) {
 	)
 }
 
+// stream implements Stream as a set of actors:
+//   - a monitor goroutine per connection owning the connection lifecycle,
+//     reading messages and forwarding them to the dispatcher.
+//   - a single dispatcher goroutine owning the deduplication and delivery state,
+//     state changes requested by other goroutines are executed as commands on the dispatcher.
+//
+// The output channel is never closed, readers and writers select on the stream context
+// which is canceled when the stream is closed.
 type stream struct {
-	httpClient         *http.Client
-	customHeaders      http.Header
-	config             Config
-	output             chan *ReportResponse
-	feedIDs            []feed.ID
-	conns              []*wsConn
-	streamCtx          context.Context
-	streamCtxCancel    context.CancelFunc
-	closeError         atomic.Value
-	connStatusCallback func(isConneccted bool, host string, origin string)
-
-	waterMarkMu sync.Mutex
-	waterMark   map[string]uint64
+	httpClient      *http.Client
+	customHeaders   http.Header
+	config          Config
+	labels          Labels
+	clock           *clockEstimator
+	creds           *credentials
+	endpoints       *endpoints
+	registry        *streamRegistry
+	recent          recentEvents
+	output          chan *ReportResponse
+	incoming        chan received
+	commands        chan func()
+	events          chan Event
+	feedsMu         sync.Mutex
+	feedIDs         []feed.ID
+	conns           []*wsConn
+	streamCtx       context.Context
+	streamCtxCancel context.CancelFunc
+	closeError      atomic.Value
+	connNotifier    *connNotifier
+
+	// inspects the websocket handshake responses, see InspectHttpResponseCtxKey
+	inspectHandshake func(*http.Response)
+
+	// owned by the dispatcher
+	waterMark map[string]uint64
+	queue     []*queued
+
+	slo          *sloTracker
+	standby      *standby
+	marketStatus *marketStatusTracker
+	verifier     *verifier
+	provenance   *provenanceTracker
+	latency      *latencyTracker
+
+	readers  atomic.Int32
+	lastRead atomic.Int64
+
+	statsDeltaMu sync.Mutex
+	statsLast    Stats
+
+	deliveredMu  sync.Mutex
+	delivered    map[string]uint64
+	handedOver   bool
+	monotonicity *monotonicityChecker
 
 	stats struct {
-		accepted              atomic.Uint64
-		skipped               atomic.Uint64
-		partialReconnects     atomic.Uint64
-		fullReconnects        atomic.Uint64
-		activeConnections     atomic.Uint64
-		configuredConnections atomic.Uint64
+		accepted               atomic.Uint64
+		skipped                atomic.Uint64
+		partialReconnects      atomic.Uint64
+		fullReconnects         atomic.Uint64
+		activeConnections      atomic.Uint64
+		configuredConnections  atomic.Uint64
+		clockAnomalies         atomic.Uint64
+		clockRejected          atomic.Uint64
+		sharedDeduplicated     atomic.Uint64
+		filtered               atomic.Uint64
+		suppressed             atomic.Uint64
+		monotonicityViolations atomic.Uint64
+		validationFailed       atomic.Uint64
+		standbyDropped         atomic.Uint64
 	}
 
-	closed       atomic.Bool
-	closingMutex sync.RWMutex
+	closed atomic.Bool
 }
 
+// streamOption configures a stream before its connections are established.
+type streamOption func(*stream)
+
 func (c *client) newStream(ctx context.Context, httpClient *http.Client, feedIDs []feed.ID,
-	origins []string, connStatusCallback func(isConnected bool, host string, origin string)) (s *stream, err error) {
+	origins []string, connStatusCallback func(ConnStatus),
+	opts ...streamOption) (s *stream, err error) {
 	streamCtx, streamCtxCancel := context.WithCancel(ctx)
+	labels := labelsFromContext(ctx)
 	s = &stream{
-		httpClient:         httpClient,
-		connStatusCallback: connStatusCallback,
-		config:             c.config,
-		output:             make(chan *ReportResponse, 1),
-		feedIDs:            feedIDs,
-		waterMark:          make(map[string]uint64),
-		streamCtx:          streamCtx,
-		streamCtxCancel:    streamCtxCancel,
+		httpClient:      httpClient,
+		config:          c.config.withLabels(labels),
+		labels:          labels,
+		clock:           c.clock,
+		creds:           c.creds,
+		endpoints:       c.endpoints,
+		output:          make(chan *ReportResponse),
+		incoming:        make(chan received),
+		commands:        make(chan func()),
+		events:          make(chan Event, eventsBufferSize),
+		feedIDs:         feedIDs,
+		waterMark:       make(map[string]uint64),
+		delivered:       make(map[string]uint64),
+		streamCtx:       streamCtx,
+		streamCtxCancel: streamCtxCancel,
+	}
+
+	if c.config.Verification != nil {
+		s.verifier = newVerifier(*c.config.Verification)
+	}
+
+	if c.config.Provenance != nil {
+		s.provenance = newProvenanceTracker(*c.config.Provenance)
+	}
+
+	if c.config.Latency != nil {
+		s.latency = newLatencyTracker(*c.config.Latency)
+	}
+
+	if c.config.Monotonicity != nil {
+		s.monotonicity = newMonotonicityChecker(*c.config.Monotonicity)
+	}
+
+	if c.config.MarketStatus != nil {
+		s.marketStatus = &marketStatusTracker{cfg: *c.config.MarketStatus, feeds: map[feed.ID]*marketStatus{}}
+	}
+
+	if connStatusCallback != nil {
+		s.connNotifier = &connNotifier{callback: connStatusCallback, window: c.config.ConnStatusWindow}
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
 
 	if value := ctx.Value(CustomHeadersCtxKey); value != nil {
@@ -117,34 +287,176 @@ func (c *client) newStream(ctx context.Context, httpClient *http.Client, feedIDs
 			s.customHeaders = h
 		}
 	}
+	s.inspectHandshake, _ = inspectFromContext(ctx)
 
 	// only creates a HA stream if
 	// more than a single origin is provided
-	// and ws ha is enabled
-	if len(origins) > 0 && c.config.WsHA {
-		c.config.logDebug("client: attempting to connect websockets in HA mode")
-		for x := 0; x < len(origins); x++ {
-			conn, err := s.newWSconn(ctx, origins[x])
-			if err != nil {
-				return nil, err
-			}
-			go s.monitorConn(conn)
-			s.conns = append(s.conns, conn)
-			s.stats.configuredConnections.Add(1)
-		}
-	} else {
-		conn, err := s.newWSconn(ctx, "")
+	// and ws ha is enabled, otherwise a single origin may be selected
+	switch {
+	case len(origins) == 0:
+		origins = []string{""}
+	case !c.config.WsHA:
+		origins = origins[:1]
+	default:
+		s.config.logDebug("client: attempting to connect websockets in HA mode")
+	}
+
+	for x := 0; x < len(origins); x++ {
+		conn, err := s.newWSconn(ctx, origins[x])
 		if err != nil {
+			for _, conn := range s.conns {
+				_ = conn.close()
+			}
+			streamCtxCancel()
 			return nil, err
 		}
-		go s.monitorConn(conn)
+		conn.id, conn.seq = uint64(x+1), 1
+		s.transition(conn, ConnConnected)
 		s.conns = append(s.conns, conn)
 		s.stats.configuredConnections.Add(1)
+		s.stats.activeConnections.Add(1)
+	}
+
+	if c.config.SLO != nil {
+		s.slo = newSLOTracker(*c.config.SLO, feedIDs, time.Now)
+		s.slo.labels = s.labels
+	}
+
+	s.registry = &c.streams
+	s.registry.add(s)
+	// a stream ended by its context or a fatal error is unregistered without waiting for Close
+	context.AfterFunc(s.streamCtx, func() { s.registry.remove(s) })
+
+	go s.dispatch()
+
+	// monitors are only started once all connections are established
+	// as a failing connection may close the stream
+	for x := 0; x < len(s.conns); x++ {
+		go s.monitorConn(s.conns[x])
+	}
+
+	if s.config.Alerts != nil {
+		go s.monitorAlerts()
+	}
+
+	if s.slo != nil {
+		go s.monitorSLO()
+	}
+
+	if c.config.Entitlement != nil {
+		go s.monitorEntitlements(c.GetFeeds)
+	}
+
+	if c.config.Abandon != nil {
+		s.lastRead.Store(time.Now().UnixNano())
+		go s.monitorAbandon()
 	}
 
 	return s, nil
 }
 
+// queued is a report waiting to be delivered, ready is closed once the report is prepared
+// for delivery and drop is set if the report must not be delivered.
+type queued struct {
+	r     *ReportResponse
+	ready chan struct{}
+	drop  bool
+}
+
+// dispatch runs the dispatcher loop until the stream is closed.
+// Messages from the connections are only received while the delivery queue is not full
+// which applies back pressure to the connections when the stream is not being read.
+func (s *stream) dispatch() {
+	queueSize := 1
+	if s.async() {
+		queueSize = asyncQueueSize
+	}
+
+	for {
+		var out chan *ReportResponse
+		var in chan received
+		var next *ReportResponse
+		var wait chan struct{}
+		if len(s.queue) > 0 {
+			if head := s.queue[0]; head.ready == nil {
+				out, next = s.output, head.r
+			} else {
+				wait = head.ready
+			}
+		}
+		if len(s.queue) < queueSize {
+			in = s.incoming
+		}
+
+		select {
+		case <-s.streamCtx.Done():
+			return
+		case m := <-in:
+			if r := s.accept(m); r != nil {
+				s.queue = append(s.queue, s.enqueue(r))
+			}
+		case <-wait:
+			if s.queue[0].drop {
+				s.queue[0] = nil
+				s.queue = s.queue[1:]
+			} else {
+				s.queue[0].ready = nil
+			}
+		case out <- next:
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+		case cmd := <-s.commands:
+			cmd()
+		}
+	}
+}
+
+// async reports whether reports are prepared for delivery asynchronously.
+func (s *stream) async() bool {
+	return s.verifier != nil || s.config.SharedDedup != nil
+}
+
+// enqueue prepares the report for delivery.
+func (s *stream) enqueue(r *ReportResponse) (q *queued) {
+	q = &queued{r: r}
+	if s.async() {
+		q.ready = make(chan struct{})
+		go s.prepare(q)
+	}
+	return q
+}
+
+// prepare claims the report across consumers and verifies it if configured.
+func (s *stream) prepare(q *queued) {
+	defer close(q.ready)
+
+	if s.config.SharedDedup != nil && !s.claim(q.r) {
+		q.drop = true
+		return
+	}
+
+	if s.verifier != nil {
+		s.verifier.verify(s.streamCtx, q.r)
+		if q.r.Verification == Unverified {
+			s.stats.validationFailed.Add(1)
+		}
+	}
+}
+
+// do executes the command on the dispatcher and waits for its completion.
+func (s *stream) do(ctx context.Context, cmd func()) (err error) {
+	done := make(chan struct{})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.streamCtx.Done():
+		return ErrStreamClosed
+	case s.commands <- func() { cmd(); close(done) }:
+	}
+	<-done
+	return nil
+}
+
 func (s *stream) pingConn(ctx context.Context, conn *wsConn) {
 	ticker := time.NewTicker(time.Second * 2)
 	defer ticker.Stop()
@@ -156,14 +468,15 @@ func (s *stream) pingConn(ctx context.Context, conn *wsConn) {
 
 		case <-ticker.C:
 			pctx, pcancel := context.WithTimeout(context.Background(), 2*time.Second)
-			err := conn.conn.Ping(pctx)
+			err := conn.current().Ping(pctx)
 			pcancel()
 
-			if s.closed.Load() {
+			if s.closed.Load() || ctx.Err() != nil {
 				return
 			}
 
 			if err != nil {
+				conn.pingFailed.Store(true)
 				s.config.logInfo(
 					"client: stream websocket %s ping error: %s, closing client: %s",
 					conn.origin, err, conn.close(),
@@ -175,27 +488,26 @@ func (s *stream) pingConn(ctx context.Context, conn *wsConn) {
 }
 
 func (s *stream) monitorConn(conn *wsConn) {
-	if s.connStatusCallback != nil {
-		go s.connStatusCallback(true, conn.host, conn.origin)
-	}
+	s.notifyConnect(conn)
 	for !s.closed.Load() {
 		ctx, cancel := context.WithCancel(s.streamCtx)
 
 		// start pinging the server in the background and ensure we fail
 		// an unresponsive connection fast
+		conn.pingFailed.Store(false)
 		go s.pingConn(ctx, conn)
 
-		// Set this conn to active
-		s.stats.activeConnections.Add(1)
-
 		// read blocks until conn is closed or errors out
-		err := conn.read(ctx, &s.closingMutex, s.accept)
+		err := conn.read(ctx, s.config.WsReadTimeout, func(ctx context.Context, b []byte) error { return s.handle(ctx, conn, b) })
 		cancel()
 		// `Add(^uint64(0))` will decrement activeConnections
 		s.stats.activeConnections.Add(^uint64(0))
-		if s.connStatusCallback != nil {
-			go s.connStatusCallback(false, conn.host, conn.origin)
+		if s.streamCtx.Err() != nil || s.closed.Load() || isSessionReplaced(err) {
+			s.transition(conn, ConnClosed)
+		} else {
+			s.transition(conn, ConnReconnecting)
 		}
+		s.notifyDisconnect(conn, err)
 
 		// check for stream close conditions before reconnect attempts
 		if ctxErr := s.streamCtx.Err(); ctxErr != nil || s.closed.Load() {
@@ -204,7 +516,25 @@ func (s *stream) monitorConn(conn *wsConn) {
 					"client: stream websocket %s context done: %s",
 					conn.origin, s.streamCtx.Err(),
 				)
-				conn.close()
+			}
+			_ = conn.close()
+			s.transition(conn, ConnClosed)
+			return
+		}
+
+		// do not fight over the session with another process sharing the same credentials
+		if isSessionReplaced(err) {
+			s.config.logInfo(
+				"client: stream websocket %s: session replaced by another connection using the same credentials: %s",
+				conn.origin, err,
+			)
+			if s.config.OnSessionReplaced != nil {
+				go s.config.OnSessionReplaced(conn.host, conn.origin)
+			}
+			_ = conn.close()
+			s.transition(conn, ConnClosed)
+			if s.stats.activeConnections.Load() == 0 {
+				s.fail(fmt.Errorf("%w, last error: %w", ErrSessionReplaced, err))
 			}
 			return
 		}
@@ -217,8 +547,8 @@ func (s *stream) monitorConn(conn *wsConn) {
 		}
 
 		s.config.logInfo(
-			"client: stream websocket %s error: %s",
-			conn.origin, err,
+			"client: stream websocket %s error: %s, request id: %s",
+			conn.origin, err, conn.requestID,
 		)
 		s.config.logInfo(
 			"client: reconnecting stream websocket %s",
@@ -228,59 +558,74 @@ func (s *stream) monitorConn(conn *wsConn) {
 		// ensure the current connection is closed
 		_ = conn.close()
 
-		// reconnect loop
-		// will try to reconnect until client is closed or
-		// we have no active connections and have exceeded maxWSReconnectAttempts
-		var attempts int
-		for {
-			var re *wsConn
-			var err error
-
-			if s.closed.Load() {
-				return
-			}
+		if !s.reconnect(conn, err) {
+			s.transition(conn, ConnClosed)
+			return
+		}
+	}
+}
 
-			// fail the stream if we are over the maxWSReconnectAttempts
-			// and there are no other active connection
-			if attempts >= s.config.WsMaxReconnect && s.stats.activeConnections.Load() == 0 {
-				s.closeError.CompareAndSwap(nil, fmt.Errorf("stream has no active connections, last error: %w", err))
-				s.Close()
-				return
-			}
-			attempts++
+// reconnect tries to reconnect until the stream is closed or
+// there are no active connections and maxWSReconnectAttempts is exceeded.
+func (s *stream) reconnect(conn *wsConn, lastErr error) (ok bool) {
+	var attempts int
+	for {
+		if s.closed.Load() {
+			return false
+		}
 
-			ctx, cancel = context.WithTimeout(context.Background(), defaultWSConnectTimeout)
-			re, err = s.newWSconn(ctx, conn.origin)
-			cancel()
+		// fail the stream if we are over the maxWSReconnectAttempts
+		// and there are no other active connection
+		if attempts >= s.config.WsMaxReconnect && s.stats.activeConnections.Load() == 0 {
+			s.fail(fmt.Errorf("stream has no active connections, last error: %w", lastErr))
+			return false
+		}
+		attempts++
 
-			if err != nil {
-				interval := time.Millisecond * time.Duration(
-					rand.Intn(maxWSReconnectIntervalMIllis-minWSReconnectIntervalMillis)+minWSReconnectIntervalMillis) //nolint:gosec
-				s.config.logInfo(
-					"client: stream websocket %s: error reconnecting: %s, backing off: %s",
-					conn.origin, err, interval.String(),
-				)
-				time.Sleep(interval)
-				continue
-			}
+		ctx, cancel := context.WithTimeout(context.Background(), defaultWSConnectTimeout)
+		re, err := s.newWSconn(ctx, conn.origin)
+		cancel()
 
-			conn.replace(re.conn)
-			if s.connStatusCallback != nil {
-				go s.connStatusCallback(true, conn.host, conn.origin)
-			}
+		if err != nil {
+			lastErr = err
+			interval := s.config.WsReconnectBackoff.Backoff(attempts)
 			s.config.logInfo(
-				"client: stream websocket %s: reconnected",
-				conn.origin,
+				"client: stream websocket %s: error reconnecting: %s, backing off: %s",
+				conn.origin, err, interval.String(),
 			)
-			break
+			select {
+			case <-s.streamCtx.Done():
+				return false
+			case <-time.After(interval):
+			}
+			continue
 		}
+
+		conn.replace(re.conn, re.requestID)
+		conn.seq++
+		// Set this conn to active
+		s.stats.activeConnections.Add(1)
+		s.transition(conn, ConnConnected)
+		s.notifyConnect(conn)
+		s.config.logInfo(
+			"client: stream websocket %s: reconnected",
+			conn.origin,
+		)
+		return true
 	}
 }
 
+// fail closes the stream with the given error returned by Read and Close.
+func (s *stream) fail(err error) {
+	s.closeError.CompareAndSwap(nil, err)
+	_ = s.Close()
+}
+
 func (s *stream) Stats() (st Stats) {
 	st.Accepted = s.stats.accepted.Load()
 	st.Deduplicated = s.stats.skipped.Load()
-	st.TotalReceived = st.Accepted + st.Deduplicated
+	// reports rejected by the clock sanity check are received but neither accepted nor deduplicated
+	st.TotalReceived = st.Accepted + st.Deduplicated + s.stats.clockRejected.Load()
 	st.PartialReconnects = s.stats.partialReconnects.Load()
 	st.FullReconnects = s.stats.fullReconnects.Load()
 	st.ConfiguredConnections = s.stats.configuredConnections.Load()
@@ -289,36 +634,106 @@ func (s *stream) Stats() (st Stats) {
 	return st
 }
 
+func (s *stream) StatsDelta() (st Stats) {
+	s.statsDeltaMu.Lock()
+	defer s.statsDeltaMu.Unlock()
+
+	cur := s.Stats()
+	st = cur
+	st.Accepted -= s.statsLast.Accepted
+	st.Deduplicated -= s.statsLast.Deduplicated
+	st.TotalReceived -= s.statsLast.TotalReceived
+	st.PartialReconnects -= s.statsLast.PartialReconnects
+	st.FullReconnects -= s.statsLast.FullReconnects
+	s.statsLast = cur
+
+	return st
+}
+
+func (s *stream) Labels() Labels {
+	return s.labels
+}
+
+func (s *stream) SLO() (st SLOStatus) {
+	if s.slo == nil {
+		return st
+	}
+	return s.slo.status()
+}
+
 func (s *stream) Read(ctx context.Context) (r *ReportResponse, err error) {
+	defer s.readStarted()()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
-	case r := <-s.output:
-		// return a valid report even if stream is closed
-		// errstreamClosed will be returned on next call to Read()
-		if s.closed.Load() && r == nil {
-			if err, ok := s.closeError.Load().(error); ok {
-				return nil, err
-			}
-			return nil, ErrStreamClosed
-		}
-		return r, nil
+	case r = <-s.output:
+		return s.delivering(r)
+	case <-s.streamCtx.Done():
+		return nil, s.Err()
 	}
 }
 
+func (s *stream) ReadInto(ctx context.Context, dst *ReportResponse) (err error) {
+	r, err := s.Read(ctx)
+	if err != nil {
+		return err
+	}
+	r.copyInto(dst)
+	return nil
+}
+
+// delivering records the report delivered to the reader.
+func (s *stream) delivering(r *ReportResponse) (*ReportResponse, error) {
+	s.deliveredMu.Lock()
+	// reports not read before a handover are delivered by the standby stream
+	if s.handedOver {
+		s.deliveredMu.Unlock()
+		return nil, ErrStreamClosed
+	}
+	s.delivered[r.FeedID.String()] = r.ObservationsTimestamp
+	var violations []MonotonicityViolation
+	if s.monotonicity != nil {
+		violations = s.monotonicity.check(r)
+	}
+	s.deliveredMu.Unlock()
+
+	for _, v := range violations {
+		s.monotonicityViolated(v)
+	}
+	return r, nil
+}
+
+func (s *stream) Done() <-chan struct{} {
+	return s.streamCtx.Done()
+}
+
+func (s *stream) Err() error {
+	if s.streamCtx.Err() == nil {
+		return nil
+	}
+	if err, ok := s.closeError.Load().(error); ok {
+		return err
+	}
+	return ErrStreamClosed
+}
+
+func (s *stream) Context() context.Context {
+	return s.streamCtx
+}
+
 func (s *stream) Close() (err error) {
 	if !s.closed.CompareAndSwap(false, true) {
 		return nil
 	}
 	s.streamCtxCancel()
-	// this lock ensures websocket readers stop in a safe spot for closing
-	s.closingMutex.Lock()
-	defer s.closingMutex.Unlock()
+	if s.registry != nil {
+		s.registry.remove(s)
+	}
 
 	for x := 0; x < len(s.conns); x++ {
 		_ = s.conns[x].close()
 	}
-	close(s.output)
 	// return a pending error
 	if err, ok := s.closeError.Load().(error); ok {
 		return err
@@ -327,33 +742,114 @@ func (s *stream) Close() (err error) {
 	return nil
 }
 
-func (s *stream) accept(ctx context.Context, m *message) (err error) {
+// handle sends the reports read by a connection to the dispatcher,
+// other messages are published as events.
+func (s *stream) handle(ctx context.Context, conn *wsConn, b []byte) (err error) {
+	m, err := s.decodeMessage(b)
+	if err != nil || m.Report == nil {
+		s.event(parseEvent(bytes.Clone(b), conn.host, conn.origin, s.labels))
+		return nil
+	}
+	if err = s.config.checkUnknownFields(b, m); err != nil {
+		s.config.logInfo("client: stream report from %s dropped: %s", conn.host, err)
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.incoming <- received{m, conn}:
+		return nil
+	}
+}
+
+// accept deduplicates the received report returning it if it must be delivered.
+// Must only be called by the dispatcher.
+func (s *stream) accept(m received) (r *ReportResponse) {
 	id := m.Report.FeedID.String()
 
-	s.waterMarkMu.Lock()
+	// check before deduplication so rejected reports do not move the watermark
+	if s.config.ClockSanity != nil {
+		if anomaly := s.config.ClockSanity.check(m.Report.ObservationsTimestamp, time.Now()); anomaly != "" {
+			s.stats.clockAnomalies.Add(1)
+			s.config.logInfo(
+				"client: stream report %s observations timestamp %d %s",
+				id, m.Report.ObservationsTimestamp, anomaly,
+			)
+			if s.config.ClockSanity.Reject {
+				s.stats.clockRejected.Add(1)
+				return nil
+			}
+		}
+	}
+
 	if s.waterMark[id] >= m.Report.ObservationsTimestamp {
+		if s.provenance != nil {
+			s.provenance.duplicate(m.Report, m.conn, time.Now())
+		}
 		s.stats.skipped.Add(1)
-		s.waterMarkMu.Unlock()
 		return nil
 	}
 
 	s.stats.accepted.Add(1)
 	s.waterMark[id] = m.Report.ObservationsTimestamp
-	s.waterMarkMu.Unlock()
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case s.output <- m.Report:
+	if s.provenance != nil {
+		s.provenance.accepted(m.Report, m.conn, time.Now())
+	}
+
+	if s.latency != nil {
+		s.latency.accepted(id, m.Report.ObservationsTimestamp, s.clock.serverNow())
+	}
+
+	if s.slo != nil {
+		s.slo.accepted(m.Report.FeedID)
+	}
+
+	if s.config.OnAccept != nil && !s.config.OnAccept(m.Report) {
+		s.stats.filtered.Add(1)
+		return nil
+	}
+
+	if s.marketStatus != nil && s.checkMarketStatus(m.Report) {
 		return nil
 	}
+
+	if s.standby != nil {
+		held, dropped := s.standby.hold(m.Report)
+		if dropped {
+			s.stats.standbyDropped.Add(1)
+		}
+		if held {
+			return nil
+		}
+	}
+	return m.Report
 }
 
 type wsConn struct {
-	mu     sync.Mutex
-	host   string
-	origin string
-	conn   *websocket.Conn
+	id         uint64 // stable connection id within the stream
+	seq        uint64 // connection session number, only accessed by the connection monitor
+	mu         sync.Mutex
+	host       string
+	origin     string
+	requestID  string
+	conn       websocketConn
+	state      atomic.Int32
+	pingFailed atomic.Bool // set when the current session was closed after a failed ping
+}
+
+// transition moves the connection to the given state.
+func (s *stream) transition(ws *wsConn, cs ConnState) {
+	if prev := ConnState(ws.state.Swap(int32(cs))); prev != cs {
+		s.config.logDebug("client: stream websocket %s: %s -> %s", ws.origin, prev, cs)
+	}
+}
+
+func (ws *wsConn) current() websocketConn {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.conn
 }
 
 func (ws *wsConn) close() (err error) {
@@ -362,46 +858,133 @@ func (ws *wsConn) close() (err error) {
 	return ws.conn.CloseNow()
 }
 
-func (ws *wsConn) read(ctx context.Context, closingMutex *sync.RWMutex, accept func(context.Context, *message) error) (err error) {
-	var lastErr error
+// read reads messages from the connection until it errors out or the context is done.
+// If timeout is set each message must be received within timeout, control frames such as
+// pongs do not extend the deadline. The message passed to handle is only valid until handle returns.
+func (ws *wsConn) read(ctx context.Context, timeout time.Duration, handle func(context.Context, []byte) error) (err error) {
+	conn := ws.current()
 	for {
-		// coordinates with a potential Close function call from client
-		closingMutex.RLock()
-		_, b, err := ws.conn.Read(ctx)
+		buf := readBufferPool.Get().(*bytes.Buffer)
+		buf.Reset()
+		if err = ws.readMessage(ctx, conn, timeout, buf); err == nil {
+			err = handle(ctx, buf.Bytes())
+		}
+		if buf.Cap() <= maxPooledReadBuffer {
+			readBufferPool.Put(buf)
+		}
 		if err != nil {
-			lastErr = err
-			break
+			return err
 		}
+	}
+}
 
-		m := &message{}
-		if err = json.Unmarshal(b, m); err != nil {
-			lastErr = err
-			break
-		}
+// readMessage reads the next message into buf within timeout, if set.
+// The connection is closed when the timeout expires.
+func (ws *wsConn) readMessage(ctx context.Context, conn websocketConn, timeout time.Duration, buf *bytes.Buffer) (err error) {
+	rctx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		rctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
-		if err = accept(ctx, m); err != nil {
-			lastErr = err
-			break
-		}
-		closingMutex.RUnlock()
+	err = conn.Read(rctx, buf)
+	if err != nil && ctx.Err() == nil && errors.Is(rctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: no message received within %s: %w", ErrReadTimeout, timeout, err)
+	}
+	return err
+}
+
+// isSessionReplaced reports whether the connection was closed by the server
+// because another connection with the same credentials took over the session.
+func isSessionReplaced(err error) bool {
+	code, reason, ok := closeStatus(err)
+	if !ok || code != statusPolicyViolation {
+		return false
 	}
-	closingMutex.RUnlock()
-	return lastErr
+	reason = strings.ToLower(reason)
+	return strings.Contains(reason, "replaced") || strings.Contains(reason, "duplicate")
 }
 
-func (ws *wsConn) replace(c *websocket.Conn) {
+func (ws *wsConn) replace(c websocketConn, requestID string) {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	ws.conn = c
+	ws.requestID = requestID
 }
 
 func (s *stream) newWSconn(ctx context.Context, origin string) (ws *wsConn, err error) {
-	reqURL := s.config.wsURL.ResolveReference(&url.URL{Path: apiV1WS})
-	reqURL.RawQuery = url.Values{"feedIDs": {strings.Join(feedIdsToStringList(s.feedIDs), ",")}}.Encode()
+	id := requestID(ctx)
+	defer func() {
+		if err != nil {
+			err = &RequestError{RequestID: id, Err: err}
+		}
+	}()
+
+	reqURL := s.endpoints.ws().ResolveReference(&url.URL{Path: apiV1WS})
+	reqURL.RawQuery = url.Values{"feedIDs": {strings.Join(feedIdsToStringList(s.feeds()), ",")}}.Encode()
 
+	var override *OriginOverride
+	if s.config.WsOriginOverride != nil {
+		override = s.config.WsOriginOverride(origin)
+	}
+
+	var conn websocketConn
+	for retry := false; ; retry = true {
+		apiKey, apiSecret, err := s.creds.get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		overridden := override != nil && override.ApiKey != "" && override.ApiSecret != ""
+		if overridden {
+			apiKey, apiSecret = override.ApiKey, override.ApiSecret
+		}
+
+		var resp *http.Response
+		conn, resp, err = s.dial(ctx, id, *reqURL, origin, apiKey, apiSecret, override)
+		if err == nil {
+			break
+		}
+
+		// refresh rejected provider credentials once
+		if retry || overridden || resp == nil || !isAuthError(resp.StatusCode) {
+			return nil, err
+		}
+		if ok, rerr := s.creds.refresh(ctx, apiKey, apiSecret); !ok {
+			if rerr != nil {
+				s.config.logInfo("client: stream websocket %s: error refreshing credentials: %s", origin, rerr)
+			}
+			return nil, err
+		}
+		s.config.logInfo("client: stream websocket %s rejected with status code %d, retrying with refreshed credentials",
+			origin, resp.StatusCode)
+	}
+
+	s.config.logDebug("client: stream websocket %s connected, request id: %s", origin, id)
+	ws = &wsConn{
+		host:      reqURL.Host,
+		origin:    origin,
+		requestID: id,
+		conn:      conn,
+	}
+
+	return ws, nil
+}
+
+// dial dials the stream websocket signed with the given credentials.
+// The handshake response is returned along with dial errors when available.
+func (s *stream) dial(ctx context.Context, id string, reqURL url.URL, origin, apiKey, apiSecret string,
+	override *OriginOverride) (conn websocketConn, resp *http.Response, err error) {
 	headers := http.Header{}
-	generateAuthHeaders(headers, http.MethodGet, reqURL.RequestURI(), nil,
-		s.config.ApiKey, s.config.ApiSecret, time.Now().UnixMilli())
+	if now := s.clock.now(); s.config.WsQueryAuth {
+		generateAuthQuery(s.config.authScheme(), &reqURL, http.MethodGet, nil, apiKey, apiSecret,
+			now.UnixMilli(), now.Add(s.config.wsQueryAuthTTL()).UnixMilli())
+	} else {
+		generateAuthHeaders(s.config.authScheme(), headers, http.MethodGet, reqURL.RequestURI(), nil,
+			apiKey, apiSecret, now.UnixMilli())
+	}
+	headers.Set(requestIDHeader, id)
+	headers.Set(userAgentHeader, userAgent())
 
 	if origin != "" {
 		headers.Add(cllOriginHeader, origin)
@@ -413,27 +996,30 @@ func (s *stream) newWSconn(ctx context.Context, origin string) (ws *wsConn, err
 		}
 	}
 
-	opts := &websocket.DialOptions{
-		HTTPHeader:      headers,
-		CompressionMode: websocket.CompressionContextTakeover,
-		HTTPClient:      s.httpClient,
-		Host:            s.customHeaders.Get("Host"),
+	if override != nil {
+		for k, v := range override.Header {
+			headers.Set(k, v[0])
+		}
+	}
+
+	opts := wsDialOptions{
+		Header:     headers,
+		HTTPClient: s.httpClient,
+		Host:       s.customHeaders.Get("Host"),
+	}
+	s.config.logDebug("client: stream websocket dial request id: %s, url: %s, opts: %v", id, reqURL.String(), opts)
+	sent := time.Now()
+	conn, resp, err = dialWebsocket(ctx, reqURL.String(), opts)
+	if resp != nil && s.inspectHandshake != nil {
+		s.inspectHandshake(resp)
 	}
-	s.config.logDebug("client: stream websocket dial request url: %s, opts: %s", reqURL.String(), opts)
-	conn, resp, err := websocket.Dial(ctx, reqURL.String(), opts)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
+	s.clock.observe(sent, time.Now(), resp.Header)
 
 	if resp.StatusCode >= http.StatusBadRequest {
-		return nil, fmt.Errorf("client: invalid status code %d", resp.StatusCode)
-	}
-
-	ws = &wsConn{
-		host:   reqURL.Host,
-		origin: origin,
-		conn:   conn,
+		return nil, resp, fmt.Errorf("client: invalid status code %d", resp.StatusCode)
 	}
-
-	return ws, nil
+	return conn, resp, nil
 }
@@ -1,12 +1,15 @@
 package streams
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -144,7 +147,7 @@ func TestClient_SubscribeWithCallback(t *testing.T) {
 	callbackOrigin := "default"
 	expectedOrigin := ""
 	callbackHost := "default"
-	expectedHost := cc.config.wsURL.Host
+	expectedHost := cc.endpoints.ws().Host
 	statusCallbackFunc := func(connected bool, host string, origin string) {
 		callbackMu.Lock()
 		defer callbackMu.Unlock()
@@ -717,3 +720,565 @@ func TestClient_StreamCustomHeader(t *testing.T) {
 	}
 
 }
+
+func TestClient_StreamSessionReplaced(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(
+			w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover},
+		)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+
+		_ = conn.Close(websocket.StatusPolicyViolation, "session replaced")
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	replaced := make(chan string, 1)
+	cc := streamsClient.(*client)
+	cc.config.Logger = LogPrintf
+	cc.config.OnSessionReplaced = func(host string, origin string) {
+		replaced <- host
+	}
+
+	sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1, feed2})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	_, err = sub.Read(context.Background())
+	if !errors.Is(err, ErrSessionReplaced) {
+		t.Errorf("expected error %s, got %s", ErrSessionReplaced, err)
+	}
+
+	select {
+	case host := <-replaced:
+		if host != cc.endpoints.ws().Host {
+			t.Errorf("expected host %s, got %s", cc.endpoints.ws().Host, host)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("timed out waiting for session replaced callback")
+	}
+
+	if stats := sub.Stats(); stats.FullReconnects != 0 {
+		t.Errorf("expected no reconnects, got %d", stats.FullReconnects)
+	}
+}
+
+func TestClient_StreamOriginOverride(t *testing.T) {
+	connects := &atomic.Uint64{}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Add(cllAvailOriginsHeader, "{001,002}")
+			w.WriteHeader(200)
+			return
+		}
+
+		switch r.Header.Get(cllOriginHeader) {
+		case "001":
+			if r.Header.Get(authzHeader) != "apiKey" {
+				t.Errorf("expected default api key, got %s", r.Header.Get(authzHeader))
+			}
+		case "002":
+			if r.Header.Get(authzHeader) != "originKey" {
+				t.Errorf("expected origin api key, got %s", r.Header.Get(authzHeader))
+			}
+			if r.Header.Get("gateway-header") != "gateway-value" {
+				t.Errorf("missing origin header")
+			}
+		default:
+			t.Errorf("no %s header found", cllOriginHeader)
+		}
+
+		conn, err := websocket.Accept(
+			w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover},
+		)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+		connects.Add(1)
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	cc := streamsClient.(*client)
+	cc.config.WsHA = true
+	cc.config.WsOriginOverride = func(origin string) *OriginOverride {
+		if origin != "002" {
+			return nil
+		}
+		return &OriginOverride{
+			ApiKey:    "originKey",
+			ApiSecret: "originSecret",
+			Header:    http.Header{"gateway-header": {"gateway-value"}},
+		}
+	}
+
+	sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1, feed2})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	for connects.Load() != 2 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestClient_StreamConcurrentUsage(t *testing.T) {
+	const numReports = 200
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Add(cllAvailOriginsHeader, "{001,002}")
+			w.WriteHeader(200)
+			return
+		}
+
+		conn, err := websocket.Accept(
+			w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover},
+		)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for x := 1; x <= numReports; x++ {
+			b, err := json.Marshal(&message{&ReportResponse{FeedID: feed1, ObservationsTimestamp: uint64(x)}})
+			if err != nil {
+				t.Errorf("failed to serialize message: %s", err)
+			}
+
+			if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+				return
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	cc := streamsClient.(*client)
+	cc.config.WsHA = true
+	cc.config.SLO = &SLOConfig{MaxStaleness: time.Second, SampleInterval: time.Millisecond}
+
+	sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := map[uint64]int{}
+	for x := 0; x < 4; x++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for {
+				r, err := sub.Read(context.Background())
+				if err != nil {
+					if !errors.Is(err, ErrStreamClosed) {
+						t.Errorf("Read() error = %s, want %s", err, ErrStreamClosed)
+					}
+					return
+				}
+				mu.Lock()
+				seen[r.ObservationsTimestamp]++
+				mu.Unlock()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for sub.Stats().Accepted < numReports {
+				_ = sub.SLO()
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+
+	for {
+		mu.Lock()
+		n := len(seen)
+		mu.Unlock()
+		if n == numReports {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var closers sync.WaitGroup
+	for x := 0; x < 4; x++ {
+		closers.Add(1)
+		go func() {
+			defer closers.Done()
+			_ = sub.Close()
+		}()
+	}
+	closers.Wait()
+	wg.Wait()
+
+	for ts, n := range seen {
+		if n != 1 {
+			t.Errorf("report %d delivered %d times, want 1", ts, n)
+		}
+	}
+
+	if stats := sub.Stats(); stats.Accepted != numReports {
+		t.Errorf("Stats() = %s, want %d accepted", stats, numReports)
+	}
+}
+
+func TestClient_StreamOnAccept(t *testing.T) {
+	reports := []*ReportResponse{
+		{FeedID: feed1, ObservationsTimestamp: 1},
+		{FeedID: feed2, ObservationsTimestamp: 1},
+		{FeedID: feed1, ObservationsTimestamp: 2},
+	}
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(
+			w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover},
+		)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for x := 0; x < len(reports); x++ {
+			b, err := json.Marshal(&message{reports[x]})
+			if err != nil {
+				t.Errorf("failed to serialize message: %s", err)
+			}
+
+			if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+				return
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).config.OnAccept = func(r *ReportResponse) bool {
+		if r.FeedID == feed2 {
+			return false
+		}
+		r.Metadata = map[string]any{"symbol": "ETH/USD"}
+		return true
+	}
+
+	sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1, feed2}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	for _, want := range []uint64{1, 2} {
+		r, err := sub.Read(context.Background())
+		if err != nil {
+			t.Fatalf("error reading report %s", err)
+		}
+		if r.FeedID != feed1 || r.ObservationsTimestamp != want || r.Metadata["symbol"] != "ETH/USD" {
+			t.Errorf("Read() = %v %v, want annotated %s report %d", r, r.Metadata, feed1.String(), want)
+		}
+	}
+
+	if got := sub.StatsV2().Counter(StatFiltered); got != 1 {
+		t.Errorf("StatsV2() filtered = %d, want 1", got)
+	}
+}
+
+func TestClient_StreamQueryAuth(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		q := r.URL.Query()
+		if r.Header.Get(authzSigHeader) != "" {
+			t.Errorf("expected no signature header, got %s", r.Header.Get(authzSigHeader))
+		}
+
+		expires, _ := strconv.ParseInt(q.Get(authzExpiresParam), 10, 64)
+		if time.UnixMilli(expires).Before(time.Now()) {
+			t.Errorf("expected expiry in the future, got %s", time.UnixMilli(expires))
+		}
+
+		// the signature covers the url without the auth parameters
+		ts, _ := strconv.ParseInt(q.Get(authzTSParam), 10, 64)
+		sig := q.Get(authzSigParam)
+		for _, p := range []string{authzKeyParam, authzTSParam, authzSigParam} {
+			q.Del(p)
+		}
+		u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+		if want := generateHMAC(http.MethodGet, u.RequestURI(), nil, "apiKey", ts, "apiSecret"); sig != want {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).config.WsQueryAuth = true
+
+	sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1, feed2})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	_ = sub.Close()
+}
+
+func TestStream_StatsDelta(t *testing.T) {
+	s := &stream{}
+	s.stats.accepted.Add(3)
+	s.stats.skipped.Add(2)
+	s.stats.activeConnections.Add(2)
+
+	expected := Stats{Accepted: 3, Deduplicated: 2, TotalReceived: 5, ActiveConnections: 2}
+	if st := s.StatsDelta(); st != expected {
+		t.Errorf("StatsDelta() = %s, want %s", st, expected)
+	}
+
+	s.stats.accepted.Add(1)
+	s.stats.fullReconnects.Add(1)
+
+	expected = Stats{Accepted: 1, TotalReceived: 1, FullReconnects: 1, ActiveConnections: 2}
+	if st := s.StatsDelta(); st != expected {
+		t.Errorf("StatsDelta() = %s, want %s", st, expected)
+	}
+
+	if st := s.Stats(); st.Accepted != 4 || st.FullReconnects != 1 {
+		t.Errorf("Stats() = %s, want cumulative counters", st)
+	}
+}
+
+func TestStream_DoneErr(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	tests := []struct {
+		name    string
+		close   func(sub Stream, cancel context.CancelFunc)
+		abandon *AbandonConfig
+		wantErr error
+	}{
+		{name: "close", close: func(sub Stream, _ context.CancelFunc) { _ = sub.Close() }, wantErr: ErrStreamClosed},
+		{name: "context canceled", close: func(_ Stream, cancel context.CancelFunc) { cancel() }, wantErr: ErrStreamClosed},
+		{name: "failure", abandon: &AbandonConfig{MaxLifetime: 50 * time.Millisecond}, wantErr: ErrStreamLifetimeExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streamsClient, err := ms.Client()
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+			streamsClient.(*client).config.Abandon = tt.abandon
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			sub, err := extendedStream(streamsClient.Stream(ctx, []feed.ID{feed1}))
+			if err != nil {
+				t.Fatalf("error subscribing %s", err)
+			}
+			defer sub.Close()
+
+			select {
+			case <-sub.Done():
+				t.Fatalf("Done() closed before the stream closed")
+			default:
+			}
+			if err = sub.Err(); err != nil {
+				t.Errorf("Err() = %v, want nil", err)
+			}
+			if err = sub.Context().Err(); err != nil {
+				t.Errorf("Context().Err() = %v, want nil", err)
+			}
+
+			if tt.close != nil {
+				tt.close(sub, cancel)
+			}
+			select {
+			case <-sub.Done():
+			case <-time.After(time.Second):
+				t.Fatalf("timeout waiting for Done()")
+			}
+
+			if err = sub.Err(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Err() = %v, want %v", err, tt.wantErr)
+			}
+			if _, err = sub.Read(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Read() error = %v, want %v", err, tt.wantErr)
+			}
+			if sub.Context().Err() == nil {
+				t.Errorf("Context() not canceled")
+			}
+		})
+	}
+}
+
+func TestClient_StreamReadInto(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for _, m := range []string{
+			`{"report":{"feedID":"` + feed1.String() + `","fullReport":"0x0102","observationsTimestamp":1}}`,
+			`{"report":{"feedID":"` + feed1.String() + `","fullReport":"0x030405","observationsTimestamp":2}}`,
+		} {
+			if err = conn.Write(context.Background(), websocket.MessageBinary, []byte(m)); err != nil {
+				t.Errorf("failed to write message: %s", err)
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).config.LazyFullReport = true
+
+	sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var r ReportResponse
+	for _, want := range [][]byte{{1, 2}, {3, 4, 5}} {
+		if err = sub.ReadInto(ctx, &r); err != nil {
+			t.Fatalf("ReadInto() error = %s", err)
+		}
+		if !bytes.Equal(r.FullReport, want) || r.FeedID != feed1 {
+			t.Errorf("ReadInto() = %+v, want full report %x", r, want)
+		}
+	}
+}
+
+func TestClient_InspectHttpResponseCtx(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == apiV1WS {
+			conn, err := websocket.Accept(w, r, nil)
+			if err != nil {
+				t.Errorf("error accepting connection: %s", err)
+				return
+			}
+			_ = conn.CloseNow()
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]*ReportResponse{"report": {FeedID: feed1}})
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	var configured, perCall atomic.Int64
+	streamsClient.(*client).config.InspectHttpResponse = func(*http.Response) { configured.Add(1) }
+	ctx := context.WithValue(context.Background(), InspectHttpResponseCtxKey,
+		func(r *http.Response) {
+			if r.StatusCode != http.StatusOK && r.StatusCode != http.StatusSwitchingProtocols {
+				t.Errorf("inspected status code %d", r.StatusCode)
+			}
+			perCall.Add(1)
+		})
+
+	if _, err = streamsClient.GetLatestReport(ctx, feed1); err != nil {
+		t.Fatalf("GetLatestReport() error = %s", err)
+	}
+	if configured.Load() != 0 || perCall.Load() != 1 {
+		t.Errorf("inspected with context hook: configured %d, per call %d, want 0 and 1", configured.Load(), perCall.Load())
+	}
+
+	if _, err = streamsClient.GetLatestReport(context.Background(), feed1); err != nil {
+		t.Fatalf("GetLatestReport() error = %s", err)
+	}
+	if configured.Load() != 1 || perCall.Load() != 1 {
+		t.Errorf("inspected without context hook: configured %d, per call %d, want 1 and 1", configured.Load(), perCall.Load())
+	}
+
+	sub, err := streamsClient.Stream(ctx, []feed.ID{feed1})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	sub.Close()
+	if configured.Load() != 1 || perCall.Load() < 2 {
+		t.Errorf("inspected stream handshake: configured %d, per call %d, want 1 and at least 2", configured.Load(), perCall.Load())
+	}
+}
@@ -0,0 +1,148 @@
+package streams
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownFields is returned when decoding a server response with fields not modeled by the SDK
+// and Config.UnknownFields is UnknownFieldsStrict.
+var ErrUnknownFields = fmt.Errorf("client: unknown fields in server response")
+
+// UnknownFields specifies how the fields of the server json responses not modeled by the SDK are handled,
+// detecting new server fields before the SDK models them.
+type UnknownFields int
+
+const (
+	UnknownFieldsIgnore  UnknownFields = iota // Ignore unknown fields
+	UnknownFieldsStrict                       // Fail decoding responses with unknown fields with ErrUnknownFields
+	UnknownFieldsCapture                      // Retain the unknown fields of reports and feeds in their RawExtra field
+)
+
+func (u UnknownFields) String() string {
+	switch u {
+	case UnknownFieldsIgnore:
+		return "ignore"
+	case UnknownFieldsStrict:
+		return "strict"
+	case UnknownFieldsCapture:
+		return "capture"
+	default:
+		return "unknown"
+	}
+}
+
+var rawExtraType = reflect.TypeOf(map[string]json.RawMessage{})
+
+// checkUnknownFields handles the unknown fields of the json value b decoded into v as specified by
+// Config.UnknownFields, returning an error listing their paths in strict mode.
+func (c Config) checkUnknownFields(b []byte, v any) (err error) {
+	if c.UnknownFields != UnknownFieldsStrict && c.UnknownFields != UnknownFieldsCapture {
+		return nil
+	}
+
+	paths := unknownFields(b, reflect.ValueOf(v), "", c.UnknownFields == UnknownFieldsCapture)
+	if len(paths) == 0 {
+		return nil
+	}
+	if c.UnknownFields == UnknownFieldsStrict {
+		return fmt.Errorf("%w: %s", ErrUnknownFields, strings.Join(paths, ", "))
+	}
+	c.logDebug("client: captured unknown fields in server response: %s", strings.Join(paths, ", "))
+	return nil
+}
+
+// unknownFields returns the paths of the fields of the json value b not modeled by the value v it was
+// decoded into, recursing into nested structs and slices. The unknown fields of structs with
+// a RawExtra field are stored into it if capture is set.
+func unknownFields(b []byte, v reflect.Value, path string, capture bool) (paths []string) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		var raws []json.RawMessage
+		if json.Unmarshal(b, &raws) != nil {
+			return nil
+		}
+		for x := 0; x < len(raws) && x < v.Len(); x++ {
+			paths = append(paths, unknownFields(raws[x], v.Index(x), fmt.Sprintf("%s[%d]", path, x), capture)...)
+		}
+		return paths
+
+	case reflect.Struct:
+		var raws map[string]json.RawMessage
+		if json.Unmarshal(b, &raws) != nil {
+			return nil
+		}
+
+		keys := make([]string, 0, len(raws))
+		for k := range raws {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		var extra map[string]json.RawMessage
+		for _, k := range keys {
+			f, ok := jsonField(v.Type(), k)
+			if !ok {
+				paths = append(paths, strings.TrimPrefix(path+"."+k, "."))
+				if extra == nil {
+					extra = map[string]json.RawMessage{}
+				}
+				extra[k] = raws[k]
+				continue
+			}
+			switch f.Type.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Struct:
+				paths = append(paths, unknownFields(raws[k], v.FieldByIndex(f.Index), strings.TrimPrefix(path+"."+k, "."), capture)...)
+			default:
+			}
+		}
+
+		if capture && extra != nil {
+			if f := v.FieldByName("RawExtra"); f.IsValid() && f.CanSet() && f.Type() == rawExtraType {
+				f.Set(reflect.ValueOf(extra))
+			}
+		}
+		return paths
+
+	default:
+		return nil
+	}
+}
+
+// jsonField returns the exported field of the struct type decoded from the json key,
+// matching the key case insensitively as json.Unmarshal does.
+func jsonField(t reflect.Type, key string) (f reflect.StructField, ok bool) {
+	for x := 0; x < t.NumField(); x++ {
+		sf := t.Field(x)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if name == key {
+			return sf, true
+		}
+		if !ok && strings.EqualFold(name, key) {
+			f, ok = sf, true
+		}
+	}
+	return f, ok
+}
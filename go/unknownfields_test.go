@@ -0,0 +1,145 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"nhooyr.io/websocket"
+)
+
+func TestUnknownFields(t *testing.T) {
+	b := []byte(`{"reports":[` +
+		`{"feedID":"` + feed1.String() + `","fullReport":"0x01","observationsTimestamp":1},` +
+		`{"feedID":"` + feed2.String() + `","fullReport":"0x02","ObservationsTimestamp":2,"bid":"5","venue":{"name":"x"}}` +
+		`],"cursor":"abc"}`)
+
+	rs := &reportsResponse{}
+	if err := json.Unmarshal(b, rs); err != nil {
+		t.Fatalf("error decoding reports: %s", err)
+	}
+
+	paths := unknownFields(b, reflect.ValueOf(rs), "", true)
+	wantPaths := []string{"cursor", "reports[1].bid", "reports[1].venue"}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("unknownFields() = %v, want %v", paths, wantPaths)
+	}
+
+	if rs.Reports[0].RawExtra != nil {
+		t.Errorf("RawExtra = %v, want nil", rs.Reports[0].RawExtra)
+	}
+	wantExtra := map[string]json.RawMessage{"bid": json.RawMessage(`"5"`), "venue": json.RawMessage(`{"name":"x"}`)}
+	if !reflect.DeepEqual(rs.Reports[1].RawExtra, wantExtra) {
+		t.Errorf("RawExtra = %s, want %s", rs.Reports[1].RawExtra, wantExtra)
+	}
+}
+
+func TestClient_UnknownFields(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case apiV1Feeds:
+			_, _ = w.Write([]byte(`{"feeds":[{"feedID":"` + feed1.String() + `","name":"ETH/USD"}]}`))
+		case apiV1ReportsPage:
+			_, _ = w.Write([]byte(`{"reports":[{"feedID":"` + feed1.String() + `","fullReport":"0x01","bid":"5"}],"nextPageTS":2}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	defer ms.Close()
+
+	tests := []struct {
+		name      string
+		mode      UnknownFields
+		wantErr   error
+		wantExtra map[string]json.RawMessage
+	}{
+		{name: "ignore", mode: UnknownFieldsIgnore},
+		{name: "strict", mode: UnknownFieldsStrict, wantErr: ErrUnknownFields},
+		{name: "capture", mode: UnknownFieldsCapture, wantExtra: map[string]json.RawMessage{"name": json.RawMessage(`"ETH/USD"`)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streamsClient, err := ms.Client()
+			if err != nil {
+				t.Fatalf("error creating client %s", err)
+			}
+			streamsClient.(*client).config.UnknownFields = tt.mode
+
+			feeds, err := streamsClient.GetFeeds(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetFeeds() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(feeds[0].RawExtra, tt.wantExtra) {
+				t.Errorf("GetFeeds() RawExtra = %s, want %s", feeds[0].RawExtra, tt.wantExtra)
+			}
+			if err != nil && !strings.Contains(err.Error(), "feeds[0].name") {
+				t.Errorf("GetFeeds() error = %v, want the unknown field path", err)
+			}
+
+			for r, err := range streamsClient.IterReportPage(context.Background(), feed1, 1, 0) {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("IterReportPage() error = %v, want %v", err, tt.wantErr)
+				}
+				if err == nil && (r.RawExtra["bid"] != nil) != (tt.mode == UnknownFieldsCapture) {
+					t.Errorf("IterReportPage() RawExtra = %s", r.RawExtra)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_StreamUnknownFieldsStrict(t *testing.T) {
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for _, m := range []string{
+			`{"report":{"feedID":"` + feed1.String() + `","fullReport":"0x01","observationsTimestamp":1,"bid":"5"}}`,
+			`{"report":{"feedID":"` + feed1.String() + `","fullReport":"0x02","observationsTimestamp":2}}`,
+		} {
+			if err = conn.Write(context.Background(), websocket.MessageBinary, []byte(m)); err != nil {
+				t.Errorf("failed to write message: %s", err)
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	streamsClient.(*client).config.UnknownFields = UnknownFieldsStrict
+
+	sub, err := streamsClient.Stream(context.Background(), []feed.ID{feed1})
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	r, err := sub.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read() error = %s", err)
+	}
+	if r.ObservationsTimestamp != 2 {
+		t.Errorf("Read() ObservationsTimestamp = %d, want 2", r.ObservationsTimestamp)
+	}
+}
@@ -0,0 +1,173 @@
+package streams
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ConfigProblem describes an invalid Config field and how to fix it.
+type ConfigProblem struct {
+	Field   string // Config field name
+	Problem string // What is wrong with the field
+	Hint    string // How to fix it
+}
+
+func (p ConfigProblem) String() string {
+	return fmt.Sprintf("%s: %s (%s)", p.Field, p.Problem, p.Hint)
+}
+
+// ConfigError is returned by New when the Config is invalid.
+// It lists all the problems found in the Config.
+type ConfigError struct {
+	Problems []ConfigProblem
+}
+
+func (e *ConfigError) Error() string {
+	s := make([]string, len(e.Problems))
+	for x, p := range e.Problems {
+		s[x] = p.String()
+	}
+	return "client: invalid config: " + strings.Join(s, "; ")
+}
+
+// Validate checks the Config returning a *ConfigError listing all problems found, if any.
+func (c Config) Validate() (err error) {
+	var problems []ConfigProblem
+	add := func(field, problem, hint string) {
+		problems = append(problems, ConfigProblem{Field: field, Problem: problem, Hint: hint})
+	}
+
+	if c.RestURL == "" && c.WsURL == "" {
+		add("RestURL", "no server url provided",
+			"set RestURL and/or WsURL to the Data Streams endpoints provided with your credentials")
+	}
+	for _, u := range []struct{ field, value string }{{"RestURL", c.RestURL}, {"WsURL", c.WsURL}} {
+		if problem, hint := validateURL(u.value); problem != "" {
+			add(u.field, problem, hint)
+		}
+	}
+
+	for _, k := range []struct{ field, value string }{{"ApiKey", c.ApiKey}, {"ApiSecret", c.ApiSecret}} {
+		switch {
+		case k.value == "" && c.Credentials != nil:
+		case k.value == "":
+			add(k.field, "empty value", "set the "+k.field+" provided with your Data Streams credentials")
+		case strings.TrimSpace(k.value) != k.value:
+			add(k.field, "leading or trailing whitespace",
+				"check the value was copied without surrounding spaces or new lines")
+		case strings.Trim(k.value, `"'`) != k.value:
+			add(k.field, "surrounding quotes", "remove the quotes copied along with the value")
+		}
+	}
+	if c.ApiKey != "" && c.ApiKey == c.ApiSecret {
+		add("ApiSecret", "same value as ApiKey", "the api secret is a different value than the api key")
+	}
+
+	if c.WsMaxReconnect < 0 {
+		add("WsMaxReconnect", fmt.Sprintf("negative value %d", c.WsMaxReconnect),
+			"set a positive number of attempts or 0 for the default")
+	}
+
+	if c.WsReadTimeout < 0 {
+		add("WsReadTimeout", fmt.Sprintf("negative value %s", c.WsReadTimeout),
+			"set a positive timeout or 0 to disable it")
+	}
+
+	if c.ConnStatusWindow < 0 {
+		add("ConnStatusWindow", fmt.Sprintf("negative value %s", c.ConnStatusWindow),
+			"set a positive window or 0 to disable it")
+	}
+
+	if c.WsOriginPolicy < OriginPolicyNone || c.WsOriginPolicy > OriginPolicySticky {
+		add("WsOriginPolicy", fmt.Sprintf("invalid value %d", c.WsOriginPolicy),
+			"use one of the OriginPolicy values")
+	}
+	if c.WsOriginPolicy == OriginPolicyPreferred && len(c.WsPreferredOrigins) == 0 {
+		add("WsPreferredOrigins", "no preferred origins",
+			"list the preferred origins or use another WsOriginPolicy")
+	}
+
+	if c.HandlerConcurrency < 0 {
+		add("HandlerConcurrency", fmt.Sprintf("negative value %d", c.HandlerConcurrency),
+			"set a positive number of workers or 0 for the default")
+	}
+
+	if c.UnknownFields < UnknownFieldsIgnore || c.UnknownFields > UnknownFieldsCapture {
+		add("UnknownFields", fmt.Sprintf("invalid value %d", c.UnknownFields),
+			"use UnknownFieldsIgnore, UnknownFieldsStrict or UnknownFieldsCapture")
+	}
+
+	if c.RestConcurrency < 0 {
+		add("RestConcurrency", fmt.Sprintf("negative value %d", c.RestConcurrency),
+			"set a positive number of requests or 0 for no limit")
+	}
+
+	if c.RestMaxResponseSize < 0 {
+		add("RestMaxResponseSize", fmt.Sprintf("negative value %d", c.RestMaxResponseSize),
+			"set a positive size in bytes or 0 for no limit")
+	}
+
+	if c.RestMaxDecodedSize < 0 {
+		add("RestMaxDecodedSize", fmt.Sprintf("negative value %d", c.RestMaxDecodedSize),
+			"set a positive size in bytes or 0 for the default")
+	}
+
+	if c.LatestReportCache != nil && c.LatestReportCache.TTL < 0 {
+		add("LatestReportCache", fmt.Sprintf("negative TTL %s", c.LatestReportCache.TTL),
+			"set a positive duration or 0 for the default")
+	}
+
+	if c.Verification != nil && c.Verification.verifyFunc() == nil {
+		add("Verification", "no Verify function or Signers",
+			"set Verification.Verify or Verification.Signers, or leave Verification nil")
+	}
+	if c.Verification != nil && c.Verification.F < 0 {
+		add("Verification", fmt.Sprintf("negative F %d", c.Verification.F), "set the fault tolerance of the DON")
+	}
+
+	if c.Latency != nil && (c.Latency.Alpha < 0 || c.Latency.Alpha > 1) {
+		add("Latency", fmt.Sprintf("Alpha %g out of range", c.Latency.Alpha),
+			"set a weight between 0 and 1 or 0 for the default")
+	}
+
+	if c.Alerts != nil && (c.Alerts.MaxDedupRatio < 0 || c.Alerts.MaxDedupRatio > 1) {
+		add("Alerts", fmt.Sprintf("MaxDedupRatio %g out of range", c.Alerts.MaxDedupRatio),
+			"set a ratio between 0 and 1 or 0 to disable the alert")
+	}
+
+	if c.Redirects == RedirectResign && len(c.RedirectHosts) == 0 {
+		add("RedirectHosts", "no hosts allowed for re-signed redirects",
+			"list the redirect hosts in RedirectHosts or use another Redirects policy")
+	}
+
+	if len(problems) > 0 {
+		return &ConfigError{Problems: problems}
+	}
+	return nil
+}
+
+// validateURL returns the problem with the server url, if any.
+func validateURL(s string) (problem, hint string) {
+	if s == "" {
+		return "", ""
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Sprintf("error parsing url: %s", err), "use an absolute url such as https://host"
+	}
+
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+	case "":
+		return "missing url scheme", "prefix the url with https://"
+	default:
+		return fmt.Sprintf("unsupported url scheme %q", u.Scheme), "use one of http, https, ws or wss"
+	}
+
+	if u.Host == "" {
+		return "missing url host", "use an absolute url such as https://host"
+	}
+	return "", ""
+}
@@ -0,0 +1,119 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        Config
+		wantFields []string
+	}{
+		{
+			name: "valid config",
+			cfg: Config{
+				ApiKey:    "mykey",
+				ApiSecret: "mysecret",
+				RestURL:   "https://rest.domain.link",
+				WsURL:     "wss://ws.domain.link",
+			},
+		},
+		{
+			name: "credentials provider",
+			cfg: Config{
+				Credentials: &mockCredentialsProvider{keys: []string{"mykey"}},
+				RestURL:     "https://rest.domain.link",
+			},
+		},
+		{
+			name:       "empty config",
+			cfg:        Config{},
+			wantFields: []string{"RestURL", "ApiKey", "ApiSecret"},
+		},
+		{
+			name: "missing scheme and host",
+			cfg: Config{
+				ApiKey:    "mykey",
+				ApiSecret: "mysecret",
+				RestURL:   "rest.domain.link",
+				WsURL:     "https://",
+			},
+			wantFields: []string{"RestURL", "WsURL"},
+		},
+		{
+			name: "suspicious credentials",
+			cfg: Config{
+				ApiKey:         "mykey\n",
+				ApiSecret:      `"mysecret"`,
+				RestURL:        "https://rest.domain.link",
+				WsMaxReconnect: -1,
+			},
+			wantFields: []string{"ApiKey", "ApiSecret", "WsMaxReconnect"},
+		},
+		{
+			name: "same key and secret",
+			cfg: Config{
+				ApiKey:    "mykey",
+				ApiSecret: "mykey",
+				WsURL:     "ftp://ws.domain.link",
+			},
+			wantFields: []string{"WsURL", "ApiSecret"},
+		},
+		{
+			name: "invalid limits",
+			cfg: Config{
+				ApiKey:           "mykey",
+				ApiSecret:        "mysecret",
+				RestURL:          "https://rest.domain.link",
+				WsReadTimeout:    -time.Second,
+				ConnStatusWindow: -time.Second,
+				UnknownFields:    UnknownFields(5),
+				RestConcurrency:  -1,
+			},
+			wantFields: []string{"WsReadTimeout", "ConnStatusWindow", "UnknownFields", "RestConcurrency"},
+		},
+		{
+			name: "invalid policies",
+			cfg: Config{
+				ApiKey:         "mykey",
+				ApiSecret:      "mysecret",
+				RestURL:        "https://rest.domain.link",
+				WsOriginPolicy: OriginPolicyPreferred,
+				Alerts:         &AlertConfig{MaxDedupRatio: 90},
+				Latency:        &LatencyConfig{Alpha: 2},
+			},
+			wantFields: []string{"WsPreferredOrigins", "Latency", "Alerts"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var cfgErr *ConfigError
+			if !errors.As(err, &cfgErr) {
+				t.Fatalf("Validate() error = %v, want *ConfigError", err)
+			}
+
+			var fields []string
+			for _, p := range cfgErr.Problems {
+				if p.Hint == "" {
+					t.Errorf("Validate() problem %s has no hint", p)
+				}
+				fields = append(fields, p.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("Validate() fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package streams
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+)
+
+const defaultVerificationWorkers = 4
+
+// VerificationStatus is the verification result of a Stream report.
+type VerificationStatus int
+
+const (
+	// VerificationNone is the status of reports when verification is disabled.
+	VerificationNone VerificationStatus = iota
+	// Verified reports passed verification.
+	Verified
+	// Unverified reports failed verification, see ReportResponse.VerificationErr.
+	Unverified
+)
+
+func (v VerificationStatus) String() string {
+	switch v {
+	case VerificationNone:
+		return "none"
+	case Verified:
+		return "verified"
+	case Unverified:
+		return "unverified"
+	default:
+		return "unknown"
+	}
+}
+
+// VerificationConfig enables Stream reports verification.
+// Each delivered report is annotated as Verified or Unverified with the verification error.
+// Reports are verified concurrently by a pool of workers and delivered in the order they were received.
+type VerificationConfig struct {
+	// Verify verifies the report, typically its signatures. When nil the report signatures are verified
+	// offchain against Signers with report.VerifySignatures.
+	Verify func(ctx context.Context, r *ReportResponse) error
+	// Signers are the Ethereum addresses of the DON signers verifying the report signatures when Verify is nil.
+	Signers [][20]byte
+	// F is the fault tolerance of the DON, reports must be signed by more than F of Signers.
+	F int
+	// Workers is the number of reports verified concurrently, defaults to 4.
+	Workers int
+}
+
+// verifyFunc returns the Verify function, or the signatures verification against the signers when not set.
+// It returns nil when neither is configured.
+func (c VerificationConfig) verifyFunc() func(ctx context.Context, r *ReportResponse) error {
+	if c.Verify != nil {
+		return c.Verify
+	}
+	if len(c.Signers) == 0 {
+		return nil
+	}
+	signers, f := c.Signers, c.F
+	return func(_ context.Context, r *ReportResponse) error {
+		return report.VerifySignatures(r.FullReport, signers, f)
+	}
+}
+
+func (c VerificationConfig) workers() int {
+	if c.Workers <= 0 {
+		return defaultVerificationWorkers
+	}
+	return c.Workers
+}
+
+// verifier limits the number of concurrent verifications to the configured workers.
+type verifier struct {
+	verifyFn func(ctx context.Context, r *ReportResponse) error
+	sem      chan struct{}
+}
+
+func newVerifier(cfg VerificationConfig) *verifier {
+	return &verifier{verifyFn: cfg.verifyFunc(), sem: make(chan struct{}, cfg.workers())}
+}
+
+// verify annotates the report with its verification status.
+func (v *verifier) verify(ctx context.Context, r *ReportResponse) {
+	select {
+	case <-ctx.Done():
+		return
+	case v.sem <- struct{}{}:
+	}
+	defer func() { <-v.sem }()
+
+	// verification needs the decoded full report, decoded once before the report is delivered
+	r.FullReport, r.fullReportHex = r.FullReportBytes(), nil
+	if err := v.verifyFn(ctx, r); err != nil {
+		r.Verification, r.VerificationErr = Unverified, err
+		return
+	}
+	r.Verification = Verified
+}
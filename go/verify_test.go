@@ -0,0 +1,194 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"math/rand"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+	v3 "github.com/smartcontractkit/data-streams-sdk/go/report/v3"
+	"nhooyr.io/websocket"
+)
+
+func TestClient_StreamVerification(t *testing.T) {
+	const numReports = 20
+
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		conn, err := websocket.Accept(
+			w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover},
+		)
+		if err != nil {
+			t.Fatalf("error accepting connection: %s", err)
+		}
+		defer func() { _ = conn.CloseNow() }()
+
+		for x := 1; x <= numReports; x++ {
+			b, err := json.Marshal(&message{&ReportResponse{FeedID: feed1, ObservationsTimestamp: uint64(x)}})
+			if err != nil {
+				t.Errorf("failed to serialize message: %s", err)
+			}
+
+			if err = conn.Write(context.Background(), websocket.MessageBinary, b); err != nil {
+				return
+			}
+		}
+
+		for conn.Ping(context.Background()) == nil {
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+
+	errOdd := errors.New("odd report")
+	streamsClient.(*client).config.Verification = &VerificationConfig{
+		Workers: 3,
+		Verify: func(_ context.Context, r *ReportResponse) error {
+			time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond) //nolint:gosec
+			if r.ObservationsTimestamp%2 == 1 {
+				return errOdd
+			}
+			return nil
+		},
+	}
+
+	sub, err := extendedStream(streamsClient.Stream(context.Background(), []feed.ID{feed1}))
+	if err != nil {
+		t.Fatalf("error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	for x := 1; x <= numReports; x++ {
+		r, err := sub.Read(context.Background())
+		if err != nil {
+			t.Fatalf("error reading report %s", err)
+		}
+
+		if r.ObservationsTimestamp != uint64(x) {
+			t.Fatalf("Read() observations timestamp = %d, want %d", r.ObservationsTimestamp, x)
+		}
+
+		want, wantErr := Verified, error(nil)
+		if x%2 == 1 {
+			want, wantErr = Unverified, errOdd
+		}
+		if r.Verification != want || !errors.Is(r.VerificationErr, wantErr) {
+			t.Errorf("Read() report %d verification = %s, %v, want %s, %v", x, r.Verification, r.VerificationErr, want, wantErr)
+		}
+	}
+
+	if got := sub.StatsV2().Counter(StatValidationFailed); got != numReports/2 {
+		t.Errorf("StatsV2() validation failed = %d, want %d", got, numReports/2)
+	}
+}
+
+func TestVerifyReports(t *testing.T) {
+	const numReports = 100
+
+	reports := make([]*ReportResponse, numReports)
+	for x := range reports {
+		reports[x] = &ReportResponse{FeedID: feed1, ObservationsTimestamp: uint64(x)}
+	}
+
+	errOdd := errors.New("odd report")
+	var active, maxActive atomic.Int32
+	cfg := VerificationConfig{
+		Workers: 3,
+		Verify: func(_ context.Context, r *ReportResponse) error {
+			n := active.Add(1)
+			defer active.Add(-1)
+			for m := maxActive.Load(); n > m && !maxActive.CompareAndSwap(m, n); m = maxActive.Load() {
+			}
+			time.Sleep(time.Duration(rand.Intn(2)) * time.Millisecond) //nolint:gosec
+			if r.ObservationsTimestamp%2 == 1 {
+				return errOdd
+			}
+			return nil
+		},
+	}
+
+	s, err := VerifyReports(context.Background(), reports, cfg)
+	if err != nil {
+		t.Fatalf("VerifyReports() error = %s", err)
+	}
+	if s.Verified != numReports/2 || s.Unverified != numReports/2 || s.Skipped != 0 {
+		t.Errorf("VerifyReports() = %s, want %d verified and unverified", s, numReports/2)
+	}
+	if s.Failures[errOdd.Error()] != numReports/2 {
+		t.Errorf("VerifyReports() failures = %v", s.Failures)
+	}
+	if maxActive.Load() > 3 {
+		t.Errorf("VerifyReports() concurrent verifications = %d, want at most 3", maxActive.Load())
+	}
+	for _, r := range reports {
+		want := Verified
+		if r.ObservationsTimestamp%2 == 1 {
+			want = Unverified
+		}
+		if r.Verification != want {
+			t.Errorf("report %d verification = %s, want %s", r.ObservationsTimestamp, r.Verification, want)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s, err = VerifyReports(ctx, []*ReportResponse{{FeedID: feed1}}, cfg)
+	if !errors.Is(err, context.Canceled) || s.Skipped != 1 {
+		t.Errorf("VerifyReports() = %s, %v, want 1 skipped, %v", s, err, context.Canceled)
+	}
+
+	if _, err = VerifyReports(context.Background(), reports, VerificationConfig{}); err == nil {
+		t.Errorf("VerifyReports() without Verify error = nil, want error")
+	}
+}
+
+func TestVerifyReports_Signers(t *testing.T) {
+	data := v3.Data{FeedID: feed1, ObservationsTimestamp: 1, NativeFee: big.NewInt(1), LinkFee: big.NewInt(1),
+		BenchmarkPrice: big.NewInt(100), Bid: big.NewInt(99), Ask: big.NewInt(101)}
+	blob, err := v3.Encode(&data)
+	if err != nil {
+		t.Fatalf("error encoding data: %s", err)
+	}
+	unsigned, err := report.Encode(&report.Report[v3.Data]{Data: data, ReportBlob: blob})
+	if err != nil {
+		t.Fatalf("error encoding report: %s", err)
+	}
+
+	reports := []*ReportResponse{
+		{FeedID: feed1, FullReport: unsigned, ObservationsTimestamp: 1},
+		{FeedID: feed1, FullReport: []byte("invalid"), ObservationsTimestamp: 2},
+	}
+	cfg := VerificationConfig{Signers: [][20]byte{{0x01}}, F: 0}
+	s, err := VerifyReports(context.Background(), reports, cfg)
+	if err != nil {
+		t.Fatalf("VerifyReports() error = %s", err)
+	}
+	if s.Unverified != 2 {
+		t.Errorf("VerifyReports() = %s, want 2 unverified", s)
+	}
+	if !errors.Is(reports[0].VerificationErr, report.ErrNoQuorum) {
+		t.Errorf("unsigned report verification error = %v, want %v", reports[0].VerificationErr, report.ErrNoQuorum)
+	}
+	if reports[1].VerificationErr == nil || errors.Is(reports[1].VerificationErr, report.ErrNoQuorum) {
+		t.Errorf("invalid report verification error = %v, want a decoding error", reports[1].VerificationErr)
+	}
+
+	if err = (Config{Verification: &VerificationConfig{F: 1}}).Validate(); err == nil {
+		t.Errorf("Validate() without Verify or Signers error = nil, want error")
+	}
+}
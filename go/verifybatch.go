@@ -0,0 +1,73 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// VerificationSummary aggregates the results of VerifyReports.
+type VerificationSummary struct {
+	Verified   int            // Number of reports passing verification
+	Unverified int            // Number of reports failing verification
+	Skipped    int            // Number of reports not verified before the context was done
+	Failures   map[string]int // Number of reports failing verification by error message
+	Duration   time.Duration  // Duration of the verification
+}
+
+func (s VerificationSummary) String() string {
+	return fmt.Sprintf("verified: %d, unverified: %d, skipped: %d, failures: %v, duration: %s",
+		s.Verified, s.Unverified, s.Skipped, s.Failures, s.Duration.Truncate(time.Millisecond))
+}
+
+// VerifyReports verifies the reports, for example historical reports fetched with GetReportPage,
+// with cfg.Verify, or the signatures verification against cfg.Signers when not set, called concurrently
+// by cfg.Workers workers. Each report is annotated as Verified
+// or Unverified with its verification error, as Stream reports are when Config.Verification is set.
+// Reports not verified before the context is done keep VerificationNone, the context error is returned.
+func VerifyReports(ctx context.Context, reports []*ReportResponse, cfg VerificationConfig) (s VerificationSummary, err error) {
+	if cfg.verifyFunc() == nil {
+		return s, fmt.Errorf("client: verification function or signers not provided")
+	}
+
+	start := time.Now()
+	v := newVerifier(cfg)
+	next := make(chan *ReportResponse)
+	var wg sync.WaitGroup
+	for x := 0; x < cfg.workers(); x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range next {
+				v.verify(ctx, r)
+			}
+		}()
+	}
+
+schedule:
+	for _, r := range reports {
+		select {
+		case <-ctx.Done():
+			break schedule
+		case next <- r:
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	s.Failures = map[string]int{}
+	for _, r := range reports {
+		switch r.Verification {
+		case Verified:
+			s.Verified++
+		case Unverified:
+			s.Unverified++
+			s.Failures[r.VerificationErr.Error()]++
+		default:
+			s.Skipped++
+		}
+	}
+	s.Duration = time.Since(start)
+	return s, ctx.Err()
+}
@@ -0,0 +1,67 @@
+package streams
+
+import (
+	"runtime/debug"
+	"sync"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+	"github.com/smartcontractkit/data-streams-sdk/go/report"
+)
+
+const modulePath = "github.com/smartcontractkit/data-streams-sdk/go"
+
+// CommitHash is the commit the SDK was built from, set at build time with:
+//
+//	go build -ldflags "-X github.com/smartcontractkit/data-streams-sdk/go.CommitHash=<commit>"
+//
+// Defaults to the vcs revision of the binary build info when the SDK is the main module, empty otherwise.
+var CommitHash = buildRevision()
+
+// Version returns the SDK module version from the binary build info,
+// "(devel)" when the SDK is the main module and "unknown" if not available.
+// Version is included in the User-Agent header of the client requests.
+func Version() string {
+	return buildVersion()
+}
+
+// SupportedSchemaVersions returns the report schema versions decodable by this SDK, oldest first.
+func SupportedSchemaVersions() []feed.FeedVersion {
+	return report.SupportedVersions()
+}
+
+// userAgent returns the User-Agent header value of the client requests.
+func userAgent() string {
+	return "data-streams-sdk-go/" + Version()
+}
+
+var buildVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
+})
+
+func buildRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Path != modulePath {
+		return ""
+	}
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" {
+			return s.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package streams
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/data-streams-sdk/go/feed"
+)
+
+func TestVersion(t *testing.T) {
+	if v := Version(); v == "" {
+		t.Errorf("Version() is empty")
+	}
+
+	want := []feed.FeedVersion{feed.FeedVersion1, feed.FeedVersion2, feed.FeedVersion3, feed.FeedVersion4}
+	if got := SupportedSchemaVersions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SupportedSchemaVersions() = %v, want %v", got, want)
+	}
+}
+
+func TestClient_UserAgent(t *testing.T) {
+	var got string
+	ms := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(userAgentHeader)
+		_, _ = w.Write([]byte(`{"feeds":[]}`))
+	})
+	defer ms.Close()
+
+	streamsClient, err := ms.Client()
+	if err != nil {
+		t.Fatalf("error creating client %s", err)
+	}
+	if _, err = streamsClient.GetFeeds(context.Background()); err != nil {
+		t.Fatalf("GetFeeds() error = %s", err)
+	}
+
+	if !strings.HasPrefix(got, "data-streams-sdk-go/") || !strings.HasSuffix(got, Version()) {
+		t.Errorf("User-Agent = %q, want the sdk version %s", got, Version())
+	}
+}
@@ -0,0 +1,28 @@
+package streams
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+)
+
+// statusPolicyViolation is the websocket close code of policy violations.
+const statusPolicyViolation = 1008
+
+// websocketConn is a Stream websocket connection implemented with the websocket library selected
+// at build time: nhooyr.io/websocket by default, github.com/gorilla/websocket with the gorilla build tag.
+type websocketConn interface {
+	// Read reads the next data message into buf until the context is done.
+	Read(ctx context.Context, buf *bytes.Buffer) error
+	// Ping sends a ping and waits for its pong until the context is done.
+	Ping(ctx context.Context) error
+	// CloseNow closes the connection without a close handshake.
+	CloseNow() error
+}
+
+// wsDialOptions are the options of a websocket dial.
+type wsDialOptions struct {
+	Header     http.Header  // Handshake request headers
+	HTTPClient *http.Client // Client whose transport is used to dial
+	Host       string       // Handshake request Host header, the url host if empty
+}
@@ -0,0 +1,109 @@
+//go:build gorilla
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// gorillaConn implements websocketConn with github.com/gorilla/websocket.
+// Pongs are received by the connection reader, Stream connections are always being read.
+type gorillaConn struct {
+	conn   *websocket.Conn
+	pingMu sync.Mutex
+	pongs  chan struct{}
+}
+
+func (c *gorillaConn) Read(ctx context.Context, buf *bytes.Buffer) (err error) {
+	if err = c.conn.SetReadDeadline(time.Time{}); err != nil {
+		return err
+	}
+	// unblock the reader once the context is done, so read timeouts are reported as context errors
+	stop := context.AfterFunc(ctx, func() { _ = c.conn.SetReadDeadline(time.Now()) })
+	defer stop()
+
+	_, r, err := c.conn.NextReader()
+	if err != nil {
+		return err
+	}
+	_, err = buf.ReadFrom(r)
+	return err
+}
+
+func (c *gorillaConn) Ping(ctx context.Context) (err error) {
+	c.pingMu.Lock()
+	defer c.pingMu.Unlock()
+
+	// discard the pong of a previous timed out ping
+	select {
+	case <-c.pongs:
+	default:
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultWSConnectTimeout)
+	}
+	if err = c.conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+		return err
+	}
+	select {
+	case <-c.pongs:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *gorillaConn) CloseNow() error {
+	return c.conn.Close()
+}
+
+// dialWebsocket dials the websocket url, the handshake response is returned along with dial errors when available.
+func dialWebsocket(ctx context.Context, url string, opts wsDialOptions) (conn websocketConn, resp *http.Response, err error) {
+	d := &websocket.Dialer{EnableCompression: true, HandshakeTimeout: defaultWSConnectTimeout}
+	if t, ok := opts.HTTPClient.Transport.(*http.Transport); ok {
+		d.NetDialContext, d.TLSClientConfig, d.Proxy = t.DialContext, t.TLSClientConfig, t.Proxy
+	}
+	header := opts.Header.Clone()
+	if opts.Host != "" {
+		header.Set("Host", opts.Host)
+	}
+
+	// gorilla only dials ws and wss urls
+	if rest, ok := strings.CutPrefix(url, "http"); ok {
+		url = "ws" + rest
+	}
+	c, resp, err := d.DialContext(ctx, url, header)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	gc := &gorillaConn{conn: c, pongs: make(chan struct{}, 1)}
+	c.SetPongHandler(func(string) error {
+		select {
+		case gc.pongs <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+	return gc, resp, nil
+}
+
+// closeStatus returns the close code and reason of a connection closed by the peer with a close frame.
+// gorilla reports connections dropped without a close frame as abnormal closures, these are not close frames.
+func closeStatus(err error) (code int, reason string, ok bool) {
+	var ce *websocket.CloseError
+	if !errors.As(err, &ce) || ce.Code == websocket.CloseAbnormalClosure {
+		return 0, "", false
+	}
+	return ce.Code, ce.Text, true
+}
@@ -0,0 +1,49 @@
+//go:build !gorilla
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+
+	"nhooyr.io/websocket"
+)
+
+// nhooyrConn implements websocketConn with nhooyr.io/websocket.
+type nhooyrConn struct {
+	*websocket.Conn
+}
+
+func (c nhooyrConn) Read(ctx context.Context, buf *bytes.Buffer) (err error) {
+	_, r, err := c.Reader(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = buf.ReadFrom(r)
+	return err
+}
+
+// dialWebsocket dials the websocket url, the handshake response is returned along with dial errors when available.
+func dialWebsocket(ctx context.Context, url string, opts wsDialOptions) (conn websocketConn, resp *http.Response, err error) {
+	c, resp, err := websocket.Dial(ctx, url, &websocket.DialOptions{
+		HTTPHeader:      opts.Header,
+		CompressionMode: websocket.CompressionContextTakeover,
+		HTTPClient:      opts.HTTPClient,
+		Host:            opts.Host,
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	return nhooyrConn{c}, resp, nil
+}
+
+// closeStatus returns the close code and reason of a connection closed by the peer with a close frame.
+func closeStatus(err error) (code int, reason string, ok bool) {
+	var ce websocket.CloseError
+	if !errors.As(err, &ce) {
+		return 0, "", false
+	}
+	return int(ce.Code), ce.Reason, true
+}